@@ -0,0 +1,52 @@
+package com.govinci.app
+
+import android.content.Context
+import android.view.View
+import android.widget.FrameLayout
+import org.json.JSONObject
+import java.util.WeakHashMap
+
+// Cameras stands in for CameraView (core/camera.go) until the host has a
+// camera: the view is an empty frame for the overlay, and every command a
+// CameraController sends ("camera" system events) is answered through the
+// view's onError, as is an active view when it first shows, so Go never
+// waits for a capture or a scan that cannot come.
+class Cameras(private val context: Context, private val apply: (patches: String) -> Unit) {
+    private val props = WeakHashMap<View, JSONObject>()
+    private val controllers = mutableMapOf<String, View>()
+
+    fun owns(view: View) = props.containsKey(view)
+
+    fun create(p: JSONObject): View {
+        val view = FrameLayout(context)
+        update(view, p)
+        if (p.optBoolean("active", true)) view.post { fail(view) }
+        return view
+    }
+
+    fun update(view: View, p: JSONObject) {
+        props[view] = p
+        controllers.values.remove(view)
+        p.optString("controller").takeIf { it.isNotEmpty() }?.let { controllers[it] = view }
+    }
+
+    fun forget(view: View) {
+        controllers.values.remove(view)
+    }
+
+    fun handleCommand(data: JSONObject) {
+        val view = controllers[data.optString("controller")] ?: return
+        fail(view, data.optString("action"))
+    }
+
+    private fun fail(view: View, action: String = "") {
+        val callback = props[view]?.optString("onError").orEmpty()
+        if (callback.isEmpty() || !view.isAttachedToWindow) return
+        val message = if (action.isEmpty()) UNAVAILABLE else "$action: $UNAVAILABLE"
+        apply(GovinciBridge.TriggerTextCallback(callback, message))
+    }
+
+    private companion object {
+        const val UNAVAILABLE = "camera is not available on Android yet"
+    }
+}
@@ -0,0 +1,90 @@
+package com.govinci.app
+
+import android.app.AlertDialog
+import android.content.Context
+import android.graphics.Color
+import android.text.SpannableString
+import android.text.style.ForegroundColorSpan
+import android.view.WindowManager
+import android.widget.EditText
+import android.widget.FrameLayout
+import org.json.JSONObject
+
+// Dialogs shows the requests of the dialog package as AlertDialogs: an alert,
+// a confirmation, a prompt with an EditText set up like an Input, and an
+// action sheet as a list. The answer goes back as the "dialog" system event,
+// {id, confirmed, value, index}, followed by a render. Dismissing a dialog
+// cancels it; each one is answered once.
+class Dialogs(
+    private val context: Context,
+    private val keyboard: (JSONObject) -> Int,
+    private val render: () -> Unit,
+) {
+    fun show(data: JSONObject) {
+        val id = data.optString("id")
+        var answered = false
+        val answer = { confirmed: Boolean, value: String, index: Int ->
+            if (!answered) {
+                answered = true
+                val result = JSONObject()
+                    .put("id", id)
+                    .put("confirmed", confirmed)
+                    .put("value", value)
+                    .put("index", index)
+                GovinciBridge.ReceiveSystemEvent("dialog", result.toString())
+                render()
+            }
+        }
+
+        val builder = AlertDialog.Builder(context)
+            .setOnCancelListener { answer(false, "", -1) }
+        data.optString("title").takeIf { it.isNotEmpty() }?.let { builder.setTitle(it) }
+        val cancel = data.optString("cancel")
+
+        if (data.optString("kind") == "actionSheet") {
+            val actions = data.optJSONArray("actions")
+            val labels = Array<CharSequence>(actions?.length() ?: 0) { i ->
+                val action = actions!!.getJSONObject(i)
+                val label = action.optString("label")
+                if (!action.optBoolean("destructive")) label
+                else SpannableString(label).apply { setSpan(ForegroundColorSpan(DESTRUCTIVE), 0, length, 0) }
+            }
+            builder.setItems(labels) { _, which -> answer(true, "", which) }
+            if (cancel.isNotEmpty()) builder.setNegativeButton(cancel) { _, _ -> answer(false, "", -1) }
+            builder.show()
+            return
+        }
+
+        data.optString("message").takeIf { it.isNotEmpty() }?.let { builder.setMessage(it) }
+        val field = if (data.optString("kind") != "prompt") null else EditText(context).apply {
+            inputType = keyboard(data)
+            setText(data.optString("value"))
+            hint = data.optString("placeholder")
+            setSelection(text.length)
+        }
+        if (field != null) {
+            val padding = (20 * context.resources.displayMetrics.density).toInt()
+            builder.setView(FrameLayout(context).apply {
+                setPadding(padding, 0, padding, 0)
+                addView(field)
+            })
+        }
+        builder.setPositiveButton(data.optString("confirm")) { _, _ ->
+            answer(true, field?.text?.toString().orEmpty(), -1)
+        }
+        if (cancel.isNotEmpty()) builder.setNegativeButton(cancel) { _, _ -> answer(false, "", -1) }
+
+        val dialog = builder.show()
+        if (data.optBoolean("destructive")) {
+            dialog.getButton(AlertDialog.BUTTON_POSITIVE)?.setTextColor(DESTRUCTIVE)
+        }
+        if (field != null) {
+            dialog.window?.setSoftInputMode(WindowManager.LayoutParams.SOFT_INPUT_STATE_VISIBLE)
+            field.requestFocus()
+        }
+    }
+
+    private companion object {
+        val DESTRUCTIVE = Color.parseColor("#FF3B30")
+    }
+}
@@ -0,0 +1,107 @@
+package com.govinci.app
+
+import android.animation.ObjectAnimator
+import android.animation.ValueAnimator
+import android.content.Context
+import android.content.res.ColorStateList
+import android.os.Handler
+import android.os.Looper
+import android.os.SystemClock
+import android.view.Gravity
+import android.view.View
+import android.view.ViewGroup
+import android.widget.FrameLayout
+import android.widget.LinearLayout
+import android.widget.ProgressBar
+import org.json.JSONObject
+import java.util.WeakHashMap
+import kotlin.math.roundToInt
+
+// Feedback renders the feedback components of core/feedback.go. ProgressBar
+// and Spinner are platform ProgressBars tinted with the style's text color
+// (the fill and ring Go builds for the web are left out); a Skeleton pulses
+// its alpha; Badge and Avatar pin every child after the first to a corner;
+// a Snackbar calls onDismiss once its duration is up, restarting the timer
+// only when the key changes.
+class Feedback(
+    private val context: Context,
+    private val color: (String) -> Int?,
+    private val apply: (patches: String) -> Unit,
+) {
+    private val props = WeakHashMap<View, JSONObject>()
+    private val snacks = WeakHashMap<View, String>()
+    private val handler = Handler(Looper.getMainLooper())
+
+    fun owns(view: View) = props.containsKey(view)
+
+    fun create(type: String, p: JSONObject, style: JSONObject?): View {
+        val view: View = when (type) {
+            "ProgressBar" -> ProgressBar(context, null, android.R.attr.progressBarStyleHorizontal).apply { max = TICKS }
+            "Spinner" -> ProgressBar(context).apply { isIndeterminate = true }
+            "Skeleton" -> View(context).apply {
+                ObjectAnimator.ofFloat(this, View.ALPHA, 1f, 0.4f).apply {
+                    duration = 750
+                    repeatMode = ValueAnimator.REVERSE
+                    repeatCount = ValueAnimator.INFINITE
+                    start()
+                }
+            }
+            "Badge" -> Overlay(context, Gravity.TOP or Gravity.END)
+            "Avatar" -> Overlay(context, Gravity.BOTTOM or Gravity.END, centered = true)
+            else -> LinearLayout(context).apply {
+                orientation = LinearLayout.HORIZONTAL
+                gravity = Gravity.CENTER_VERTICAL
+            }
+        }
+        if (view is ProgressBar) {
+            style?.optString("TextColor")?.let(color)?.let {
+                view.progressTintList = ColorStateList.valueOf(it)
+                view.indeterminateTintList = ColorStateList.valueOf(it)
+            }
+        }
+        update(view, p)
+        return view
+    }
+
+    fun update(view: View, p: JSONObject) {
+        props[view] = p
+        if (view is ProgressBar && view.max == TICKS) {
+            view.isIndeterminate = p.optBoolean("indeterminate")
+            view.progress = (p.optDouble("progress", 0.0) * TICKS).roundToInt()
+        }
+        val key = p.optString("key")
+        if (p.has("onDismiss") && snacks[view] != key) {
+            snacks[view] = key
+            handler.removeCallbacksAndMessages(view)
+            val duration = p.optLong("duration")
+            if (duration > 0) {
+                handler.postAtTime({ dismiss(view) }, view, SystemClock.uptimeMillis() + duration)
+            }
+        }
+    }
+
+    // Read at fire time: the callback ID changes with every render.
+    private fun dismiss(view: View) {
+        if (!view.isAttachedToWindow) return
+        val callback = props[view]?.optString("onDismiss").orEmpty()
+        if (callback.isEmpty()) return
+        apply(GovinciBridge.TriggerCallback(callback))
+    }
+
+    // The first child is the content, centered for an Avatar's initials.
+    private class Overlay(context: Context, private val corner: Int, private val centered: Boolean = false) : FrameLayout(context) {
+        override fun onViewAdded(child: View) {
+            super.onViewAdded(child)
+            val gravity = when {
+                indexOfChild(child) > 0 -> corner
+                centered -> Gravity.CENTER
+                else -> return
+            }
+            child.layoutParams = LayoutParams(ViewGroup.LayoutParams.WRAP_CONTENT, ViewGroup.LayoutParams.WRAP_CONTENT, gravity)
+        }
+    }
+
+    private companion object {
+        const val TICKS = 1000 // a determinate bar's resolution
+    }
+}
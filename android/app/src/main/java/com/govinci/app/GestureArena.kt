@@ -0,0 +1,250 @@
+package com.govinci.app
+
+import android.graphics.Rect
+import android.os.Handler
+import android.os.Looper
+import android.view.MotionEvent
+import android.view.VelocityTracker
+import android.view.View
+import org.json.JSONObject
+import java.util.WeakHashMap
+import kotlin.math.abs
+import kotlin.math.hypot
+
+// GestureArena mirrors the web runtime's arena. The activity shows it every
+// touch before the views see it. On ACTION_DOWN every view with recognizers
+// under the finger joins; once the finger moves past the slop, the innermost
+// view whose recognizers match the movement wins, the view hierarchy gets an
+// ACTION_CANCEL and the arena consumes the rest of the gesture. Without a
+// winner the touch goes on to the views, so a vertical drag over a row that
+// only swipes horizontally still scrolls its ScrollView.
+class GestureArena(private val send: (callback: String, payload: JSONObject) -> Unit) {
+    private val recognizers = WeakHashMap<View, JSONObject>()
+    private val handler = Handler(Looper.getMainLooper())
+
+    private var members = listOf<View>()
+    private var winner: View? = null
+    private var kind: String? = null
+    private var startX = 0f
+    private var startY = 0f
+    private var lastX = 0f
+    private var lastY = 0f
+    private var pinchDistance = 1f
+    private var scale = 1f
+    private var velocity: VelocityTracker? = null
+    private var lastTap: Pair<View, Long>? = null
+    private var pendingTap: Runnable? = null
+
+    fun attach(view: View, gestures: JSONObject?) {
+        if (gestures == null) recognizers.remove(view) else recognizers[view] = gestures
+    }
+
+    // Returns true while the arena owns the touch.
+    fun onTouchEvent(ev: MotionEvent): Boolean {
+        when (ev.actionMasked) {
+            MotionEvent.ACTION_DOWN -> start(ev)
+            MotionEvent.ACTION_POINTER_DOWN -> if (members.isNotEmpty() && ev.pointerCount == 2) startPinch(ev)
+            MotionEvent.ACTION_MOVE -> return move(ev)
+            MotionEvent.ACTION_UP -> return end(ev, cancelled = false)
+            MotionEvent.ACTION_CANCEL -> return end(ev, cancelled = true)
+        }
+        return winner != null
+    }
+
+    private fun start(ev: MotionEvent) {
+        reset()
+        val x = ev.rawX.toInt()
+        val y = ev.rawY.toInt()
+        members = recognizers.keys
+            .filter { it.isShown && it.isEnabled && screenRect(it).contains(x, y) }
+            .sortedByDescending { depth(it) }
+        if (members.isEmpty()) return
+        startX = ev.rawX
+        startY = ev.rawY
+        lastX = startX
+        lastY = startY
+        velocity = VelocityTracker.obtain().also { it.addMovement(ev) }
+        handler.postDelayed(longPress, LONG_PRESS)
+    }
+
+    private val longPress = Runnable {
+        if (winner == null && claim { if (it.has("longPress")) "longPress" else null }) {
+            emit("longPress", "ended")
+        }
+    }
+
+    // claim hands the gesture to the innermost member for which match returns
+    // a recognizer kind; without one the arena lets go.
+    private fun claim(match: (JSONObject) -> String?): Boolean {
+        handler.removeCallbacks(longPress)
+        for (view in members) {
+            val k = recognizers[view]?.let(match) ?: continue
+            winner = view
+            kind = k
+            view.parent?.requestDisallowInterceptTouchEvent(true)
+            return true
+        }
+        reset()
+        return false
+    }
+
+    private fun startPinch(ev: MotionEvent) {
+        pinchDistance = distance(ev).coerceAtLeast(1f)
+        if (kind == "pan") emit("pan", "cancelled")
+        if (claim { if (it.has("pinch")) "pinch" else null }) emit("pinch", "began")
+    }
+
+    private fun move(ev: MotionEvent): Boolean {
+        if (members.isEmpty()) return false
+        velocity?.addMovement(ev)
+        if (kind == "pinch" && ev.pointerCount >= 2) {
+            scale = distance(ev) / pinchDistance
+            lastX = (rawX(ev, 0) + rawX(ev, 1)) / 2
+            lastY = (rawY(ev, 0) + rawY(ev, 1)) / 2
+            emit("pinch", "changed")
+            return true
+        }
+        lastX = ev.rawX
+        lastY = ev.rawY
+        if (winner == null) {
+            val dx = lastX - startX
+            val dy = lastY - startY
+            if (hypot(dx, dy) < SLOP * density()) return false
+            val horizontal = abs(dx) > abs(dy)
+            val claimed = claim {
+                when {
+                    it.has("pan") -> "pan"
+                    horizontal && (it.has("swipeleft") || it.has("swiperight")) -> "swipe"
+                    !horizontal && (it.has("swipeup") || it.has("swipedown")) -> "swipe"
+                    else -> null
+                }
+            }
+            if (!claimed) return false
+            if (kind == "pan") emit("pan", "began")
+            return true
+        }
+        if (kind == "pan") emit("pan", "changed")
+        return true
+    }
+
+    private fun end(ev: MotionEvent, cancelled: Boolean): Boolean {
+        if (members.isEmpty()) return false
+        handler.removeCallbacks(longPress)
+        velocity?.addMovement(ev)
+        val owned = winner != null
+        when {
+            winner == null -> if (!cancelled) tap()
+            kind == "pan" || kind == "pinch" -> emit(kind!!, if (cancelled) "cancelled" else "ended")
+            kind == "swipe" && !cancelled -> {
+                val (vx, vy) = currentVelocity()
+                val horizontal = abs(vx) > abs(vy)
+                val direction = if (horizontal) (if (vx < 0) "left" else "right") else (if (vy < 0) "up" else "down")
+                val speed = (if (horizontal) abs(vx) else abs(vy)) / density()
+                if (speed >= SWIPE_VELOCITY && recognizers[winner]?.has("swipe$direction") == true) {
+                    emit("swipe$direction", "ended", direction)
+                }
+            }
+        }
+        reset()
+        return owned
+    }
+
+    // A tap goes to the innermost member with tap or doubleTap; when it has
+    // both, the single tap waits to see whether a second one follows. Taps
+    // never consume the touch, so buttons underneath still click.
+    private fun tap() {
+        val view = members.firstOrNull { recognizers[it]?.let { g -> g.has("tap") || g.has("doubleTap") } == true } ?: return
+        val gestures = recognizers[view] ?: return
+        winner = view
+        val now = System.currentTimeMillis()
+        val previous = lastTap
+        if (gestures.has("doubleTap") && previous != null && previous.first === view && now - previous.second < DOUBLE_TAP) {
+            pendingTap?.let { handler.removeCallbacks(it) }
+            lastTap = null
+            emit("doubleTap", "ended")
+            return
+        }
+        if (!gestures.has("doubleTap")) {
+            emit("tap", "ended")
+            return
+        }
+        lastTap = view to now
+        if (gestures.has("tap")) {
+            val payload = payload("ended")
+            val callback = gestures.getString("tap")
+            pendingTap = Runnable { lastTap = null; send(callback, payload) }.also {
+                handler.postDelayed(it, DOUBLE_TAP)
+            }
+        }
+    }
+
+    private fun emit(name: String, state: String, direction: String? = null) {
+        val callback = winner?.let { recognizers[it] }?.optString(name).orEmpty()
+        if (callback.isEmpty()) return
+        send(callback, payload(state).apply { direction?.let { put("direction", it) } })
+    }
+
+    private fun payload(state: String): JSONObject {
+        val d = density()
+        val rect = winner?.let { screenRect(it) } ?: Rect()
+        val (vx, vy) = currentVelocity()
+        return JSONObject()
+            .put("state", state)
+            .put("x", (lastX - rect.left) / d)
+            .put("y", (lastY - rect.top) / d)
+            .put("translationX", (lastX - startX) / d)
+            .put("translationY", (lastY - startY) / d)
+            .put("velocityX", vx / d)
+            .put("velocityY", vy / d)
+            .put("scale", scale)
+    }
+
+    private fun currentVelocity(): Pair<Float, Float> {
+        val tracker = velocity ?: return 0f to 0f
+        tracker.computeCurrentVelocity(1000)
+        return tracker.xVelocity to tracker.yVelocity
+    }
+
+    private fun reset() {
+        handler.removeCallbacks(longPress)
+        velocity?.recycle()
+        velocity = null
+        members = emptyList()
+        winner = null
+        kind = null
+        scale = 1f
+    }
+
+    private fun density(): Float =
+        members.firstOrNull()?.resources?.displayMetrics?.density ?: 1f
+
+    private fun distance(ev: MotionEvent): Float =
+        hypot(rawX(ev, 0) - rawX(ev, 1), rawY(ev, 0) - rawY(ev, 1))
+
+    // getRawX(index) needs API 29; offset the pointer by the event's origin.
+    private fun rawX(ev: MotionEvent, i: Int) = ev.getX(i) + ev.rawX - ev.x
+    private fun rawY(ev: MotionEvent, i: Int) = ev.getY(i) + ev.rawY - ev.y
+
+    private fun screenRect(view: View): Rect {
+        val loc = IntArray(2)
+        view.getLocationOnScreen(loc)
+        return Rect(loc[0], loc[1], loc[0] + view.width, loc[1] + view.height)
+    }
+
+    private fun depth(view: View): Int {
+        var d = 0
+        var p = view.parent
+        while (p != null) {
+            d++
+            p = p.parent
+        }
+        return d
+    }
+
+    private companion object {
+        const val SLOP = 10f               // dp before a press becomes a drag
+        const val LONG_PRESS = 500L        // ms
+        const val DOUBLE_TAP = 300L        // ms between the two taps
+        const val SWIPE_VELOCITY = 300f    // dp/s along the swipe axis
+    }
+}
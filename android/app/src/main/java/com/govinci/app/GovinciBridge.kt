@@ -9,4 +9,9 @@ object GovinciBridge {
     external fun RenderInitial(): String
     external fun TriggerCallback(id: String): String
     external fun TriggerTextCallback(id: String, value: String): String
+    external fun TriggerEvent(id: String, payload: String): String
+    external fun PollSystemEvents(): String
+    external fun ReceiveSystemEvent(name: String, payload: String)
+    external fun IsDirty(): Boolean
+    external fun RenderAgain(): String
 }
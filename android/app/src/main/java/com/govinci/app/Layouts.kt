@@ -0,0 +1,307 @@
+package com.govinci.app
+
+import android.content.Context
+import android.view.Gravity
+import android.view.View
+import android.view.ViewGroup
+import android.widget.FrameLayout
+import org.json.JSONArray
+import org.json.JSONObject
+import kotlin.math.max
+import kotlin.math.min
+import kotlin.math.roundToInt
+
+// Layouts renders the containers of core/grid.go. A Grid sizes its columns
+// from the tracks and places children left to right, a GridItem (Span)
+// covering several cells, each row as tall as its tallest cell; a Wrap
+// breaks its children into lines; a Stack is a FrameLayout whose children
+// all take the same gravity. Gaps come from the style's Gap, RowGap and
+// ColumnGap, in dp.
+class Layouts(private val context: Context) {
+    fun owns(view: View) = view is GridView || view is GridCell || view is FlowView || view is StackView
+
+    fun create(type: String, p: JSONObject, style: JSONObject?): View {
+        val view: View = when (type) {
+            "Grid" -> GridView(context)
+            "GridItem" -> GridCell(context)
+            "Wrap" -> FlowView(context)
+            else -> StackView(context)
+        }
+        update(view, p)
+        style?.let { applyStyle(view, it) }
+        return view
+    }
+
+    fun update(view: View, p: JSONObject) {
+        when (view) {
+            is GridView -> {
+                view.tracks = p.optJSONArray("columns") ?: JSONArray()
+                view.horizontal = p.optString("justifyItems")
+                view.vertical = p.optString("alignItems")
+                view.requestLayout()
+            }
+            is GridCell -> {
+                view.columns = max(p.optInt("columns", 1), 1)
+                view.rows = max(p.optInt("rows", 1), 1)
+                (view.parent as? View)?.requestLayout()
+            }
+            is StackView -> view.align(p.optString("justifyItems"), p.optString("alignItems"))
+        }
+    }
+
+    fun applyStyle(view: View, style: JSONObject) {
+        val density = context.resources.displayMetrics.density
+        val gap = style.optDouble("Gap", 0.0)
+        val rowGap = (style.optDouble("RowGap", 0.0).takeIf { it > 0 } ?: gap) * density
+        val columnGap = (style.optDouble("ColumnGap", 0.0).takeIf { it > 0 } ?: gap) * density
+        when (view) {
+            is GridView -> {
+                view.rowGap = rowGap.roundToInt()
+                view.columnGap = columnGap.roundToInt()
+            }
+            is FlowView -> {
+                view.rowGap = rowGap.roundToInt()
+                view.columnGap = columnGap.roundToInt()
+                view.justify = style.optString("JustifyContent")
+            }
+        }
+        view.requestLayout()
+    }
+}
+
+// GridCell is a Span: a child covering columns x rows cells of its Grid.
+class GridCell(context: Context) : FrameLayout(context) {
+    var columns = 1
+    var rows = 1
+}
+
+class GridView(context: Context) : ViewGroup(context) {
+    var tracks = JSONArray()
+    var horizontal = ""
+    var vertical = ""
+    var rowGap = 0
+    var columnGap = 0
+
+    private class Cell(val view: View, val row: Int, val column: Int, val rows: Int, val columns: Int)
+
+    private val cells = mutableListOf<Cell>()
+    private var widths = IntArray(0)
+    private var lefts = IntArray(0)
+    private var heights = IntArray(0)
+    private var tops = IntArray(0)
+
+    override fun onMeasure(widthSpec: Int, heightSpec: Int) {
+        val width = MeasureSpec.getSize(widthSpec)
+        sizeColumns(max(0, width - paddingLeft - paddingRight))
+        place()
+
+        heights = IntArray(cells.maxOfOrNull { it.row + it.rows } ?: 0)
+        // single-row cells first; a taller spanning cell grows its last row
+        for (cell in cells.sortedBy { it.rows }) {
+            val mode = if (stretches(horizontal)) MeasureSpec.EXACTLY else MeasureSpec.AT_MOST
+            cell.view.measure(
+                MeasureSpec.makeMeasureSpec(spanWidth(cell), mode),
+                MeasureSpec.makeMeasureSpec(0, MeasureSpec.UNSPECIFIED),
+            )
+            val covered = spanHeight(cell)
+            if (cell.view.measuredHeight > covered) {
+                heights[cell.row + cell.rows - 1] += cell.view.measuredHeight - covered
+            }
+        }
+        tops = IntArray(heights.size)
+        for (i in 1 until heights.size) tops[i] = tops[i - 1] + heights[i - 1] + rowGap
+
+        val content = heights.sum() + rowGap * max(0, heights.size - 1)
+        setMeasuredDimension(width, resolveSize(content + paddingTop + paddingBottom, heightSpec))
+    }
+
+    override fun onLayout(changed: Boolean, l: Int, t: Int, r: Int, b: Int) {
+        for (cell in cells) {
+            val cellWidth = spanWidth(cell)
+            val cellHeight = spanHeight(cell)
+            if (stretches(vertical) && cell.view.measuredHeight != cellHeight) {
+                cell.view.measure(
+                    MeasureSpec.makeMeasureSpec(cell.view.measuredWidth, MeasureSpec.EXACTLY),
+                    MeasureSpec.makeMeasureSpec(cellHeight, MeasureSpec.EXACTLY),
+                )
+            }
+            val x = paddingLeft + lefts[cell.column] + offset(horizontal, cellWidth - cell.view.measuredWidth)
+            val y = paddingTop + tops[cell.row] + offset(vertical, cellHeight - cell.view.measuredHeight)
+            cell.view.layout(x, y, x + cell.view.measuredWidth, y + cell.view.measuredHeight)
+        }
+    }
+
+    // Fixed tracks take their width, Flexible ones share what is left; an
+    // Adaptive track makes as many columns of at least its min as fit.
+    private fun sizeColumns(inner: Int) {
+        val density = resources.displayMetrics.density
+        val list = (0 until tracks.length()).map { tracks.getJSONObject(it) }
+        val adaptive = list.firstOrNull { it.optString("kind") == "adaptive" }
+        widths = when {
+            adaptive != null -> {
+                val least = max(1, (adaptive.optDouble("min", 0.0) * density).roundToInt())
+                val count = max(1, (inner + columnGap) / (least + columnGap))
+                var each = (inner - columnGap * (count - 1)) / count
+                val most = (adaptive.optDouble("max", 0.0) * density).roundToInt()
+                if (most > 0) each = min(each, most)
+                IntArray(count) { each }
+            }
+            list.isEmpty() -> intArrayOf(inner)
+            else -> {
+                val fixed = list.filter { it.optString("kind") == "fixed" }
+                    .sumOf { (it.optDouble("size", 0.0) * density).roundToInt() }
+                val shares = list.filter { it.optString("kind") != "fixed" }.sumOf { it.optDouble("size", 1.0) }
+                val free = max(0, inner - fixed - columnGap * (list.size - 1))
+                IntArray(list.size) { i ->
+                    val track = list[i]
+                    if (track.optString("kind") == "fixed") (track.optDouble("size", 0.0) * density).roundToInt()
+                    else (free * track.optDouble("size", 1.0) / shares).toInt()
+                }
+            }
+        }
+        lefts = IntArray(widths.size)
+        for (i in 1 until widths.size) lefts[i] = lefts[i - 1] + widths[i - 1] + columnGap
+    }
+
+    // Row-major auto-placement: each child takes the first free spot after
+    // the previous one that fits its span.
+    private fun place() {
+        cells.clear()
+        val count = widths.size
+        val taken = mutableListOf<BooleanArray>()
+        fun free(row: Int, column: Int, rows: Int, columns: Int): Boolean {
+            while (taken.size < row + rows) taken.add(BooleanArray(count))
+            return (row until row + rows).all { r -> (column until column + columns).none { taken[r][it] } }
+        }
+        var row = 0
+        var column = 0
+        for (i in 0 until childCount) {
+            val child = getChildAt(i)
+            if (child.visibility == GONE) continue
+            val span = child as? GridCell
+            val columns = min(span?.columns ?: 1, count)
+            val rows = span?.rows ?: 1
+            while (column + columns > count || !free(row, column, rows, columns)) {
+                column++
+                if (column + columns > count) {
+                    column = 0
+                    row++
+                }
+            }
+            for (r in row until row + rows) for (c in column until column + columns) taken[r][c] = true
+            cells.add(Cell(child, row, column, rows, columns))
+            column += columns
+        }
+    }
+
+    private fun spanWidth(cell: Cell) =
+        (cell.column until cell.column + cell.columns).sumOf { widths[it] } + columnGap * (cell.columns - 1)
+
+    private fun spanHeight(cell: Cell) =
+        (cell.row until cell.row + cell.rows).sumOf { heights[it] } + rowGap * (cell.rows - 1)
+}
+
+class FlowView(context: Context) : ViewGroup(context) {
+    var rowGap = 0
+    var columnGap = 0
+    var justify = ""
+
+    private val lines = mutableListOf<List<View>>()
+
+    override fun onMeasure(widthSpec: Int, heightSpec: Int) {
+        val limit = if (MeasureSpec.getMode(widthSpec) == MeasureSpec.UNSPECIFIED) Int.MAX_VALUE
+        else MeasureSpec.getSize(widthSpec) - paddingLeft - paddingRight
+        lines.clear()
+        var line = mutableListOf<View>()
+        var x = 0
+        var widest = 0
+        for (i in 0 until childCount) {
+            val child = getChildAt(i)
+            if (child.visibility == GONE) continue
+            child.measure(
+                MeasureSpec.makeMeasureSpec(max(0, limit), MeasureSpec.AT_MOST),
+                MeasureSpec.makeMeasureSpec(0, MeasureSpec.UNSPECIFIED),
+            )
+            if (line.isNotEmpty() && x + columnGap + child.measuredWidth > limit) {
+                lines.add(line)
+                line = mutableListOf()
+                x = 0
+            }
+            x += (if (line.isEmpty()) 0 else columnGap) + child.measuredWidth
+            widest = max(widest, x)
+            line.add(child)
+        }
+        if (line.isNotEmpty()) lines.add(line)
+
+        val content = lines.sumOf { l -> l.maxOf { it.measuredHeight } } + rowGap * max(0, lines.size - 1)
+        setMeasuredDimension(
+            resolveSize(widest + paddingLeft + paddingRight, widthSpec),
+            resolveSize(content + paddingTop + paddingBottom, heightSpec),
+        )
+    }
+
+    // Lines follow JustifyContent; children are centered in their line.
+    override fun onLayout(changed: Boolean, l: Int, t: Int, r: Int, b: Int) {
+        val inner = r - l - paddingLeft - paddingRight
+        var y = paddingTop
+        for (line in lines) {
+            val height = line.maxOf { it.measuredHeight }
+            val used = line.sumOf { it.measuredWidth } + columnGap * (line.size - 1)
+            val extra = max(0, inner - used)
+            var gap = columnGap
+            var x = paddingLeft + when (justify) {
+                "center" -> extra / 2
+                "flex-end" -> extra
+                else -> 0
+            }
+            if (justify == "space-between" && line.size > 1) gap += extra / (line.size - 1)
+            for (child in line) {
+                val top = y + (height - child.measuredHeight) / 2
+                child.layout(x, top, x + child.measuredWidth, top + child.measuredHeight)
+                x += child.measuredWidth + gap
+            }
+            y += height + rowGap
+        }
+    }
+}
+
+class StackView(context: Context) : FrameLayout(context) {
+    private var horizontal = "center"
+    private var vertical = "center"
+
+    fun align(horizontal: String, vertical: String) {
+        this.horizontal = horizontal
+        this.vertical = vertical
+        for (i in 0 until childCount) arrange(getChildAt(i))
+    }
+
+    override fun onViewAdded(child: View) {
+        super.onViewAdded(child)
+        arrange(child)
+    }
+
+    private fun arrange(child: View) {
+        val gravity = when (horizontal) {
+            "start" -> Gravity.START
+            "end" -> Gravity.END
+            else -> Gravity.CENTER_HORIZONTAL
+        } or when (vertical) {
+            "start" -> Gravity.TOP
+            "end" -> Gravity.BOTTOM
+            else -> Gravity.CENTER_VERTICAL
+        }
+        child.layoutParams = LayoutParams(
+            if (horizontal == "stretch") ViewGroup.LayoutParams.MATCH_PARENT else ViewGroup.LayoutParams.WRAP_CONTENT,
+            if (vertical == "stretch") ViewGroup.LayoutParams.MATCH_PARENT else ViewGroup.LayoutParams.WRAP_CONTENT,
+            gravity,
+        )
+    }
+}
+
+private fun stretches(alignment: String) = alignment == "stretch" || alignment.isEmpty()
+
+private fun offset(alignment: String, room: Int) = when (alignment) {
+    "center" -> room / 2
+    "end" -> room
+    else -> 0
+}
@@ -1,12 +1,22 @@
 package com.govinci.app
 
+import android.content.res.Configuration
 import android.os.Bundle
+import android.view.Choreographer
+import android.view.MotionEvent
+import android.view.WindowManager
 import android.widget.FrameLayout
 import androidx.appcompat.app.AppCompatActivity
+import androidx.core.view.ViewCompat
+import androidx.core.view.WindowInsetsCompat
+import org.json.JSONObject
+import java.io.IOException
 
 class MainActivity : AppCompatActivity() {
     private lateinit var root: FrameLayout
     private lateinit var renderer: PatchRenderer
+    private var arenaOwnsTouch = false
+    private var keyboardHeight = 0
 
     override fun onCreate(savedInstanceState: Bundle?) {
         super.onCreate(savedInstanceState)
@@ -15,7 +25,96 @@ class MainActivity : AppCompatActivity() {
         renderer = PatchRenderer(this)
 
         GovinciBridge.InitApp()
+        reportAssets()
+        reportAppearance(resources.configuration)
+        reportWindowSize(resources.configuration)
+        watchKeyboard()
         val initial = GovinciBridge.RenderInitial()
         renderer.renderInitial(initial, root)
+        Choreographer.getInstance().postFrameCallback(frameLoop)
+    }
+
+    // Go marks its root context dirty on its own (timers, finished
+    // thumbnails, system events); re-render on the next frame when it has,
+    // like the web runtime's checkLoop.
+    private val frameLoop = object : Choreographer.FrameCallback {
+        override fun doFrame(frameTimeNanos: Long) {
+            if (GovinciBridge.IsDirty()) {
+                renderer.applyPatches(GovinciBridge.RenderAgain())
+            }
+            Choreographer.getInstance().postFrameCallback(this)
+        }
+    }
+
+    override fun onDestroy() {
+        Choreographer.getInstance().removeFrameCallback(frameLoop)
+        super.onDestroy()
+    }
+
+    // Touches go through the gesture arena first. When a recognizer wins, the
+    // views get one ACTION_CANCEL and nothing more of that touch.
+    override fun dispatchTouchEvent(ev: MotionEvent): Boolean {
+        val owned = renderer.gestures.onTouchEvent(ev)
+        if (owned && !arenaOwnsTouch) {
+            val cancel = MotionEvent.obtain(ev).apply { action = MotionEvent.ACTION_CANCEL }
+            super.dispatchTouchEvent(cancel)
+            cancel.recycle()
+        }
+        val finished = ev.actionMasked == MotionEvent.ACTION_UP || ev.actionMasked == MotionEvent.ACTION_CANCEL
+        arenaOwnsTouch = owned && !finished
+        return owned || super.dispatchTouchEvent(ev)
+    }
+
+    // Requires android:configChanges="uiMode|screenSize|screenLayout|orientation"
+    // so the activity is not recreated.
+    override fun onConfigurationChanged(newConfig: Configuration) {
+        super.onConfigurationChanged(newConfig)
+        reportAppearance(newConfig)
+        reportWindowSize(newConfig)
+        renderer.applyPatches(GovinciBridge.RenderAgain())
+    }
+
+    // The manifest `govinci assets -target android` writes into the APK's
+    // assets, and the screen density, so asset:// URLs resolve to the hashed
+    // @2x/@3x files under file:///android_asset/.
+    private fun reportAssets() {
+        val data = JSONObject().put("density", resources.displayMetrics.density.toDouble())
+        try {
+            data.put("manifest", assets.open("govinci-assets.json").bufferedReader().use { it.readText() })
+        } catch (e: IOException) {
+            // not bundled: names resolve as they are
+        }
+        GovinciBridge.ReceiveSystemEvent("assets", data.toString())
+    }
+
+    private fun reportAppearance(config: Configuration) {
+        val night = config.uiMode and Configuration.UI_MODE_NIGHT_MASK
+        val scheme = if (night == Configuration.UI_MODE_NIGHT_YES) "dark" else "light"
+        GovinciBridge.ReceiveSystemEvent("appearance", "{\"colorScheme\":\"$scheme\"}")
+    }
+
+    // The window shrinks around the keyboard (adjustResize), which also keeps
+    // the focused input of a ScrollView in view; Go only learns the height.
+    private fun watchKeyboard() {
+        window.setSoftInputMode(WindowManager.LayoutParams.SOFT_INPUT_ADJUST_RESIZE)
+        ViewCompat.setOnApplyWindowInsetsListener(window.decorView) { view, insets ->
+            val ime = insets.getInsets(WindowInsetsCompat.Type.ime()).bottom
+            val bars = insets.getInsets(WindowInsetsCompat.Type.systemBars()).bottom
+            val height = (maxOf(ime - bars, 0) / resources.displayMetrics.density).toInt()
+            if (height != keyboardHeight) {
+                keyboardHeight = height
+                GovinciBridge.ReceiveSystemEvent("keyboard", "{\"height\":$height,\"resized\":true}")
+                view.post { renderer.applyPatches(GovinciBridge.RenderAgain()) }
+            }
+            ViewCompat.onApplyWindowInsets(view, insets)
+        }
+    }
+
+    // Sizes are in dp, which line up with the CSS pixel breakpoints.
+    private fun reportWindowSize(config: Configuration) {
+        GovinciBridge.ReceiveSystemEvent(
+            "resize",
+            "{\"width\":${config.screenWidthDp},\"height\":${config.screenHeightDp}}",
+        )
     }
 }
@@ -1,17 +1,55 @@
 package com.govinci.app
 
+import android.animation.TimeInterpolator
 import android.content.Context
+import android.content.res.ColorStateList
+import android.graphics.Color
+import android.graphics.Paint
+import android.graphics.Typeface
+import android.graphics.drawable.GradientDrawable
+import android.graphics.drawable.StateListDrawable
+import android.os.Build
+import android.os.SystemClock
+import android.util.TypedValue
+import android.view.Gravity
 import android.view.View
 import android.view.ViewGroup
+import android.view.ViewPropertyAnimator
+import android.view.animation.LinearInterpolator
+import android.view.animation.PathInterpolator
 import android.widget.Button
+import android.widget.EditText
 import android.widget.FrameLayout
 import android.widget.LinearLayout
+import android.widget.ScrollView
 import android.widget.TextView
+import android.widget.Toast
 import org.json.JSONArray
 import org.json.JSONObject
+import java.util.WeakHashMap
 
 class PatchRenderer(private val context: Context) {
     private val viewMap = mutableMapOf<String, View>()
+    private val fonts = mutableMapOf<String, Typeface>()
+    private val exits = WeakHashMap<View, JSONObject>()
+    private val animateKeys = WeakHashMap<View, String>()
+    private val lazyProps = WeakHashMap<View, JSONObject>()
+    private val scrollTargets = mutableMapOf<String, View>()
+    private val measured = WeakHashMap<View, Boolean>()
+    private val scrollPending = WeakHashMap<View, Boolean>()
+
+    private val textFields = TextFields(context) { applyPatches(it) }
+    private val controls = SelectionControls(context) { applyPatches(it) }
+    private val pickers = Pickers(context) { applyPatches(it) }
+    private val feedback = Feedback(context, { cssColor(it) }) { applyPatches(it) }
+    private val layouts = Layouts(context)
+    private val cameras = Cameras(context) { applyPatches(it) }
+    private val dialogs = Dialogs(context, { textFields.inputType("Input", it) }) { applyPatches(GovinciBridge.RenderAgain()) }
+
+    // Shown every touch by MainActivity before the views see it.
+    val gestures = GestureArena { callback, payload ->
+        applyPatches(GovinciBridge.TriggerEvent(callback, JSONObject().put("value", payload).toString()))
+    }
 
     fun renderInitial(json: String, container: FrameLayout) {
         val node = JSONObject(json)
@@ -28,33 +66,80 @@ class PatchRenderer(private val context: Context) {
             val target = p.getString("TargetID")
             when (type) {
                 "replace" -> {
-                    val changes = p.getJSONObject("Changes")
-                    val newView = createView(changes, target)
                     val old = viewMap[target] ?: continue
                     val parent = old.parent as? ViewGroup ?: continue
-                    val index = parent.indexOfChild(old)
-                    parent.removeViewAt(index)
-                    parent.addView(newView, index)
+                    forget(target)
+                    val newView = createView(p.getJSONObject("Changes"), target)
+                    parent.addView(newView, parent.indexOfChild(old))
+                    removeView(old)
                 }
                 "remove", "remove-child" -> {
                     val view = viewMap[target] ?: continue
-                    val parent = view.parent as? ViewGroup ?: continue
-                    parent.removeView(view)
-                    viewMap.remove(target)
+                    forget(target)
+                    removeView(view)
                 }
                 "add-child" -> {
-                    val changes = p.getJSONObject("Changes")
-                    val parent = viewMap[target] as? ViewGroup ?: continue
-                    val path = "$target/${parent.childCount}"
-                    val child = createView(changes, path)
-                    parent.addView(child)
+                    // the target is the new child's path; the diff only adds
+                    // children after the existing ones
+                    val parent = viewMap[target.substringBeforeLast("/")]?.let { childHost(it) } ?: continue
+                    parent.addView(createView(p.getJSONObject("Changes"), target))
                 }
                 "update-props" -> {
                     val changes = p.getJSONObject("Changes")
                     val view = viewMap[target] ?: continue
                     updateProps(view, changes)
+                    applyMotionProps(view, changes, false)
+                    gestures.attach(view, changes.optJSONObject("gestures"))
+                    if (lazyProps.containsKey(view)) applyLazyProps(view, changes)
+                    if (view is ScrollContainer) applyScrollProps(view, changes)
+                    if (view is EditText) textFields.update(view, changes)
+                    if (controls.owns(view)) controls.update(view, changes)
+                    if (pickers.owns(view)) pickers.update(view, changes)
+                    if (feedback.owns(view)) feedback.update(view, changes)
+                    if (layouts.owns(view)) layouts.update(view, changes)
+                    if (cameras.owns(view)) cameras.update(view, changes)
+                }
+                "update-style" -> {
+                    val view = viewMap[target] ?: continue
+                    applyStateStyles(view, p.getJSONObject("Changes"))
+                    if (layouts.owns(view)) layouts.applyStyle(view, p.getJSONObject("Changes"))
+                }
+            }
+        }
+        handleSystemEvents()
+    }
+
+    // Commands Go queued while handling the event (scrolling...).
+    private fun handleSystemEvents() {
+        val events = JSONArray(GovinciBridge.PollSystemEvents())
+        for (i in 0 until events.length()) {
+            val event = events.getJSONObject(i)
+            val data = event.optJSONObject("data") ?: continue
+            when (event.optString("name")) {
+                "focus" -> textFields.handleFocusEvent(data)
+                "dialog" -> dialogs.show(data)
+                "camera" -> cameras.handleCommand(data)
+                "toast" -> {
+                    val length = if (data.optInt("duration") > 2000) Toast.LENGTH_LONG else Toast.LENGTH_SHORT
+                    Toast.makeText(context, data.optString("message"), length).show()
+                }
+                "scroll" -> {
+                    val target = scrollTargets[data.optString("target")] ?: continue
+                    val animated = data.optBoolean("animated")
+                    val x = (data.optDouble("x", 0.0) * density()).toInt()
+                    val y = (data.optDouble("y", 0.0) * density()).toInt()
+                    when (target) {
+                        is ScrollContainer -> when (data.optString("action")) {
+                            "scrollTo" -> target.moveTo(x, y, animated)
+                            "scrollToEnd" -> target.moveToEnd(animated)
+                        }
+                        is ScrollView -> {
+                            val end = (target.getChildAt(0)?.height ?: 0)
+                            val top = if (data.optString("action") == "scrollToEnd") end else y
+                            if (animated) target.smoothScrollTo(0, top) else target.scrollTo(0, top)
+                        }
+                    }
                 }
-                // update-style omitted for brevity
             }
         }
     }
@@ -65,36 +150,453 @@ class PatchRenderer(private val context: Context) {
         val view: View = when (type) {
             "Text" -> TextView(context).apply {
                 text = props?.optString("content", "")
+                node.optJSONObject("Style")?.let { applyTextStyle(this, it) }
             }
             "Button" -> Button(context).apply {
                 text = props?.optString("label", "")
                 val cb = props?.optString("onClick")
-                if (cb != null) {
+                if (!cb.isNullOrEmpty()) {
                     setOnClickListener {
                         val patch = GovinciBridge.TriggerCallback(cb)
                         applyPatches(patch)
                     }
                 }
             }
-            "Column" -> LinearLayout(context).apply { orientation = LinearLayout.VERTICAL }
-            "Row" -> LinearLayout(context).apply { orientation = LinearLayout.HORIZONTAL }
+            "Theme" -> FrameLayout(context).also {
+                props?.optJSONArray("fonts")?.let { registerFonts(it) }
+            }
+            "Column", "RadioGroup" -> LinearLayout(context).apply { orientation = LinearLayout.VERTICAL }
+            "Row", "SegmentedControl", "Stepper" -> LinearLayout(context).apply { orientation = LinearLayout.HORIZONTAL }
+            "LazyList" -> lazyList()
+            "Input", "InputPassword", "NumericInput", "TextArea" -> textFields.create(type, props ?: JSONObject())
+            "Checkbox", "Switch", "Radio", "Slider", "RangeSlider" -> controls.create(type, props ?: JSONObject())
+            "Select", "DatePicker", "TimePicker", "DateRangePicker" -> pickers.create(type, props ?: JSONObject())
+            "ProgressBar", "Spinner", "Skeleton", "Snackbar", "Badge", "Avatar" ->
+                feedback.create(type, props ?: JSONObject(), node.optJSONObject("Style"))
+            "Grid", "GridItem", "Wrap", "Stack" -> layouts.create(type, props ?: JSONObject(), node.optJSONObject("Style"))
+            "CameraView" -> cameras.create(props ?: JSONObject())
+            "Scroll" -> scrollContainer(props?.optString("direction").orEmpty().ifEmpty { "vertical" })
             else -> FrameLayout(context)
         }
+        if (type == "LazyList" || type == "LazyItem") applyLazyProps(view, props ?: JSONObject())
+        if (view is ScrollContainer) applyScrollProps(view, props ?: JSONObject())
+        node.optJSONObject("Style")?.let { applyStateStyles(view, it) }
+        applyStateProps(view, props ?: JSONObject())
+        applyMotionProps(view, props ?: JSONObject(), true)
+        gestures.attach(view, props?.optJSONObject("gestures"))
         view.tag = path
         viewMap[path] = view
         val children = node.optJSONArray("Children")
-        if (children != null && view is ViewGroup) {
+        val host = childHost(view)
+        if (children != null && host != null) {
             for (i in 0 until children.length()) {
                 val child = children.getJSONObject(i)
                 val childView = createView(child, "$path/$i")
-                view.addView(childView)
+                host.addView(childView)
             }
         }
         return view
     }
 
-    private fun updateProps(view: View, props: JSONObject) {
+    // A LazyList is a ScrollView around the FrameLayout its items live in; a
+    // Scroll keeps its children in its content LinearLayout.
+    private fun childHost(view: View): ViewGroup? = when {
+        view is ScrollContainer -> view.content
+        lazyProps.containsKey(view) && view is ScrollView -> view.getChildAt(0) as? ViewGroup
+        else -> view as? ViewGroup
+    }
+
+    private fun scrollContainer(direction: String): ScrollContainer {
+        val scroll = ScrollContainer(context, direction)
+        scroll.onScrolled = { scrolled(scroll) }
+        scroll.refresh.setOnRefreshListener {
+            val callback = scroll.props.optString("onRefresh")
+            if (callback.isNotEmpty()) applyPatches(GovinciBridge.TriggerCallback(callback))
+        }
+        scroll.content.addOnLayoutChangeListener { _, _, _, _, _, _, _, _, _ -> checkEndReached(scroll) }
+        return scroll
+    }
+
+    // The spinner follows "refreshing"; SwipeRefreshLayout starts it on its
+    // own when pulled and Go confirms with refreshing=true.
+    private fun applyScrollProps(scroll: ScrollContainer, props: JSONObject) {
+        scroll.props = props
+        props.optString("controller").takeIf { it.isNotEmpty() }?.let { scrollTargets[it] = scroll }
+        scroll.refresh.isEnabled = props.has("onRefresh")
+        scroll.refresh.isRefreshing = props.optBoolean("refreshing")
+    }
+
+    // Reports the position at most once per scrollThrottle ms, trailing edge
+    // included, so the last position always reaches Go.
+    private fun scrolled(scroll: ScrollContainer) {
+        if (scroll.props.has("onScroll") && !scroll.reportPending) {
+            val wait = scroll.props.optLong("scrollThrottle", 0) - (SystemClock.uptimeMillis() - scroll.lastReport)
+            scroll.reportPending = true
+            scroll.postDelayed({
+                scroll.reportPending = false
+                reportScrollMetrics(scroll)
+            }, maxOf(wait, 0))
+        }
+        checkEndReached(scroll)
+    }
+
+    private fun reportScrollMetrics(scroll: ScrollContainer) {
+        scroll.lastReport = SystemClock.uptimeMillis()
+        val callback = scroll.props.optString("onScroll")
+        if (callback.isEmpty() || !scroll.isAttachedToWindow) return
+        val d = density()
+        val value = JSONObject()
+            .put("x", scroll.offsetX() / d)
+            .put("y", scroll.offsetY() / d)
+            .put("contentWidth", scroll.content.width / d)
+            .put("contentHeight", scroll.content.height / d)
+            .put("viewportWidth", scroll.width / d)
+            .put("viewportHeight", scroll.height / d)
+        applyPatches(GovinciBridge.TriggerEvent(callback, JSONObject().put("value", value).toString()))
+    }
+
+    // onEndReached fires once per content size, when the end comes within
+    // endThreshold of the viewport.
+    private fun checkEndReached(scroll: ScrollContainer) {
+        val callback = scroll.props.optString("onEndReached")
+        if (callback.isEmpty() || scroll.height == 0) return
+        val horizontal = scroll.props.optString("direction") == "horizontal"
+        val size = if (horizontal) scroll.content.width else scroll.content.height
+        val remaining = if (horizontal) size - scroll.offsetX() - scroll.width else size - scroll.offsetY() - scroll.height
+        if (remaining > scroll.props.optDouble("endThreshold", 200.0) * density() || scroll.endReachedAt == size) return
+        scroll.endReachedAt = size
+        scroll.post { applyPatches(GovinciBridge.TriggerCallback(callback)) }
+    }
+
+    private fun lazyList(): ScrollView {
+        val scroll = ScrollView(context)
+        scroll.addView(FrameLayout(context), FrameLayout.LayoutParams(ViewGroup.LayoutParams.MATCH_PARENT, ViewGroup.LayoutParams.WRAP_CONTENT))
+        scroll.setOnScrollChangeListener { _, _, _, _, _ -> lazyScrolled(scroll) }
+        scroll.post { reportScroll(scroll) }
+        return scroll
+    }
+
+    // Items are slots positioned by topMargin inside the list's content, whose
+    // minimum height is the total height Go computed. Scroll position and
+    // measured heights go back to Go, which answers with the new window.
+    private fun applyLazyProps(view: View, props: JSONObject) {
+        lazyProps[view] = props
+        val d = density()
+        if (view is ScrollView) {
+            view.getChildAt(0).minimumHeight = (props.optDouble("totalHeight", 0.0) * d).toInt()
+            props.optString("controller").takeIf { it.isNotEmpty() }?.let { scrollTargets[it] = view }
+            positionSticky(view)
+            return
+        }
+        val lp = view.layoutParams as? FrameLayout.LayoutParams
+            ?: FrameLayout.LayoutParams(ViewGroup.LayoutParams.MATCH_PARENT, ViewGroup.LayoutParams.WRAP_CONTENT)
+        lp.topMargin = (props.optDouble("top", 0.0) * d).toInt()
+        view.layoutParams = lp
+        view.visibility = if (props.optInt("index", -1) < 0) View.GONE else View.VISIBLE
+        view.z = if (props.optBoolean("sticky")) d else 0f
+        if (measured.put(view, true) == null) {
+            view.addOnLayoutChangeListener { v, _, _, _, _, _, _, _, _ -> measureItem(v) }
+        }
+        (view.parent?.parent as? ScrollView)?.let { positionSticky(it) }
+    }
+
+    private fun lazyScrolled(scroll: ScrollView) {
+        positionSticky(scroll)
+        if (scrollPending.put(scroll, true) == true) return
+        scroll.postOnAnimation {
+            scrollPending.remove(scroll)
+            reportScroll(scroll)
+        }
+    }
+
+    private fun reportScroll(scroll: ScrollView) {
+        val callback = lazyProps[scroll]?.optString("onScroll").orEmpty()
+        if (callback.isEmpty()) return
+        val d = density()
+        val value = JSONObject().put("offset", scroll.scrollY / d).put("viewport", scroll.height / d)
+        applyPatches(GovinciBridge.TriggerEvent(callback, JSONObject().put("value", value).toString()))
+    }
+
+    private fun positionSticky(scroll: ScrollView) {
+        val content = scroll.getChildAt(0) as? ViewGroup ?: return
+        val d = density()
+        for (i in 0 until content.childCount) {
+            val item = content.getChildAt(i)
+            val props = lazyProps[item] ?: continue
+            if (!props.optBoolean("sticky")) continue
+            val top = props.optDouble("top", 0.0)
+            val limit = props.optDouble("stickyLimit", Double.MAX_VALUE)
+            val pinned = maxOf(top, minOf(scroll.scrollY / d.toDouble(), limit))
+            item.translationY = ((pinned - top) * d).toFloat()
+        }
+    }
+
+    // Reports an item whose laid-out height differs from the one Go used.
+    private fun measureItem(item: View) {
+        val props = lazyProps[item] ?: return
+        val index = props.optInt("index", -1)
+        val scroll = item.parent?.parent as? ScrollView ?: return
+        val callback = lazyProps[scroll]?.optString("onMeasure").orEmpty()
+        if (index < 0 || callback.isEmpty()) return
+        val height = item.height / density()
+        if (Math.abs(height - props.optDouble("height", 0.0)) < 0.5) return
+        props.put("height", height)
+        val value = JSONObject().put("heights", JSONObject().put(index.toString(), height))
+        item.post { applyPatches(GovinciBridge.TriggerEvent(callback, JSONObject().put("value", value).toString())) }
+    }
+
+    private fun density(): Float = context.resources.displayMetrics.density
+
+    // Drops a subtree from patch addressing; a leaving view may stay on screen
+    // for its exit animation.
+    private fun forget(path: String) {
+        viewMap.keys.removeAll { it == path || it.startsWith("$path/") }
+    }
+
+    private fun removeView(view: View) {
+        scrollTargets.values.remove(view)
+        cameras.forget(view)
+        val parent = view.parent as? ViewGroup ?: return
+        val exit = exits[view]
+        if (exit == null) {
+            parent.removeView(view)
+            return
+        }
+        view.isEnabled = false
+        play(view, exit) { parent.removeView(view) }
+    }
+
+    // Animations arrive as resolved frames plus timing (core/animation.go). A
+    // single frame animates from the view's current values.
+    private fun applyMotionProps(view: View, props: JSONObject, mounting: Boolean) {
+        val exit = props.optJSONObject("exit")
+        if (exit != null) exits[view] = exit else exits.remove(view)
+        val animate = props.optJSONObject("animate")
+        val key = animate?.toString() ?: ""
+        if (animateKeys[view] == key) return
+        animateKeys[view] = key
+
+        val enter = props.optJSONObject("enter")
+        if (mounting && enter != null) {
+            play(view, enter) {
+                if (animate != null && animate.getJSONArray("frames").length() > 1) play(view, animate)
+            }
+            return
+        }
+        if (animate == null) return
+        val frames = animate.getJSONArray("frames")
+        if (mounting && frames.length() == 1) {
+            setFrame(view, frames.getJSONObject(0))
+            return
+        }
+        play(view, animate)
+    }
+
+    // ViewPropertyAnimator has no keyframes, so each segment between two
+    // frames runs as its own animation, chained through withEndAction.
+    // Cancelling (a newer animation on the same view) ends the chain.
+    private fun play(view: View, motion: JSONObject, onEnd: () -> Unit = {}) {
+        val frames = motion.getJSONArray("frames")
+        val duration = motion.optLong("duration", 300)
+        val iterations = motion.optInt("iterations", 0).let { if (it == 0) 1 else it }
+        val interpolator = interpolatorFor(motion)
+        view.animate().cancel()
+
+        fun segment(i: Int, iteration: Int) {
+            if (i >= frames.length()) {
+                if (iterations < 0 || iteration + 1 < iterations) {
+                    setFrame(view, frames.getJSONObject(0))
+                    segment(1, iteration + 1)
+                } else {
+                    onEnd()
+                }
+                return
+            }
+            val frame = frames.getJSONObject(i)
+            val from = if (i == 0) 0.0 else frames.getJSONObject(i - 1).optDouble("offset", 0.0)
+            val span = ((frame.optDouble("offset", 1.0) - from) * duration).toLong()
+            animateTo(view.animate(), frame)
+                .setDuration(span)
+                .setStartDelay(if (i <= 1 && iteration == 0) motion.optLong("delay", 0) else 0)
+                .setInterpolator(interpolator)
+                .withEndAction { segment(i + 1, iteration) }
+                .start()
+        }
+
+        if (frames.length() > 1) {
+            setFrame(view, frames.getJSONObject(0))
+            segment(1, 0)
+        } else {
+            segment(0, 0)
+        }
+    }
+
+    private fun animateTo(animator: ViewPropertyAnimator, frame: JSONObject): ViewPropertyAnimator {
+        val density = context.resources.displayMetrics.density
+        return animator
+            .alpha(frame.optDouble("opacity", 1.0).toFloat())
+            .translationX(frame.optDouble("x", 0.0).toFloat() * density)
+            .translationY(frame.optDouble("y", 0.0).toFloat() * density)
+            .scaleX(frame.optDouble("scale", 1.0).toFloat())
+            .scaleY(frame.optDouble("scale", 1.0).toFloat())
+            .rotation(frame.optDouble("rotate", 0.0).toFloat())
+    }
+
+    private fun setFrame(view: View, frame: JSONObject) {
+        val density = context.resources.displayMetrics.density
+        view.alpha = frame.optDouble("opacity", 1.0).toFloat()
+        view.translationX = frame.optDouble("x", 0.0).toFloat() * density
+        view.translationY = frame.optDouble("y", 0.0).toFloat() * density
+        view.scaleX = frame.optDouble("scale", 1.0).toFloat()
+        view.scaleY = frame.optDouble("scale", 1.0).toFloat()
+        view.rotation = frame.optDouble("rotate", 0.0).toFloat()
+    }
+
+    // Springs arrive pre-sampled as "curve"; CSS easing names map onto the
+    // same cubic-bezier curves the browser uses.
+    private fun interpolatorFor(motion: JSONObject): TimeInterpolator {
+        val curve = motion.optJSONArray("curve")
+        if (curve != null && curve.length() > 1) {
+            val points = DoubleArray(curve.length()) { curve.getDouble(it) }
+            return TimeInterpolator { t ->
+                val pos = t * (points.size - 1)
+                val i = pos.toInt().coerceAtMost(points.size - 2)
+                (points[i] + (points[i + 1] - points[i]) * (pos - i)).toFloat()
+            }
+        }
+        val easing = motion.optString("easing", "ease")
+        return when (easing) {
+            "linear" -> LinearInterpolator()
+            "ease-in" -> PathInterpolator(0.42f, 0f, 1f, 1f)
+            "ease-out" -> PathInterpolator(0f, 0f, 0.58f, 1f)
+            "ease-in-out" -> PathInterpolator(0.42f, 0f, 0.58f, 1f)
+            else -> cubicBezier(easing) ?: PathInterpolator(0.25f, 0.1f, 0.25f, 1f)
+        }
+    }
+
+    private fun cubicBezier(easing: String): PathInterpolator? {
+        if (!easing.startsWith("cubic-bezier(")) return null
+        val p = easing.removePrefix("cubic-bezier(").removeSuffix(")").split(",").mapNotNull { it.trim().toFloatOrNull() }
+        return if (p.size == 4) PathInterpolator(p[0], p[1], p[2], p[3]) else null
+    }
+
+    private fun registerFonts(faces: JSONArray) {
+        for (i in 0 until faces.length()) {
+            val face = faces.getJSONObject(i)
+            val family = face.getString("family")
+            if (fonts.containsKey(family)) continue
+            val src = face.getString("src").removePrefix("file:///android_asset/")
+            try {
+                fonts[family] = Typeface.createFromAsset(context.assets, src)
+            } catch (e: RuntimeException) {
+                // missing asset: fall back to the system family
+            }
+        }
+    }
+
+    private fun applyTextStyle(view: TextView, style: JSONObject) {
+        val size = style.optDouble("FontSize", 0.0)
+        if (size > 0) {
+            view.setTextSize(TypedValue.COMPLEX_UNIT_SP, size.toFloat())
+        }
+
+        val family = style.optString("FontFamily", "")
+        val base = if (family.isNotEmpty()) fonts[family] ?: Typeface.create(family, Typeface.NORMAL) else Typeface.DEFAULT
+        val weight = style.optInt("FontWeight", 0).takeIf { it > 0 } ?: 400
+        val italic = style.optString("FontStyle", "") == "italic"
+        view.typeface = if (Build.VERSION.SDK_INT >= 28) {
+            Typeface.create(base, weight, italic)
+        } else {
+            val flags = (if (weight >= 600) Typeface.BOLD else 0) or (if (italic) Typeface.ITALIC else 0)
+            Typeface.create(base, flags)
+        }
+
+        val spacing = style.optDouble("LetterSpacing", 0.0)
+        if (spacing != 0.0 && size > 0) {
+            // Android expresses letter spacing in ems
+            view.letterSpacing = (spacing / size).toFloat()
+        }
+
+        when (style.optString("TextAlign", "")) {
+            "center" -> view.gravity = Gravity.CENTER_HORIZONTAL
+            "right" -> view.gravity = Gravity.END
+            "left" -> view.gravity = Gravity.START
+            "justify" -> if (Build.VERSION.SDK_INT >= 26) {
+                view.justificationMode = android.text.Layout.JUSTIFICATION_MODE_INTER_WORD
+            }
+        }
+
+        when (style.optString("TextDecoration", "")) {
+            "underline" -> view.paintFlags = view.paintFlags or Paint.UNDERLINE_TEXT_FLAG
+            "line-through" -> view.paintFlags = view.paintFlags or Paint.STRIKE_THRU_TEXT_FLAG
+        }
+
+        view.isAllCaps = style.optString("TextTransform", "") == "uppercase"
+    }
+
+    // State styles map onto Android's view states: a StateListDrawable for the
+    // background and a ColorStateList for text, most specific state first.
+    private fun applyStateStyles(view: View, style: JSONObject) {
+        val states = listOf(
+            intArrayOf(-android.R.attr.state_enabled) to style.optJSONObject("DisabledStyle"),
+            intArrayOf(android.R.attr.state_pressed) to style.optJSONObject("PressedStyle"),
+            intArrayOf(android.R.attr.state_selected) to style.optJSONObject("SelectedStyle"),
+            intArrayOf(android.R.attr.state_focused) to style.optJSONObject("FocusStyle"),
+            intArrayOf(android.R.attr.state_hovered) to style.optJSONObject("HoverStyle"),
+        ).mapNotNull { (state, s) -> s?.let { state to it } }
+
+        val radius = style.optDouble("BorderRadius", 0.0).toFloat() * view.resources.displayMetrics.density
+        val backgrounds = states.mapNotNull { (state, s) -> cssColor(s.optString("Background", ""))?.let { state to it } }
+        val base = cssColor(style.optString("Background", ""))
+        if (backgrounds.isNotEmpty() || base != null) {
+            val drawable = StateListDrawable()
+            for ((state, color) in backgrounds) {
+                drawable.addState(state, GradientDrawable().apply { setColor(color); cornerRadius = radius })
+            }
+            if (base != null) {
+                drawable.addState(intArrayOf(), GradientDrawable().apply { setColor(base); cornerRadius = radius })
+            }
+            view.background = drawable
+        }
+
         if (view is TextView) {
+            val colors = states.mapNotNull { (state, s) -> cssColor(s.optString("TextColor", ""))?.let { state to it } }
+            val textBase = cssColor(style.optString("TextColor", "")) ?: view.currentTextColor
+            if (colors.isNotEmpty()) {
+                view.setTextColor(ColorStateList(
+                    (colors.map { it.first } + listOf(intArrayOf())).toTypedArray(),
+                    (colors.map { it.second } + listOf(textBase)).toIntArray(),
+                ))
+            } else if (style.has("TextColor")) {
+                view.setTextColor(textBase)
+            }
+        }
+    }
+
+    private fun applyStateProps(view: View, props: JSONObject) {
+        view.isEnabled = !props.optBoolean("disabled", false)
+        view.isSelected = props.optBoolean("selected", false)
+    }
+
+    // Parses #RGB, #RRGGBB and CSS-ordered #RRGGBBAA into an Android color.
+    private fun cssColor(value: String): Int? {
+        if (!value.startsWith("#")) return null
+        val hex = value.substring(1)
+        return try {
+            when (hex.length) {
+                3 -> Color.parseColor("#" + hex.map { "$it$it" }.joinToString(""))
+                6 -> Color.parseColor(value)
+                8 -> Color.parseColor("#" + hex.substring(6) + hex.substring(0, 6))
+                else -> null
+            }
+        } catch (e: IllegalArgumentException) {
+            null
+        }
+    }
+
+    private fun updateProps(view: View, props: JSONObject) {
+        applyStateProps(view, props)
+        if (view is TextView && view !is EditText) {
             props.optString("content")?.let { view.text = it }
         }
         if (view is Button) {
@@ -0,0 +1,230 @@
+package com.govinci.app
+
+import android.app.AlertDialog
+import android.app.DatePickerDialog
+import android.app.TimePickerDialog
+import android.content.Context
+import android.text.Editable
+import android.text.TextWatcher
+import android.view.View
+import android.widget.ArrayAdapter
+import android.widget.EditText
+import android.widget.LinearLayout
+import android.widget.ListView
+import android.widget.TextView
+import org.json.JSONArray
+import org.json.JSONObject
+import java.text.DateFormat
+import java.text.SimpleDateFormat
+import java.util.Calendar
+import java.util.Locale
+import java.util.WeakHashMap
+
+// Pickers renders Select, DatePicker, TimePicker and DateRangePicker
+// (core/picker.go) as fields that open the platform dialogs: a single or
+// multi choice list (with a filter field when searchable), DatePickerDialog
+// and TimePickerDialog. Values are shown in the node's locale and go back
+// as numbers, {year, month, day} or {hour, minute}; TimePickerDialog has no
+// bounds, so Go clamps times.
+class Pickers(private val context: Context, private val apply: (patches: String) -> Unit) {
+    private val props = WeakHashMap<View, JSONObject>()
+    private val types = WeakHashMap<View, String>()
+
+    fun owns(view: View) = props.containsKey(view)
+
+    fun create(type: String, p: JSONObject): View {
+        val view: View = when (type) {
+            "DateRangePicker" -> LinearLayout(context).apply {
+                orientation = LinearLayout.HORIZONTAL
+                addView(field { pickDate(this, "start") })
+                addView(field { pickDate(this, "end") })
+            }
+            "Select" -> field { pickOptions(it) }
+            "TimePicker" -> field { pickTime(it) }
+            else -> field { pickDate(it, "value") }
+        }
+        types[view] = type
+        update(view, p)
+        return view
+    }
+
+    fun update(view: View, p: JSONObject) {
+        props[view] = p
+        val locale = locale(p)
+        when (types[view]) {
+            "DateRangePicker" -> {
+                view as LinearLayout
+                (view.getChildAt(0) as TextView).text = dateLabel(p.optString("start"), locale).ifEmpty { p.optString("placeholder") }
+                (view.getChildAt(1) as TextView).text = dateLabel(p.optString("end"), locale)
+                for (i in 0 until view.childCount) view.getChildAt(i).isEnabled = !p.optBoolean("disabled")
+            }
+            "Select" -> (view as TextView).text = selectedLabels(p).ifEmpty { p.optString("placeholder") }
+            "TimePicker" -> (view as TextView).text = timeLabel(p.optString("value"), locale).ifEmpty { p.optString("placeholder") }
+            else -> (view as TextView).text = dateLabel(p.optString("value"), locale).ifEmpty { p.optString("placeholder") }
+        }
+    }
+
+    private fun field(onClick: (TextView) -> Unit) = TextView(context).apply {
+        isClickable = true
+        isFocusable = true
+        setOnClickListener { onClick(this) }
+    }
+
+    private fun pickOptions(view: TextView) {
+        val p = props[view] ?: return
+        val options = p.optJSONArray("options") ?: return
+        val labels = List(options.length()) { options.getJSONObject(it).optString("label") }
+        val disabled = List(options.length()) { options.getJSONObject(it).optBoolean("disabled") }
+        val selected = p.optJSONArray("selected").let { a -> List(a?.length() ?: 0) { a!!.getInt(it) } }
+        val builder = AlertDialog.Builder(context)
+
+        if (p.optBoolean("multiple")) {
+            val checked = BooleanArray(labels.size) { it in selected }
+            builder.setMultiChoiceItems(labels.toTypedArray(), checked) { dialog, which, isChecked ->
+                if (disabled[which]) {
+                    checked[which] = !isChecked
+                    (dialog as AlertDialog).listView.setItemChecked(which, !isChecked)
+                    return@setMultiChoiceItems
+                }
+                checked[which] = isChecked
+                val indices = JSONArray(checked.indices.filter { checked[it] })
+                trigger(view, JSONObject().put("indices", indices))
+            }
+            builder.setPositiveButton(android.R.string.ok, null)
+            builder.show()
+            return
+        }
+
+        if (!p.optBoolean("searchable")) {
+            builder.setSingleChoiceItems(labels.toTypedArray(), selected.firstOrNull() ?: -1) { dialog, which ->
+                if (disabled[which]) return@setSingleChoiceItems
+                trigger(view, which)
+                dialog.dismiss()
+            }
+            builder.show()
+            return
+        }
+
+        // searchable: a filter field above the list; positions map back to
+        // option indices through `shown`
+        var shown = labels.indices.toList()
+        val adapter = ArrayAdapter(context, android.R.layout.simple_list_item_1, labels.toMutableList())
+        val list = ListView(context).apply { this.adapter = adapter }
+        val filter = EditText(context).apply {
+            hint = selectedLabels(p).ifEmpty { p.optString("placeholder") }
+            isSingleLine = true
+        }
+        val layout = LinearLayout(context).apply {
+            orientation = LinearLayout.VERTICAL
+            addView(filter)
+            addView(list)
+        }
+        val dialog = builder.setView(layout).create()
+        val locale = locale(p)
+        filter.addTextChangedListener(object : TextWatcher {
+            override fun beforeTextChanged(s: CharSequence?, start: Int, count: Int, after: Int) {}
+            override fun onTextChanged(s: CharSequence?, start: Int, before: Int, count: Int) {}
+            override fun afterTextChanged(s: Editable) {
+                val query = s.toString().trim().lowercase(locale)
+                shown = labels.indices.filter { labels[it].lowercase(locale).contains(query) }
+                adapter.clear()
+                adapter.addAll(shown.map { labels[it] })
+            }
+        })
+        list.setOnItemClickListener { _, _, position, _ ->
+            val index = shown[position]
+            if (disabled[index]) return@setOnItemClickListener
+            trigger(view, index)
+            dialog.dismiss()
+        }
+        dialog.show()
+    }
+
+    // key is the prop being picked: "value", or "start"/"end" of a range.
+    private fun pickDate(view: View, key: String) {
+        val p = props[view] ?: return
+        val current = parseDate(p.optString(key)) ?: Calendar.getInstance()
+        val dialog = DatePickerDialog(context, { _, year, month, day ->
+            val picked = JSONObject().put("year", year).put("month", month + 1).put("day", day)
+            if (key == "value") {
+                trigger(view, picked)
+            } else {
+                val range = JSONObject()
+                    .put("start", parseDate(p.optString("start"))?.let { dateJSON(it) } ?: cleared())
+                    .put("end", parseDate(p.optString("end"))?.let { dateJSON(it) } ?: cleared())
+                    .put(key, picked)
+                trigger(view, range)
+            }
+        }, current.get(Calendar.YEAR), current.get(Calendar.MONTH), current.get(Calendar.DAY_OF_MONTH))
+        parseDate(p.optString("min"))?.let { dialog.datePicker.minDate = it.timeInMillis }
+        parseDate(p.optString("max"))?.let { dialog.datePicker.maxDate = it.timeInMillis }
+        dialog.show()
+    }
+
+    // an empty day of a range, as the web sends an emptied input
+    private fun cleared() = JSONObject().put("cleared", true)
+
+    private fun pickTime(view: TextView) {
+        val p = props[view] ?: return
+        val (hour, minute) = p.optString("value").split(":").mapNotNull { it.toIntOrNull() }.let {
+            if (it.size == 2) it[0] to it[1]
+            else Calendar.getInstance().let { c -> c.get(Calendar.HOUR_OF_DAY) to c.get(Calendar.MINUTE) }
+        }
+        TimePickerDialog(context, { _, h, m ->
+            trigger(view, JSONObject().put("hour", h).put("minute", m))
+        }, hour, minute, is24Hour(locale(p))).show()
+    }
+
+    private fun selectedLabels(p: JSONObject): String {
+        val options = p.optJSONArray("options") ?: return ""
+        val selected = p.optJSONArray("selected") ?: return ""
+        return (0 until selected.length())
+            .mapNotNull { options.optJSONObject(selected.getInt(it))?.optString("label") }
+            .joinToString(", ")
+    }
+
+    private fun locale(p: JSONObject): Locale =
+        p.optString("locale").takeIf { it.isNotEmpty() }?.let { Locale.forLanguageTag(it) } ?: Locale.getDefault()
+
+    private fun parseDate(value: String): Calendar? {
+        val parts = value.split("-").mapNotNull { it.toIntOrNull() }
+        if (parts.size != 3) return null
+        return Calendar.getInstance().apply {
+            clear()
+            set(parts[0], parts[1] - 1, parts[2])
+        }
+    }
+
+    private fun dateJSON(c: Calendar) = JSONObject()
+        .put("year", c.get(Calendar.YEAR))
+        .put("month", c.get(Calendar.MONTH) + 1)
+        .put("day", c.get(Calendar.DAY_OF_MONTH))
+
+    private fun dateLabel(value: String, locale: Locale): String {
+        val date = parseDate(value) ?: return ""
+        return DateFormat.getDateInstance(DateFormat.MEDIUM, locale).format(date.time)
+    }
+
+    private fun timeLabel(value: String, locale: Locale): String {
+        val parts = value.split(":").mapNotNull { it.toIntOrNull() }
+        if (parts.size != 2) return ""
+        val time = Calendar.getInstance().apply {
+            set(Calendar.HOUR_OF_DAY, parts[0])
+            set(Calendar.MINUTE, parts[1])
+        }
+        return DateFormat.getTimeInstance(DateFormat.SHORT, locale).format(time.time)
+    }
+
+    private fun is24Hour(locale: Locale): Boolean {
+        val format = DateFormat.getTimeInstance(DateFormat.SHORT, locale)
+        return (format as? SimpleDateFormat)?.toPattern()?.contains('H') ?: true
+    }
+
+    // The callback is read when the dialog answers: every render registers it
+    // under a new ID and the one the dialog opened with may be gone.
+    private fun trigger(view: View, value: Any) {
+        val callback = props[view]?.optString("onChange").orEmpty()
+        if (callback.isEmpty()) return
+        apply(GovinciBridge.TriggerEvent(callback, JSONObject().put("value", value).toString()))
+    }
+}
@@ -0,0 +1,62 @@
+package com.govinci.app
+
+import android.content.Context
+import android.view.ViewGroup
+import android.widget.FrameLayout
+import android.widget.HorizontalScrollView
+import android.widget.LinearLayout
+import android.widget.ScrollView
+import androidx.swiperefreshlayout.widget.SwipeRefreshLayout
+import org.json.JSONObject
+
+// ScrollContainer is a core.Scroll: a ScrollView, a HorizontalScrollView or
+// both nested for "both", inside a SwipeRefreshLayout that stays disabled
+// until Go sets onRefresh. Children go into content. The direction is fixed
+// when the view is created.
+class ScrollContainer(context: Context, direction: String) : FrameLayout(context) {
+    val refresh = SwipeRefreshLayout(context)
+    val vertical: ScrollView? = if (direction != "horizontal") ScrollView(context) else null
+    val horizontal: HorizontalScrollView? = if (direction != "vertical") HorizontalScrollView(context) else null
+    val content = LinearLayout(context).apply {
+        orientation = if (direction == "horizontal") LinearLayout.HORIZONTAL else LinearLayout.VERTICAL
+    }
+
+    var props = JSONObject()
+    var onScrolled: () -> Unit = {}
+
+    // Renderer bookkeeping for throttling and end-reached detection.
+    var lastReport = 0L
+    var reportPending = false
+    var endReachedAt = -1
+
+    init {
+        val wrap = ViewGroup.LayoutParams.WRAP_CONTENT
+        val match = ViewGroup.LayoutParams.MATCH_PARENT
+        val inner: ViewGroup = horizontal ?: vertical!!
+        inner.addView(content, LayoutParams(if (horizontal != null) wrap else match, wrap))
+        if (vertical != null && horizontal != null) vertical.addView(horizontal, LayoutParams(match, wrap))
+        val outer: ViewGroup = vertical ?: horizontal!!
+        refresh.addView(outer, LayoutParams(match, match))
+        refresh.isEnabled = false
+        addView(refresh, LayoutParams(match, match))
+        vertical?.setOnScrollChangeListener { _, _, _, _, _ -> onScrolled() }
+        horizontal?.setOnScrollChangeListener { _, _, _, _, _ -> onScrolled() }
+    }
+
+    fun offsetX(): Int = horizontal?.scrollX ?: 0
+    fun offsetY(): Int = vertical?.scrollY ?: 0
+
+    fun moveTo(x: Int, y: Int, animated: Boolean) {
+        if (animated) {
+            vertical?.smoothScrollTo(0, y)
+            horizontal?.smoothScrollTo(x, 0)
+        } else {
+            vertical?.scrollTo(0, y)
+            horizontal?.scrollTo(x, 0)
+        }
+    }
+
+    fun moveToEnd(animated: Boolean) {
+        moveTo(content.width, content.height, animated)
+    }
+}
@@ -0,0 +1,140 @@
+package com.govinci.app
+
+import android.content.Context
+import android.view.View
+import android.widget.CheckBox
+import android.widget.CompoundButton
+import android.widget.LinearLayout
+import android.widget.RadioButton
+import android.widget.SeekBar
+import android.widget.Switch
+import org.json.JSONObject
+import java.util.WeakHashMap
+import kotlin.math.roundToInt
+
+// SelectionControls renders Checkbox, Switch, Radio, Slider and RangeSlider
+// (core/selection.go). Go owns the state: a change is reported with the
+// value its callback expects and the widget shows whatever Go renders back.
+// A SeekBar has integer progress, so a slider's range is split into its
+// steps, or into SMOOTH ticks when it slides continuously. A RangeSlider is
+// two SeekBars, low above high.
+class SelectionControls(private val context: Context, private val apply: (patches: String) -> Unit) {
+    private val props = WeakHashMap<View, JSONObject>()
+    private val dragging = WeakHashMap<SeekBar, Boolean>()
+    private var writing = false
+
+    fun owns(view: View) = props.containsKey(view)
+
+    fun create(type: String, p: JSONObject): View {
+        val view: View = when (type) {
+            "Checkbox", "Switch", "Radio" -> {
+                val button = when (type) {
+                    "Switch" -> Switch(context)
+                    "Radio" -> RadioButton(context)
+                    else -> CheckBox(context)
+                }
+                button.setOnCheckedChangeListener { _, checked ->
+                    if (writing) return@setOnCheckedChangeListener
+                    val current = props[button] ?: return@setOnCheckedChangeListener
+                    if (type == "Radio") {
+                        if (checked) trigger(current.optString("onSelect"), null)
+                    } else {
+                        trigger(current.optString("onToggle"), checked)
+                    }
+                }
+                button
+            }
+            "Slider" -> seekBar { bar -> props[bar]?.let { trigger(it.optString("onChange"), valueOf(bar, it)) } }
+            else -> LinearLayout(context).apply {
+                orientation = LinearLayout.VERTICAL
+                val report = { moved: SeekBar ->
+                    val low = getChildAt(0) as SeekBar
+                    val high = getChildAt(1) as SeekBar
+                    // the moved thumb stops at the other one
+                    if (low.progress > high.progress) {
+                        writing = true
+                        moved.progress = if (moved === low) high.progress else low.progress
+                        writing = false
+                    }
+                    props[this]?.let {
+                        val value = JSONObject().put("low", valueOf(low, it)).put("high", valueOf(high, it))
+                        trigger(it.optString("onChange"), value)
+                    }
+                }
+                addView(seekBar(report))
+                addView(seekBar(report))
+            }
+        }
+        update(view, p)
+        return view
+    }
+
+    fun update(view: View, p: JSONObject) {
+        props[view] = p
+        writing = true
+        when (view) {
+            is CompoundButton -> {
+                view.isChecked = p.optBoolean("checked")
+                if (view is RadioButton) view.text = p.optString("label")
+            }
+            is SeekBar -> setRange(view, p, p.optDouble("value", 0.0))
+            is LinearLayout -> {
+                val low = view.getChildAt(0) as SeekBar
+                val high = view.getChildAt(1) as SeekBar
+                setRange(low, p, p.optDouble("low", 0.0))
+                setRange(high, p, p.optDouble("high", 0.0))
+                low.isEnabled = !p.optBoolean("disabled")
+                high.isEnabled = low.isEnabled
+            }
+        }
+        writing = false
+    }
+
+    private fun seekBar(changed: (SeekBar) -> Unit) = SeekBar(context).apply {
+        setOnSeekBarChangeListener(object : SeekBar.OnSeekBarChangeListener {
+            override fun onProgressChanged(bar: SeekBar, progress: Int, fromUser: Boolean) {
+                if (fromUser && !writing) changed(bar)
+            }
+            override fun onStartTrackingTouch(bar: SeekBar) {
+                dragging[bar] = true
+            }
+            override fun onStopTrackingTouch(bar: SeekBar) {
+                dragging.remove(bar)
+            }
+        })
+    }
+
+    // Values from Go wait while the thumb is held, so a late render doesn't
+    // pull it back.
+    private fun setRange(bar: SeekBar, p: JSONObject, value: Double) {
+        val min = p.optDouble("min", 0.0)
+        bar.max = ticks(p)
+        if (dragging[bar] == true) return
+        bar.progress = ((value - min) / tick(p)).roundToInt()
+    }
+
+    private fun valueOf(bar: SeekBar, p: JSONObject) = p.optDouble("min", 0.0) + bar.progress * tick(p)
+
+    private fun ticks(p: JSONObject): Int {
+        val span = p.optDouble("max", 1.0) - p.optDouble("min", 0.0)
+        return if (p.has("step")) (span / p.getDouble("step")).roundToInt() else SMOOTH
+    }
+
+    private fun tick(p: JSONObject): Double {
+        val span = p.optDouble("max", 1.0) - p.optDouble("min", 0.0)
+        return if (p.has("step")) p.getDouble("step") else span / SMOOTH
+    }
+
+    private fun trigger(callback: String, value: Any?) {
+        if (callback.isEmpty()) return
+        if (value == null) {
+            apply(GovinciBridge.TriggerCallback(callback))
+        } else {
+            apply(GovinciBridge.TriggerEvent(callback, JSONObject().put("value", value).toString()))
+        }
+    }
+
+    private companion object {
+        const val SMOOTH = 1000 // ticks of a continuous slider
+    }
+}
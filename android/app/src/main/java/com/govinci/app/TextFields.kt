@@ -0,0 +1,187 @@
+package com.govinci.app
+
+import android.content.Context
+import android.text.Editable
+import android.text.InputFilter
+import android.text.InputType
+import android.text.SpannableString
+import android.text.TextWatcher
+import android.view.Gravity
+import android.view.inputmethod.BaseInputConnection
+import android.view.inputmethod.EditorInfo
+import android.view.inputmethod.InputMethodManager
+import android.widget.EditText
+import org.json.JSONObject
+import java.lang.ref.WeakReference
+import java.util.WeakHashMap
+
+// TextFields renders Input, InputPassword, NumericInput and TextArea as
+// EditTexts and maps the input props (core/input_props.go) to input types,
+// IME options and filters. Edits follow the sequence protocol in
+// core/input_sync.go: stale echoes are dropped, and values Go sends back are
+// not written while the IME is composing, which would cancel the
+// composition.
+class TextFields(private val context: Context, private val apply: (patches: String) -> Unit) {
+    private val props = WeakHashMap<EditText, JSONObject>()
+    private val types = WeakHashMap<EditText, String>()
+    private val seqs = WeakHashMap<EditText, Int>()
+    private val selections = WeakHashMap<EditText, String>()
+    private val focusTargets = mutableMapOf<String, WeakReference<EditText>>()
+    private var writing = false
+    private var inputSeq = 0
+
+    fun create(type: String, p: JSONObject): EditText {
+        val field = EditText(context)
+        if (type == "TextArea") {
+            field.minLines = p.optInt("rows", 3)
+            field.gravity = Gravity.TOP or Gravity.START
+        } else {
+            field.isSingleLine = true
+        }
+        field.addTextChangedListener(object : TextWatcher {
+            override fun beforeTextChanged(s: CharSequence?, start: Int, count: Int, after: Int) {}
+            override fun onTextChanged(s: CharSequence?, start: Int, before: Int, count: Int) {}
+            override fun afterTextChanged(s: Editable) {
+                if (writing) return
+                val callback = props[field]?.optString("onChange").orEmpty()
+                if (callback.isEmpty()) return
+                val seq = ++inputSeq
+                seqs[field] = seq
+                val value = JSONObject()
+                    .put("text", s.toString())
+                    .put("seq", seq)
+                    .put("selectionStart", field.selectionStart)
+                    .put("selectionEnd", field.selectionEnd)
+                val composingStart = BaseInputConnection.getComposingSpanStart(s)
+                if (composingStart != -1) {
+                    value.put("composingStart", composingStart)
+                    value.put("composingEnd", BaseInputConnection.getComposingSpanEnd(s))
+                }
+                apply(GovinciBridge.TriggerEvent(callback, JSONObject().put("value", value).toString()))
+            }
+        })
+        field.setOnFocusChangeListener { _, hasFocus ->
+            val callback = props[field]?.optString(if (hasFocus) "onFocus" else "onBlur").orEmpty()
+            if (callback.isNotEmpty()) apply(GovinciBridge.TriggerCallback(callback))
+        }
+        field.setOnEditorActionListener { _, _, _ ->
+            val callback = props[field]?.optString("onSubmit").orEmpty()
+            if (callback.isEmpty()) return@setOnEditorActionListener false
+            apply(GovinciBridge.TriggerTextCallback(callback, field.text.toString()))
+            true
+        }
+        types[field] = type
+        update(field, p)
+        if (p.optBoolean("autoFocus")) field.post { focus(field) }
+        return field
+    }
+
+    // Every keystroke comes back as an update (the "seq" ack), and assigning
+    // inputType, imeOptions or filters restarts the IME connection, which
+    // cancels the composition; only changed values are written.
+    fun update(field: EditText, p: JSONObject) {
+        props[field] = p
+        val inputType = inputType(types[field].orEmpty(), p)
+        if (field.inputType != inputType) field.inputType = inputType
+        val imeOptions = when (p.optString("returnKey")) {
+            "next" -> EditorInfo.IME_ACTION_NEXT
+            "go" -> EditorInfo.IME_ACTION_GO
+            "search" -> EditorInfo.IME_ACTION_SEARCH
+            "send" -> EditorInfo.IME_ACTION_SEND
+            "done" -> EditorInfo.IME_ACTION_DONE
+            else -> EditorInfo.IME_ACTION_UNSPECIFIED
+        }
+        if (field.imeOptions != imeOptions) field.imeOptions = imeOptions
+        val maxLength = p.optInt("maxLength", 0)
+        val currentMax = (field.filters.firstOrNull() as? InputFilter.LengthFilter)?.max ?: 0
+        if (currentMax != maxLength) {
+            field.filters = if (maxLength > 0) arrayOf(InputFilter.LengthFilter(maxLength)) else arrayOf()
+        }
+        val hint = p.optString("placeholder")
+        if (field.hint?.toString() != hint) field.hint = hint
+        field.isEnabled = !p.optBoolean("disabled")
+        p.optString("focusId").takeIf { it.isNotEmpty() }?.let { focusTargets[it] = WeakReference(field) }
+
+        writeValue(field, p)
+    }
+
+    // Writes Go's value unless it is a stale echo or a composition is open;
+    // the caret keeps its distance from the end unless Go sends a selection.
+    private fun writeValue(field: EditText, p: JSONObject) {
+        val text = field.text
+        if (p.optInt("seq", 0) < (seqs[field] ?: 0) || BaseInputConnection.getComposingSpanStart(text) != -1) return
+        val value = p.optString("value")
+        writing = true
+        if (text.toString() != value) {
+            val fromEnd = text.length - field.selectionEnd.coerceAtLeast(0)
+            field.setText(value)
+            val caret = (value.length - fromEnd).coerceIn(0, value.length)
+            field.setSelection(caret)
+            selections.remove(field)
+        }
+        val selection = p.optJSONArray("selection")
+        if (selection != null && selection.toString() != selections[field]) {
+            val end = field.text.length
+            field.setSelection(selection.optInt(0).coerceIn(0, end), selection.optInt(1).coerceIn(0, end))
+        }
+        selections[field] = selection?.toString()
+        p.optJSONArray("composition")?.let { compose(field.text, it.optInt(0), it.optInt(1)) }
+        writing = false
+    }
+
+    // The composing span class is private; BaseInputConnection puts one on a
+    // scratch string and it is moved over to the range Go asked for.
+    private fun compose(text: Editable, start: Int, end: Int) {
+        if (start < 0 || end > text.length || start >= end) return
+        BaseInputConnection.removeComposingSpans(text)
+        val scratch = SpannableString(text.subSequence(start, end).toString())
+        BaseInputConnection.setComposingSpans(scratch)
+        for (span in scratch.getSpans(0, scratch.length, Any::class.java)) {
+            text.setSpan(span, start, end, scratch.getSpanFlags(span))
+        }
+    }
+
+    fun inputType(type: String, p: JSONObject): Int {
+        val secure = p.optBoolean("secure")
+        var t = when (p.optString("keyboard")) {
+            "number" -> InputType.TYPE_CLASS_NUMBER or
+                (if (secure) InputType.TYPE_NUMBER_VARIATION_PASSWORD else 0)
+            "decimal" -> InputType.TYPE_CLASS_NUMBER or InputType.TYPE_NUMBER_FLAG_DECIMAL
+            "phone" -> InputType.TYPE_CLASS_PHONE
+            "email" -> InputType.TYPE_CLASS_TEXT or InputType.TYPE_TEXT_VARIATION_EMAIL_ADDRESS
+            "url" -> InputType.TYPE_CLASS_TEXT or InputType.TYPE_TEXT_VARIATION_URI
+            else -> InputType.TYPE_CLASS_TEXT or
+                (if (secure) InputType.TYPE_TEXT_VARIATION_PASSWORD else 0)
+        }
+        if ((t and InputType.TYPE_MASK_CLASS) != InputType.TYPE_CLASS_TEXT) return t
+        if (type == "TextArea") t = t or InputType.TYPE_TEXT_FLAG_MULTI_LINE
+        t = t or when (p.optString("autoCapitalize")) {
+            "sentences" -> InputType.TYPE_TEXT_FLAG_CAP_SENTENCES
+            "words" -> InputType.TYPE_TEXT_FLAG_CAP_WORDS
+            "characters" -> InputType.TYPE_TEXT_FLAG_CAP_CHARACTERS
+            else -> 0
+        }
+        if (p.has("autoCorrect")) {
+            t = t or if (p.optBoolean("autoCorrect")) InputType.TYPE_TEXT_FLAG_AUTO_CORRECT else InputType.TYPE_TEXT_FLAG_NO_SUGGESTIONS
+        }
+        return t
+    }
+
+    // Programmatic focus (FocusManager); the target is the field's focusId.
+    fun handleFocusEvent(data: JSONObject) {
+        val field = focusTargets[data.optString("target")]?.get() ?: return
+        when (data.optString("action")) {
+            "focus" -> focus(field)
+            "blur" -> {
+                field.clearFocus()
+                imm().hideSoftInputFromWindow(field.windowToken, 0)
+            }
+        }
+    }
+
+    private fun focus(field: EditText) {
+        if (field.requestFocus()) imm().showSoftInput(field, InputMethodManager.SHOW_IMPLICIT)
+    }
+
+    private fun imm() = context.getSystemService(Context.INPUT_METHOD_SERVICE) as InputMethodManager
+}
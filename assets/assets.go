@@ -0,0 +1,134 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/GraHms/govinci/core"
+)
+
+var (
+	mu       sync.RWMutex
+	files    fs.FS
+	manifest *Manifest
+	density  = 1.0
+)
+
+// Register makes an embedded file system available to ReadFile/ReadJSON,
+// typically:
+//
+//	//go:embed assets
+//	var bundled embed.FS
+//	assets.Register(must(fs.Sub(bundled, "assets")))
+func Register(fsys fs.FS) {
+	mu.Lock()
+	files = fsys
+	mu.Unlock()
+}
+
+// Use installs the manifest produced by Bundle and makes core.ResolveAsset
+// return hashed, per-platform URLs.
+func Use(m *Manifest) {
+	mu.Lock()
+	manifest = m
+	mu.Unlock()
+	core.SetAssetResolver(Resolve)
+}
+
+// Watch starts resolving asset:// URLs for target before any manifest is
+// known, straight under its BaseURL, and installs the manifest and density
+// the host reports in the "assets" system event:
+//
+//	{"manifest": "<contents of govinci-assets.json>", "density": 2}
+//
+// Hosts send it before the first render, without "manifest" when the app
+// was not bundled.
+func Watch(target Target) {
+	Use(&Manifest{Target: target, Base: target.BaseURL(), Files: map[string]string{}})
+
+	core.OnSystemEvent("assets", func(data map[string]any) {
+		if ratio, ok := data["density"].(float64); ok {
+			SetDensity(ratio)
+		}
+		raw, _ := data["manifest"].(string)
+		if raw == "" {
+			return
+		}
+		m, err := LoadManifest(strings.NewReader(raw))
+		if err != nil {
+			return
+		}
+		if m.Base == "" {
+			m.Base = target.BaseURL()
+		}
+		Use(m)
+	})
+}
+
+// SetDensity reports the device pixel ratio so @2x/@3x variants are picked.
+func SetDensity(ratio float64) {
+	if ratio < 1 {
+		ratio = 1
+	}
+	mu.Lock()
+	density = ratio
+	mu.Unlock()
+}
+
+func ReadFile(name string) ([]byte, error) {
+	mu.RLock()
+	fsys := files
+	mu.RUnlock()
+
+	if fsys == nil {
+		return nil, fmt.Errorf("assets: no file system registered")
+	}
+	return fs.ReadFile(fsys, strings.TrimPrefix(name, "asset://"))
+}
+
+func ReadJSON(name string, v any) error {
+	data, err := ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Resolve maps a logical asset path to the URL of its best density variant.
+func Resolve(name string) string {
+	name = strings.TrimPrefix(name, "asset://")
+
+	mu.RLock()
+	m, d := manifest, density
+	mu.RUnlock()
+
+	if m == nil {
+		return "assets/" + name
+	}
+	for _, candidate := range variants(name, d) {
+		if hashed, ok := m.Files[candidate]; ok {
+			return m.Base + hashed
+		}
+	}
+	return m.Base + name
+}
+
+// variants lists the density-specific names to try, best match first:
+// icons/home@3x.png, icons/home@2x.png, icons/home.png.
+func variants(name string, density float64) []string {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+
+	var out []string
+	for scale := int(density + 0.5); scale > 1; scale-- {
+		if scale > 3 {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s@%dx%s", stem, scale, ext))
+	}
+	return append(out, name)
+}
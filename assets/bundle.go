@@ -0,0 +1,118 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type Target string
+
+const (
+	TargetWasm    Target = "wasm"
+	TargetAndroid Target = "android"
+)
+
+const ManifestName = "govinci-assets.json"
+
+// Manifest maps logical asset paths to their content-hashed copies.
+type Manifest struct {
+	Target Target            `json:"target"`
+	Base   string            `json:"base"`
+	Files  map[string]string `json:"files"`
+}
+
+// BaseURL is where a target loads bundled files from.
+func (t Target) BaseURL() string {
+	switch t {
+	case TargetAndroid:
+		return "file:///android_asset/"
+	default:
+		return "assets/"
+	}
+}
+
+// Bundle copies every file of src into outDir under a content-hashed name
+// (icons/home.png -> icons/home.3f2a9c1e.png) and writes the manifest next to
+// them. For wasm, outDir is the dist "assets" folder; for Android it is
+// app/src/main/assets.
+func Bundle(src fs.FS, target Target, outDir string) (*Manifest, error) {
+	m := &Manifest{
+		Target: target,
+		Base:   target.BaseURL(),
+		Files:  map[string]string{},
+	}
+
+	err := fs.WalkDir(src, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(src, name)
+		if err != nil {
+			return err
+		}
+		hashed := hashedName(name, data)
+		dst := filepath.Join(outDir, filepath.FromSlash(hashed))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, data, 0o644); err != nil {
+			return err
+		}
+		m.Files[name] = hashed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(filepath.Join(outDir, ManifestName))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := m.Write(f); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func hashedName(name string, data []byte) string {
+	sum := sha256.Sum256(data)
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hex.EncodeToString(sum[:4]) + ext
+}
+
+func LoadManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	return &m, nil
+}
+
+func (m *Manifest) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// Names returns the logical asset paths in a stable order.
+func (m *Manifest) Names() []string {
+	names := make([]string, 0, len(m.Files))
+	for name := range m.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/GraHms/govinci/assets"
+)
+
+// govinci assets -src assets -target wasm -out wasm/dist/assets
+func runAssets(args []string) error {
+	fs := flag.NewFlagSet("assets", flag.ExitOnError)
+	src := fs.String("src", "assets", "directory with the source assets")
+	target := fs.String("target", string(assets.TargetWasm), "wasm or android")
+	out := fs.String("out", "", "output directory (default depends on target)")
+	_ = fs.Parse(args)
+
+	t := assets.Target(*target)
+	dir := *out
+	if dir == "" {
+		switch t {
+		case assets.TargetWasm:
+			dir = "wasm/dist/assets"
+		case assets.TargetAndroid:
+			dir = "android/app/src/main/assets"
+		default:
+			return fmt.Errorf("unknown target %q", *target)
+		}
+	}
+
+	m, err := assets.Bundle(os.DirFS(*src), t, dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range m.Names() {
+		fmt.Printf("%s -> %s%s\n", name, m.Base, m.Files[name])
+	}
+	return nil
+}
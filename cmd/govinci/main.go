@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+var commands = map[string]func(args []string) error{
+	"assets": runAssets,
+	"theme":  runTheme,
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: govinci <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  assets   bundle assets with content hashing for a target")
+	fmt.Fprintln(os.Stderr, "  theme    validate theme files and diff component defaults")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "govinci:", err)
+		os.Exit(1)
+	}
+}
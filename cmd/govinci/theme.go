@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GraHms/govinci/theme"
+)
+
+// govinci theme diff [-all] old.yaml new.yaml
+// govinci theme check theme.json
+func runTheme(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: govinci theme <diff|check> ...")
+	}
+	switch args[0] {
+	case "diff":
+		return themeDiff(args[1:])
+	case "check":
+		return themeCheck(args[1:])
+	}
+	return fmt.Errorf("unknown theme command %q", args[0])
+}
+
+func themeDiff(args []string) error {
+	fs := flag.NewFlagSet("theme diff", flag.ExitOnError)
+	all := fs.Bool("all", false, "show colors, typography and spacing too, not only component defaults and variants")
+	_ = fs.Parse(args)
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: govinci theme diff [-all] <old> <new>")
+	}
+
+	from, err := theme.LoadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	to, err := theme.LoadFile(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	changes := theme.Diff(from, to)
+	shown := 0
+	for _, c := range changes {
+		if !*all && !componentPath(c.Path) {
+			continue
+		}
+		fmt.Println(c)
+		shown++
+	}
+	if shown == 0 {
+		fmt.Println("no changes")
+	}
+	return nil
+}
+
+// componentPath reports whether a diff path is a component default or one of
+// its variants, the changes a theme diff shows without -all.
+func componentPath(p string) bool {
+	return strings.HasPrefix(p, "components.") || strings.HasPrefix(p, "variants.")
+}
+
+func themeCheck(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: govinci theme check <file>")
+	}
+	if _, err := theme.LoadFile(args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, args[0]+": ok")
+	return nil
+}
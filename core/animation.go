@@ -0,0 +1,248 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+type Easing string
+
+const (
+	EaseLinear    Easing = "linear"
+	Ease          Easing = "ease"
+	EaseIn        Easing = "ease-in"
+	EaseOut       Easing = "ease-out"
+	EaseInOut     Easing = "ease-in-out"
+	defaultEasing        = Ease
+)
+
+// CubicBezier builds a custom easing curve with CSS semantics.
+func CubicBezier(x1, y1, x2, y2 float64) Easing {
+	return Easing(fmt.Sprintf("cubic-bezier(%g, %g, %g, %g)", x1, y1, x2, y2))
+}
+
+// Infinite repeats an animation until it is replaced or the node unmounts.
+const Infinite = -1
+
+const defaultAnimationDuration = 300 // ms
+
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Float returns a pointer to v, for the optional Opacity and Scale fields.
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Animate describes the visual state a node animates to. Opacity and Scale
+// are pointers because 0 is a meaningful target; nil means 1. Translate is in
+// CSS pixels / dp and Rotate in degrees.
+type Animate struct {
+	Opacity   *float64
+	Translate Point
+	Scale     *float64
+	Rotate    float64
+
+	Duration int // ms; ignored when Spring is set
+	Delay    int // ms
+	Easing   Easing
+	Spring   *Spring
+
+	// Keyframes, when set, replace the single target above. Easing applies
+	// to each segment between two keyframes.
+	Keyframes  []Keyframe
+	Iterations int // 0 plays once; Infinite loops
+}
+
+type Keyframe struct {
+	Offset    float64 // 0..1 along the timeline
+	Opacity   *float64
+	Translate Point
+	Scale     *float64
+	Rotate    float64
+}
+
+// Spring replaces the duration and easing with a simulated damped spring.
+// Zero fields take the DefaultSpring values.
+type Spring struct {
+	Stiffness float64
+	Damping   float64
+	Mass      float64
+}
+
+var (
+	DefaultSpring = Spring{Stiffness: 170, Damping: 26, Mass: 1}
+	BouncySpring  = Spring{Stiffness: 180, Damping: 12, Mass: 1}
+	StiffSpring   = Spring{Stiffness: 400, Damping: 40, Mass: 1}
+)
+
+type AnimationProp interface {
+	Apply(*AnimationNode)
+}
+
+type AnimationNode struct {
+	Animate Animate
+	Enter   *Animate
+	Exit    *Animate
+}
+
+type animationFunc func(*AnimationNode)
+
+func (f animationFunc) Apply(n *AnimationNode) { f(n) }
+
+// Enter plays when the node mounts, from the given state to the Animate
+// target. With Keyframes it plays those instead.
+func Enter(from Animate) AnimationProp {
+	return animationFunc(func(n *AnimationNode) {
+		n.Enter = &from
+	})
+}
+
+// Exit plays when the node is removed or replaced; renderers keep the old
+// element around, detached from further patches, until it finishes.
+func Exit(to Animate) AnimationProp {
+	return animationFunc(func(n *AnimationNode) {
+		n.Exit = &to
+	})
+}
+
+// Animated animates view towards anim whenever anim changes between renders.
+// Keyframe animations restart when their description changes. The work is
+// done by the host (Web Animations, ViewPropertyAnimator), so Go only
+// re-renders when the target changes, not on every frame.
+func Animated(view View, anim Animate, props ...AnimationProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		node := view.Render(ctx)
+		if node == nil {
+			return nil
+		}
+		config := &AnimationNode{Animate: anim}
+		for _, p := range props {
+			p.Apply(config)
+		}
+
+		if node.Props == nil {
+			node.Props = map[string]any{}
+		}
+		node.Props["animate"] = config.Animate.motion()
+		if config.Enter != nil {
+			enter := config.Enter.motion()
+			if len(config.Enter.Keyframes) == 0 {
+				from := config.Enter.rest()
+				from.Offset = 0
+				enter.Frames = []frame{from, config.Animate.rest()}
+			}
+			node.Props["enter"] = enter
+		}
+		if config.Exit != nil {
+			node.Props["exit"] = config.Exit.motion()
+		}
+		return node
+	})
+}
+
+// motion is the platform-neutral form the renderers play: fully resolved
+// frames plus timing. A single frame means "from the current state".
+type motion struct {
+	Frames     []frame   `json:"frames"`
+	Duration   int       `json:"duration"`
+	Delay      int       `json:"delay,omitempty"`
+	Easing     string    `json:"easing,omitempty"`
+	Curve      []float64 `json:"curve,omitempty"` // sampled progress, replaces Easing
+	Iterations int       `json:"iterations,omitempty"`
+}
+
+type frame struct {
+	Offset  float64 `json:"offset"`
+	Opacity float64 `json:"opacity"`
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Scale   float64 `json:"scale"`
+	Rotate  float64 `json:"rotate"`
+}
+
+func (a Animate) motion() motion {
+	m := motion{
+		Duration:   a.Duration,
+		Delay:      a.Delay,
+		Easing:     string(a.Easing),
+		Iterations: a.Iterations,
+	}
+	if len(a.Keyframes) > 0 {
+		for _, k := range a.Keyframes {
+			m.Frames = append(m.Frames, newFrame(k.Offset, k.Opacity, k.Translate, k.Scale, k.Rotate))
+		}
+	} else {
+		m.Frames = []frame{a.rest()}
+	}
+
+	if a.Spring != nil {
+		m.Duration, m.Curve = a.Spring.sample()
+		m.Easing = ""
+		return m
+	}
+	if m.Duration == 0 {
+		m.Duration = defaultAnimationDuration
+	}
+	if m.Easing == "" {
+		m.Easing = string(defaultEasing)
+	}
+	return m
+}
+
+// rest is the state the node settles in: the target, or the last keyframe.
+func (a Animate) rest() frame {
+	if n := len(a.Keyframes); n > 0 {
+		k := a.Keyframes[n-1]
+		return newFrame(1, k.Opacity, k.Translate, k.Scale, k.Rotate)
+	}
+	return newFrame(1, a.Opacity, a.Translate, a.Scale, a.Rotate)
+}
+
+func newFrame(offset float64, opacity *float64, translate Point, scale *float64, rotate float64) frame {
+	f := frame{Offset: offset, Opacity: 1, X: translate.X, Y: translate.Y, Scale: 1, Rotate: rotate}
+	if opacity != nil {
+		f.Opacity = *opacity
+	}
+	if scale != nil {
+		f.Scale = *scale
+	}
+	return f
+}
+
+// sample simulates the spring from 0 to 1 in 1ms steps until it settles and
+// returns the progress sampled once per frame, with the matching duration.
+func (s Spring) sample() (int, []float64) {
+	k, c, m := s.Stiffness, s.Damping, s.Mass
+	if k <= 0 {
+		k = DefaultSpring.Stiffness
+	}
+	if c <= 0 {
+		c = DefaultSpring.Damping
+	}
+	if m <= 0 {
+		m = DefaultSpring.Mass
+	}
+
+	const (
+		dt      = 0.001
+		maxTime = 10000 // ms
+		every   = 16    // ms between samples
+	)
+	x, v := 0.0, 0.0
+	curve := []float64{0}
+	for ms := 1; ms < maxTime; ms++ {
+		v += (-k*(x-1) - c*v) / m * dt
+		x += v * dt
+		if math.Abs(x-1) < 0.001 && math.Abs(v) < 0.01 {
+			break
+		}
+		if ms%every == 0 {
+			curve = append(curve, math.Round(x*1000)/1000)
+		}
+	}
+	curve = append(curve, 1)
+	return (len(curve) - 1) * every, curve
+}
@@ -0,0 +1,88 @@
+package core
+
+import "sync"
+
+type ColorScheme string
+
+const (
+	SchemeLight ColorScheme = "light"
+	SchemeDark  ColorScheme = "dark"
+)
+
+var appearance = struct {
+	mu       sync.Mutex
+	system   ColorScheme
+	override ColorScheme
+}{
+	system: SchemeLight,
+}
+
+// The host reports the platform scheme (prefers-color-scheme on the web,
+// uiMode on Android) as {"colorScheme": "dark"}.
+func init() {
+	OnSystemEvent("appearance", func(data map[string]any) {
+		if scheme, ok := data["colorScheme"].(string); ok {
+			SetSystemColorScheme(ColorScheme(scheme))
+		}
+	})
+}
+
+// SetSystemColorScheme records the platform scheme and marks the root
+// context dirty so WithThemePair repaints.
+func SetSystemColorScheme(scheme ColorScheme) {
+	if scheme != SchemeDark {
+		scheme = SchemeLight
+	}
+	appearance.mu.Lock()
+	appearance.system = scheme
+	appearance.mu.Unlock()
+	MarkRootDirty()
+}
+
+// SetColorScheme forces a scheme regardless of the platform setting. An empty
+// scheme goes back to following the system. Like SetSystemColorScheme it
+// marks the root context dirty, whichever context it is called with.
+func SetColorScheme(ctx *Context, scheme ColorScheme) {
+	appearance.mu.Lock()
+	appearance.override = scheme
+	appearance.mu.Unlock()
+	MarkRootDirty()
+}
+
+func UseColorScheme(ctx *Context) ColorScheme {
+	appearance.mu.Lock()
+	defer appearance.mu.Unlock()
+
+	if appearance.override != "" {
+		return appearance.override
+	}
+	return appearance.system
+}
+
+type ThemePair struct {
+	Light *Theme
+	Dark  *Theme
+}
+
+func (p ThemePair) For(scheme ColorScheme) *Theme {
+	if scheme == SchemeDark && p.Dark != nil {
+		return p.Dark
+	}
+	return p.Light
+}
+
+// WithThemePair applies the light or dark theme following UseColorScheme, so
+// the subtree repaints when the platform appearance changes.
+func WithThemePair(pair ThemePair, children ...View) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		return WithTheme(pair.For(UseColorScheme(ctx)), children...).Render(ctx)
+	})
+}
+
+// WithPalette returns a copy of the theme using a different color palette.
+// Components that reference color tokens pick the new colors up as is.
+func (t *Theme) WithPalette(colors ColorPalette) *Theme {
+	out := *t
+	out.Colors = colors
+	return &out
+}
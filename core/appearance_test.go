@@ -0,0 +1,35 @@
+package core
+
+import "testing"
+
+func TestWithThemePairKeepsHookState(t *testing.T) {
+	ctx := NewContext()
+	var tab State[string]
+	view := WithThemePair(DefaultThemes, ComponentFunc(func(ctx *Context) *Node {
+		tab = NewState(ctx, "home")
+		return Text(tab.Get()).Render(ctx)
+	}))
+
+	view.Render(ctx)
+	tab.Set("search")
+	ctx.Reset()
+	view.Render(ctx)
+
+	if got := tab.Get(); got != "search" {
+		t.Errorf("state after re-render = %q, want %q", got, "search")
+	}
+}
+
+func TestSetColorSchemeMarksRoot(t *testing.T) {
+	root := NewContext()
+	SetRootContext(root)
+	t.Cleanup(func() {
+		SetRootContext(nil)
+		SetColorScheme(nil, "")
+	})
+
+	SetColorScheme(root.NewChildContext(), SchemeDark)
+	if !root.IsDirty() {
+		t.Error("root context not marked dirty")
+	}
+}
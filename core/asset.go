@@ -0,0 +1,47 @@
+package core
+
+import (
+	"strings"
+	"sync"
+)
+
+const assetScheme = "asset://"
+
+var (
+	assetResolverMux sync.RWMutex
+	assetResolver    func(path string) string
+)
+
+// Asset references a bundled file, e.g. Image(Asset("icons/home.png")).
+// The reference is turned into a platform URL by ResolveAsset at render time.
+func Asset(path string) string {
+	return assetScheme + strings.TrimPrefix(path, "/")
+}
+
+func IsAsset(src string) bool {
+	return strings.HasPrefix(src, assetScheme)
+}
+
+// SetAssetResolver installs the function that maps an asset path to the URL
+// the current platform can load. The assets package installs one via Use.
+func SetAssetResolver(fn func(path string) string) {
+	assetResolverMux.Lock()
+	assetResolver = fn
+	assetResolverMux.Unlock()
+}
+
+func ResolveAsset(src string) string {
+	if !IsAsset(src) {
+		return src
+	}
+	path := strings.TrimPrefix(src, assetScheme)
+
+	assetResolverMux.RLock()
+	fn := assetResolver
+	assetResolverMux.RUnlock()
+
+	if fn == nil {
+		return "assets/" + path
+	}
+	return fn(path)
+}
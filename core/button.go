@@ -2,18 +2,21 @@ package core
 
 func Button(label string, onClick func(), styleProps ...StyleProp) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Button
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
+		style := themedStyle(ctx, "button", ctx.Theme().Components.Button, styleProps)
+
+		resolveStyle(ctx, style)
+
+		props := map[string]any{
+			"label": label,
+		}
+		if !style.Disabled {
+			props["onClick"] = registerCallback(onClick)
 		}
+		stateProps(style, props)
 
 		return &Node{
-			Type: "Button",
-			Props: map[string]any{
-				"label":   label,
-				"onClick": registerCallback(onClick),
-			},
+			Type:  "Button",
+			Props: props,
 			Style: style,
 		}
 	})
@@ -21,16 +24,17 @@ func Button(label string, onClick func(), styleProps ...StyleProp) View {
 
 func ButtonWithEvent(label string, event string, handler func(), styleProps ...StyleProp) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Button
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
-		}
+		style := themedStyle(ctx, "button", ctx.Theme().Components.Button, styleProps)
 
 		props := map[string]any{
 			"label": label,
 		}
-		props["on"+event] = registerCallback(handler)
+		if !style.Disabled {
+			props["on"+event] = registerCallback(handler)
+		}
+		stateProps(style, props)
+
+		resolveStyle(ctx, style)
 
 		return &Node{
 			Type:  "Button",
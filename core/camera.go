@@ -1,17 +1,24 @@
 package core
 
+import "strings"
+
 type CameraProp interface {
 	Apply(*CameraNode)
 }
 
 type CameraNode struct {
-	OnCapture func(string)
-	OnError   func(string)
-	Active    bool
-	Flash     bool
-	Facing    string
-	Overlay   View
-	Style     Style
+	OnCapture     func(CapturedImage)
+	OnRecord      func(CapturedVideo)
+	OnCodeScanned func(ScannedCode)
+	OnError       func(string)
+	Active        bool
+	Flash         bool
+	Facing        string
+	Zoom          float64
+	ScanFormats   []string
+	Controller    *CameraController
+	Overlay       View
+	Style         Style
 }
 
 func CameraView(props ...CameraProp) View {
@@ -19,20 +26,62 @@ func CameraView(props ...CameraProp) View {
 		node := &CameraNode{
 			Active: true, // default
 			Facing: "rear",
+			Zoom:   1,
+			Style:  ctx.Theme().Components.Camera,
 		}
 
 		for _, p := range props {
 			p.Apply(node)
 		}
 
+		if c := node.Controller; c != nil {
+			if c.facing != "" {
+				node.Facing = c.facing
+			}
+			if c.zoom != 0 {
+				node.Zoom = c.zoom
+			}
+		}
+
 		propMap := map[string]any{
 			"active": node.Active,
 			"flash":  node.Flash,
 			"facing": node.Facing,
+			"zoom":   node.Zoom,
+		}
+
+		if node.Controller != nil {
+			propMap["controller"] = node.Controller.ID
 		}
 
 		if node.OnCapture != nil {
-			propMap["onCapture"] = registerTextCallback(node.OnCapture)
+			onCapture := node.OnCapture
+			propMap["onCapture"] = registerDataCallback(func(data map[string]any) {
+				onCapture(parseCapturedImage(data))
+			})
+		}
+
+		if node.OnRecord != nil {
+			onRecord := node.OnRecord
+			propMap["onRecord"] = registerDataCallback(func(data map[string]any) {
+				onRecord(parseCapturedVideo(data))
+			})
+		}
+
+		if node.OnCodeScanned != nil {
+			onScan := node.OnCodeScanned
+			formats := strings.Join(node.ScanFormats, ",")
+			if formats == "" {
+				formats = "qr_code"
+			}
+			// comma-joined: props must stay comparable for the diff
+			propMap["scanFormats"] = formats
+			propMap["onCodeScanned"] = registerDataCallback(func(data map[string]any) {
+				onScan(ScannedCode{
+					Format: stringField(data, "format"),
+					Value:  stringField(data, "value"),
+				})
+			})
 		}
 
 		if node.OnError != nil {
@@ -44,6 +93,8 @@ func CameraView(props ...CameraProp) View {
 			children = append(children, node.Overlay)
 		}
 
+		resolveStyle(ctx, &node.Style)
+
 		return &Node{
 			Type:     "CameraView",
 			Props:    propMap,
@@ -69,18 +120,45 @@ func SetFacing(facing string) CameraProp {
 	})
 }
 
+func Zoom(level float64) CameraProp {
+	return cameraFunc(func(c *CameraNode) {
+		c.Zoom = level
+	})
+}
+
 func OnError(fn func(string)) CameraProp {
 	return cameraFunc(func(c *CameraNode) {
 		c.OnError = fn
 	})
 }
 
-func OnCapture(fn func(string)) CameraProp {
+func OnCapture(fn func(CapturedImage)) CameraProp {
 	return cameraFunc(func(c *CameraNode) {
 		c.OnCapture = fn
 	})
 }
 
+func OnRecord(fn func(CapturedVideo)) CameraProp {
+	return cameraFunc(func(c *CameraNode) {
+		c.OnRecord = fn
+	})
+}
+
+// ScanCodes switches the camera into frame-analysis mode. Formats follow the
+// BarcodeDetector names ("qr_code", "ean_13", "code_128"...), defaulting to QR.
+func ScanCodes(fn func(ScannedCode), formats ...string) CameraProp {
+	return cameraFunc(func(c *CameraNode) {
+		c.OnCodeScanned = fn
+		c.ScanFormats = formats
+	})
+}
+
+func WithController(ctrl *CameraController) CameraProp {
+	return cameraFunc(func(c *CameraNode) {
+		c.Controller = ctrl
+	})
+}
+
 func WithOverlay(view View) CameraProp {
 	return cameraFunc(func(c *CameraNode) {
 		c.Overlay = view
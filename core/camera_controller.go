@@ -0,0 +1,202 @@
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type CapturedImage struct {
+	Data     []byte
+	MimeType string
+	Width    int
+	Height   int
+}
+
+type CapturedVideo struct {
+	Data     []byte
+	MimeType string
+	Duration float64 // seconds
+}
+
+type ScannedCode struct {
+	Format string
+	Value  string
+}
+
+// CameraController drives a CameraView imperatively. Commands travel to the
+// host through the "camera" system event, addressed by the controller ID that
+// the view exposes in its props.
+type CameraController struct {
+	ID        string
+	mu        sync.Mutex
+	facing    string
+	zoom      float64
+	recording bool
+}
+
+var cameraCounter int
+
+func UseCamera(ctx *Context) *CameraController {
+	index := ctx.Cursor
+	ctx.Cursor++
+
+	if index >= len(ctx.slots) {
+		callbackMux.Lock()
+		id := fmt.Sprintf("camera_%d", cameraCounter)
+		cameraCounter++
+		callbackMux.Unlock()
+		ctx.slots = append(ctx.slots, &CameraController{ID: id, facing: "rear", zoom: 1})
+	}
+	return ctx.slots[index].(*CameraController)
+}
+
+func (c *CameraController) Capture() {
+	c.send("capture", nil)
+}
+
+func (c *CameraController) SwitchFacing() {
+	c.mu.Lock()
+	if c.facing == "front" {
+		c.facing = "rear"
+	} else {
+		c.facing = "front"
+	}
+	facing := c.facing
+	c.mu.Unlock()
+
+	c.send("facing", map[string]any{"facing": facing})
+}
+
+func (c *CameraController) Facing() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.facing
+}
+
+func (c *CameraController) SetZoom(level float64) {
+	if level < 1 {
+		level = 1
+	}
+	c.mu.Lock()
+	c.zoom = level
+	c.mu.Unlock()
+
+	c.send("zoom", map[string]any{"zoom": level})
+}
+
+func (c *CameraController) StartRecording() {
+	c.mu.Lock()
+	if c.recording {
+		c.mu.Unlock()
+		return
+	}
+	c.recording = true
+	c.mu.Unlock()
+
+	c.send("record-start", nil)
+}
+
+// StopRecording ends the current recording; the clip is delivered to the
+// view's OnRecord handler.
+func (c *CameraController) StopRecording() {
+	c.mu.Lock()
+	if !c.recording {
+		c.mu.Unlock()
+		return
+	}
+	c.recording = false
+	c.mu.Unlock()
+
+	c.send("record-stop", nil)
+}
+
+func (c *CameraController) IsRecording() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recording
+}
+
+func (c *CameraController) send(action string, extra map[string]any) {
+	payload := map[string]any{
+		"controller": c.ID,
+		"action":     action,
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	SendSystemEvent("camera", payload)
+}
+
+func parseCapturedImage(data map[string]any) CapturedImage {
+	raw, mime := decodeMediaData(stringField(data, "data"))
+	if m := stringField(data, "mime"); m != "" {
+		mime = m
+	}
+	return CapturedImage{
+		Data:     raw,
+		MimeType: mime,
+		Width:    intField(data, "width"),
+		Height:   intField(data, "height"),
+	}
+}
+
+func parseCapturedVideo(data map[string]any) CapturedVideo {
+	raw, mime := decodeMediaData(stringField(data, "data"))
+	if m := stringField(data, "mime"); m != "" {
+		mime = m
+	}
+	duration, _ := data["duration"].(float64)
+	return CapturedVideo{
+		Data:     raw,
+		MimeType: mime,
+		Duration: duration,
+	}
+}
+
+// decodeMediaData accepts either plain base64 or a data URL and returns the
+// raw bytes together with the mime type found in the URL header, if any.
+func decodeMediaData(s string) ([]byte, string) {
+	mime := ""
+	if strings.HasPrefix(s, "data:") {
+		header, body, ok := strings.Cut(s, ",")
+		if !ok {
+			return nil, ""
+		}
+		mime = strings.TrimSuffix(strings.TrimPrefix(header, "data:"), ";base64")
+		s = body
+	}
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, mime
+	}
+	return raw, mime
+}
+
+func stringField(data map[string]any, key string) string {
+	if v, ok := data[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func intField(data map[string]any, key string) int {
+	switch v := data[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+func floatField(data map[string]any, key string) float64 {
+	switch v := data[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	return 0
+}
@@ -17,21 +17,59 @@ type Context struct {
 	usedCallbacks   map[string]bool
 	dirty           bool
 	parent          *Context
+	window          *WindowSize // see WithWindowSizeOpt
 
 	children       []*Context
 	childrenCursor int
 }
 
+// MarkDirty, IsDirty and ClearDirty take ctx.lock: goroutines (finished
+// thumbnails, forms, intervals) mark the context while the host polls it.
 func (ctx *Context) MarkDirty() {
+	ctx.lock.Lock()
 	ctx.dirty = true
+	ctx.lock.Unlock()
+}
+
+// The context the host renders from and polls with IsDirty; see
+// SetRootContext.
+var root struct {
+	mu  sync.Mutex
+	ctx *Context
+}
+
+// SetRootContext tells core which context to mark dirty when something
+// outside the tree changes what it renders, such as a finished thumbnail.
+// render.New calls it with the manager's context.
+func SetRootContext(ctx *Context) {
+	root.mu.Lock()
+	root.ctx = ctx
+	root.mu.Unlock()
+}
+
+// MarkRootDirty asks the host for a render from any goroutine. Code that
+// finishes work in the background (async validation, a submit handler)
+// calls it rather than MarkDirty on its own context, which the host never
+// polls.
+func MarkRootDirty() {
+	root.mu.Lock()
+	ctx := root.ctx
+	root.mu.Unlock()
+	if ctx != nil {
+		ctx.MarkDirty()
+	}
 }
 
 func (ctx *Context) IsDirty() bool {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
 	return ctx.dirty
 }
 
 func (ctx *Context) ClearDirty() {
+	ctx.lock.Lock()
 	ctx.dirty = false
+	ctx.lock.Unlock()
 }
 
 type AppConfig struct {
@@ -63,6 +101,7 @@ func (ctx *Context) NewChildContext() *Context {
 		callbackMap:     ctx.callbackMap,
 		callbackCounter: ctx.callbackCounter,
 		parent:          ctx,
+		window:          ctx.window,
 	}
 }
 func UseChildContext(ctx *Context) *Context {
@@ -111,6 +150,7 @@ func (ctx *Context) WithConfig(cfg *AppConfig) *Context {
 		renderManager:   ctx.renderManager,
 		callbackMap:     ctx.callbackMap,
 		callbackCounter: ctx.callbackCounter,
+		window:          ctx.window,
 	}
 }
 
@@ -123,6 +163,7 @@ func (ctx *Context) WithTheme(theme *Theme) *Context {
 		renderManager:   ctx.renderManager,
 		callbackMap:     ctx.callbackMap,
 		callbackCounter: ctx.callbackCounter,
+		window:          ctx.window,
 	}
 }
 
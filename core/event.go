@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 )
 
@@ -11,10 +12,14 @@ var (
 	callbacks     = map[string]func(){}
 	textCallbacks = map[string]func(string){}
 	boolCallbacks = map[string]func(bool){}
+	dataCallbacks = map[string]func(map[string]any){}
+	numCallbacks  = map[string]func(float64){}
 	callbackMux   sync.Mutex
 	counter       int
 	textCounter   int
 	boolCounter   int
+	dataCounter   int
+	numCounter    int
 
 	usedCallbacks = map[string]bool{}
 )
@@ -30,13 +35,20 @@ func registerCallback(fn func()) string {
 	return id
 }
 
+// TriggerCallback runs the handler registered under id. Handlers run
+// without callbackMux held: they may send system events whose host side
+// calls straight back into Go (a blur when focus moves, a camera capture),
+// and the same goes for the other Trigger functions.
 func TriggerCallback(id string) {
 	callbackMux.Lock()
-	defer callbackMux.Unlock()
+	fn, ok := callbacks[id]
+	if ok {
+		usedCallbacks[id] = true
+	}
+	callbackMux.Unlock()
 
-	if fn, ok := callbacks[id]; ok {
+	if ok {
 		fn()
-		usedCallbacks[id] = true
 	}
 }
 
@@ -53,11 +65,14 @@ func registerTextCallback(fn func(string)) string {
 
 func TriggerTextCallback(id string, val string) {
 	callbackMux.Lock()
-	defer callbackMux.Unlock()
+	fn, ok := textCallbacks[id]
+	if ok {
+		usedCallbacks[id] = true
+	}
+	callbackMux.Unlock()
 
-	if fn, ok := textCallbacks[id]; ok {
+	if ok {
 		fn(val)
-		usedCallbacks[id] = true
 	}
 }
 
@@ -73,13 +88,68 @@ func registerBoolCallback(fn func(bool)) string {
 }
 
 func TriggerBoolCallback(id string, val bool) {
+	callbackMux.Lock()
+	fn, ok := boolCallbacks[id]
+	if ok {
+		usedCallbacks[id] = true
+	}
+	callbackMux.Unlock()
+
+	if ok {
+		fn(val)
+	}
+}
+
+// registerDataCallback registers a handler for structured payloads sent by the
+// host (camera captures, gestures, scroll metrics...).
+func registerDataCallback(fn func(map[string]any)) string {
 	callbackMux.Lock()
 	defer callbackMux.Unlock()
 
-	if fn, ok := boolCallbacks[id]; ok {
+	id := fmt.Sprintf("data_cb_%d", dataCounter)
+	dataCounter++
+	dataCallbacks[id] = fn
+	usedCallbacks[id] = true
+	return id
+}
+
+func TriggerDataCallback(id string, val map[string]any) {
+	callbackMux.Lock()
+	fn, ok := dataCallbacks[id]
+	if ok {
+		usedCallbacks[id] = true
+	}
+	callbackMux.Unlock()
+
+	if ok {
 		fn(val)
+	}
+}
+
+// registerNumberCallback registers a handler for numeric payloads (slider
+// positions...).
+func registerNumberCallback(fn func(float64)) string {
+	callbackMux.Lock()
+	defer callbackMux.Unlock()
+
+	id := fmt.Sprintf("num_cb_%d", numCounter)
+	numCounter++
+	numCallbacks[id] = fn
+	usedCallbacks[id] = true
+	return id
+}
+
+func TriggerNumberCallback(id string, val float64) {
+	callbackMux.Lock()
+	fn, ok := numCallbacks[id]
+	if ok {
 		usedCallbacks[id] = true
 	}
+	callbackMux.Unlock()
+
+	if ok {
+		fn(val)
+	}
 }
 
 func ReceiveEventPayload(payload map[string]any) {
@@ -110,6 +180,15 @@ func ReceiveEventPayload(payload map[string]any) {
 
 	case bool:
 		TriggerBoolCallback(id, val)
+	case map[string]any:
+		TriggerDataCallback(id, val)
+	case float64:
+		// JSON numbers; int callbacks (TabView) take them truncated
+		if strings.HasPrefix(id, "int_cb_") {
+			TriggerIntCallback(id, int(val))
+		} else {
+			TriggerNumberCallback(id, val)
+		}
 	case nil:
 		TriggerCallback(id)
 	default:
@@ -124,6 +203,8 @@ func PurgeUnusedCallbacks() {
 	newCallbacks := make(map[string]func())
 	newTextCallbacks := make(map[string]func(string))
 	newBoolCallbacks := make(map[string]func(bool))
+	newDataCallbacks := make(map[string]func(map[string]any))
+	newNumCallbacks := make(map[string]func(float64))
 
 	for id, fn := range callbacks {
 		if usedCallbacks[id] {
@@ -141,8 +222,22 @@ func PurgeUnusedCallbacks() {
 		}
 	}
 
+	for id, fn := range dataCallbacks {
+		if usedCallbacks[id] {
+			newDataCallbacks[id] = fn
+		}
+	}
+
+	for id, fn := range numCallbacks {
+		if usedCallbacks[id] {
+			newNumCallbacks[id] = fn
+		}
+	}
+
 	callbacks = newCallbacks
 	textCallbacks = newTextCallbacks
 	boolCallbacks = newBoolCallbacks
+	dataCallbacks = newDataCallbacks
+	numCallbacks = newNumCallbacks
 	usedCallbacks = make(map[string]bool) // Clean up
 }
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// withHost installs a system event handler for the test, standing in for a
+// host that answers synchronously.
+func withHost(t *testing.T, fn func(name string, data map[string]any)) {
+	t.Helper()
+	SetSystemEventHandler(fn)
+	t.Cleanup(func() { SetSystemEventHandler(nil) })
+}
+
+// noDeadlock fails the test if fn does not return in time.
+func noDeadlock(t *testing.T, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deadlock: callback did not return")
+	}
+}
+
+func TestTriggerCallbackReentrant(t *testing.T) {
+	var captured map[string]any
+	onCapture := registerDataCallback(func(data map[string]any) { captured = data })
+
+	// a camera that captures as soon as it is asked to
+	withHost(t, func(name string, data map[string]any) {
+		if name == "camera" {
+			TriggerDataCallback(onCapture, map[string]any{"mime": "image/jpeg"})
+		}
+	})
+	onClick := registerCallback(func() {
+		SendSystemEvent("camera", map[string]any{"action": "capture"})
+	})
+
+	noDeadlock(t, func() { TriggerCallback(onClick) })
+	if captured["mime"] != "image/jpeg" {
+		t.Errorf("capture not delivered: %v", captured)
+	}
+}
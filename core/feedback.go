@@ -0,0 +1,364 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Indeterminate makes a ProgressBar show activity instead of a fraction.
+const Indeterminate = -1
+
+// ProgressBar shows value, a fraction between 0 and 1, as a filled track in
+// the theme's Components.Progress; the fill takes the track's text color.
+func ProgressBar(value float64, styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		style := themedStyle(ctx, "progress", ctx.Theme().Components.Progress, styleProps)
+		resolveStyle(ctx, style)
+
+		fill := &Style{
+			Background:   style.TextColor,
+			BorderRadius: style.BorderRadius,
+			Height:       "100%",
+		}
+		props := map[string]any{}
+		if value < 0 {
+			props["indeterminate"] = true
+			fill.Width = "40%"
+			fill.Position = PositionAbsolute
+			fill.Animation = "gv-indeterminate 1.2s ease-in-out infinite"
+		} else {
+			value = min(value, 1)
+			props["progress"] = value
+			fill.Width = fmt.Sprintf("%g%%", value*100)
+			fill.Transition = "width 0.2s ease"
+		}
+		stateProps(style, props)
+
+		return &Node{
+			Type:     "ProgressBar",
+			Props:    props,
+			Style:    style,
+			Children: []*Node{{Type: "ProgressFill", Style: fill}},
+		}
+	})
+}
+
+// Spinner is an indeterminate circular indicator in the text color of the
+// theme's Components.Spinner.
+func Spinner(styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		style := themedStyle(ctx, "spinner", ctx.Theme().Components.Spinner, styleProps)
+		resolveStyle(ctx, style)
+
+		return &Node{
+			Type:  "Spinner",
+			Props: map[string]any{},
+			Style: style,
+		}
+	})
+}
+
+// Skeleton is a pulsing placeholder for content that is still loading. Size
+// it like what it stands in for; BorderRadius turns it into a circle.
+func Skeleton(styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		style := themedStyle(ctx, "skeleton", ctx.Theme().Components.Skeleton, styleProps)
+		resolveStyle(ctx, style)
+
+		return &Node{
+			Type:  "Skeleton",
+			Props: map[string]any{},
+			Style: style,
+		}
+	})
+}
+
+// SkeletonText stands in for a paragraph: lines Skeletons, the last one
+// shorter.
+func SkeletonText(lines int, styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		children := make([]*Node, lines)
+		for i := range children {
+			props := styleProps
+			if i == lines-1 && lines > 1 {
+				props = append(styleProps[:len(styleProps):len(styleProps)], Width("60%"))
+			}
+			children[i] = Skeleton(props...).Render(ctx)
+		}
+		return &Node{
+			Type:     "Column",
+			Style:    &Style{Display: DisplayFlex, FlexDirection: FlexColumn, Gap: 8},
+			Children: children,
+		}
+	})
+}
+
+// Badge pins label to the top right corner of child, in the theme's
+// Components.Badge. An empty label shows a dot.
+func Badge(child View, label string, styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		base := ctx.Theme().Components.Badge
+		if label == "" {
+			base = base.Merge(Style{Width: "10px", Height: "10px", MinWidth: "10px", Unset: FieldPadding})
+		}
+		style := themedStyle(ctx, "badge", base, styleProps)
+		resolveStyle(ctx, style)
+
+		return &Node{
+			Type:  "Badge",
+			Props: map[string]any{},
+			Style: &Style{Position: PositionRelative, Display: DisplayInlineBlock},
+			Children: []*Node{
+				child.Render(ctx),
+				{Type: "Text", Props: map[string]any{"content": label}, Style: style},
+			},
+		}
+	})
+}
+
+// CountBadge is a Badge showing count, capped at max ("99+"), and nothing
+// while count is 0.
+func CountBadge(child View, count, max int, styleProps ...StyleProp) View {
+	if count <= 0 {
+		return child
+	}
+	label := fmt.Sprint(count)
+	if max > 0 && count > max {
+		label = fmt.Sprintf("%d+", max)
+	}
+	return Badge(child, label, styleProps...)
+}
+
+type AvatarStatus string
+
+const (
+	StatusNone    AvatarStatus = ""
+	StatusOnline  AvatarStatus = "online"
+	StatusAway    AvatarStatus = "away"
+	StatusBusy    AvatarStatus = "busy"
+	StatusOffline AvatarStatus = "offline"
+)
+
+var statusColors = map[AvatarStatus]string{
+	StatusOnline:  "#34C759",
+	StatusAway:    "#FF9500",
+	StatusBusy:    Token("colors.error"),
+	StatusOffline: "#8E8E93",
+}
+
+type AvatarProp interface {
+	Apply(*AvatarNode)
+}
+
+type AvatarNode struct {
+	Size   float64
+	Status AvatarStatus
+}
+
+type avatarFunc func(*AvatarNode)
+
+func (f avatarFunc) Apply(n *AvatarNode) { f(n) }
+
+// AvatarSize sets the diameter in px; the initials scale with it.
+func AvatarSize(px float64) AvatarProp {
+	return avatarFunc(func(n *AvatarNode) {
+		n.Size = px
+	})
+}
+
+// WithStatus adds a presence dot to the bottom right of the avatar.
+func WithStatus(status AvatarStatus) AvatarProp {
+	return avatarFunc(func(n *AvatarNode) {
+		n.Status = status
+	})
+}
+
+// Avatar shows the picture at src in a circle, or name's initials while
+// there is none or it fails to load.
+func Avatar(src, name string, props ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		var styleProps []StyleProp
+		config := &AvatarNode{}
+		for _, item := range props {
+			switch v := item.(type) {
+			case StyleProp:
+				styleProps = append(styleProps, v)
+			case AvatarProp:
+				v.Apply(config)
+			}
+		}
+		if config.Size > 0 {
+			size := fmt.Sprintf("%gpx", config.Size)
+			sized := []StyleProp{Width(size), Height(size), BorderRadius(config.Size / 2), FontSize(math.Round(config.Size * 0.4))}
+			styleProps = append(sized, styleProps...)
+		}
+		style := themedStyle(ctx, "avatar", ctx.Theme().Components.Avatar, styleProps)
+		resolveStyle(ctx, style)
+
+		label := Text(initials(name), TextColor(style.TextColor), FontSize(style.FontSize), FontWeight(style.FontWeight))
+		face := label
+		if src != "" {
+			face = Image(src, ErrorView(label),
+				Width("100%"), Height("100%"), BorderRadius(style.BorderRadius), Overflow("hidden"))
+		}
+		children := []*Node{face.Render(ctx)}
+
+		if color, ok := statusColors[config.Status]; ok {
+			dot := &Style{
+				Width:        "25%",
+				Height:       "25%",
+				Background:   color,
+				BorderColor:  Token("colors.background"),
+				BorderWidth:  2,
+				BorderRadius: 999,
+				Position:     PositionAbsolute,
+				Right:        "0",
+				Bottom:       "0",
+			}
+			resolveStyle(ctx, dot)
+			children = append(children, &Node{
+				Type:  "AvatarStatus",
+				Props: map[string]any{"status": string(config.Status)},
+				Style: dot,
+			})
+		}
+
+		return &Node{
+			Type:     "Avatar",
+			Props:    map[string]any{},
+			Style:    style,
+			Children: children,
+		}
+	})
+}
+
+// initials takes the first letter of the first and last words of name.
+func initials(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ""
+	}
+	first := func(word string) string {
+		for _, r := range word {
+			return string(unicode.ToUpper(r))
+		}
+		return ""
+	}
+	if len(words) == 1 {
+		return first(words[0])
+	}
+	return first(words[0]) + first(words[len(words)-1])
+}
+
+// Snackbars queue up and SnackbarHost shows them one at a time, each until
+// its duration runs out or its action is pressed.
+type snackbar struct {
+	id       int
+	message  string
+	duration int
+	action   string
+	onAction func()
+}
+
+var (
+	snackbarMux     sync.Mutex
+	snackbarQueue   []*snackbar
+	snackbarCounter int
+)
+
+type SnackbarOpt interface {
+	Apply(*snackbar)
+}
+
+type snackbarFunc func(*snackbar)
+
+func (f snackbarFunc) Apply(s *snackbar) { f(s) }
+
+// SnackbarAction adds a button to the snackbar; pressing it runs fn and
+// dismisses the snackbar.
+func SnackbarAction(label string, fn func()) SnackbarOpt {
+	return snackbarFunc(func(s *snackbar) {
+		s.action = label
+		s.onAction = fn
+	})
+}
+
+// SnackbarDuration sets how long the snackbar stays up, in ms (4000 by
+// default). 0 keeps it until its action is pressed.
+func SnackbarDuration(ms int) SnackbarOpt {
+	return snackbarFunc(func(s *snackbar) {
+		s.duration = ms
+	})
+}
+
+// ShowSnackbar queues message for the SnackbarHost. Like any state change it
+// shows on the next render, so call it from an event handler.
+func ShowSnackbar(message string, opts ...SnackbarOpt) {
+	s := &snackbar{message: message, duration: 4000}
+	for _, opt := range opts {
+		opt.Apply(s)
+	}
+
+	snackbarMux.Lock()
+	snackbarCounter++
+	s.id = snackbarCounter
+	snackbarQueue = append(snackbarQueue, s)
+	snackbarMux.Unlock()
+}
+
+// dismissSnackbar drops the snackbar with id if it is still the one showing;
+// a late timer must not take the next one down.
+func dismissSnackbar(id int) {
+	snackbarMux.Lock()
+	defer snackbarMux.Unlock()
+	if len(snackbarQueue) > 0 && snackbarQueue[0].id == id {
+		snackbarQueue = snackbarQueue[1:]
+	}
+}
+
+// SnackbarHost shows the first queued snackbar, in the theme's
+// Components.Snackbar, pinned to the bottom of the screen. Place it once,
+// last in the root view.
+func SnackbarHost(styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		snackbarMux.Lock()
+		var s *snackbar
+		if len(snackbarQueue) > 0 {
+			s = snackbarQueue[0]
+		}
+		snackbarMux.Unlock()
+		if s == nil {
+			return Fragment().Render(ctx)
+		}
+
+		style := themedStyle(ctx, "snackbar", ctx.Theme().Components.Snackbar, styleProps)
+		resolveStyle(ctx, style)
+
+		id := s.id
+		children := []*Node{
+			Text(s.message, TextColor(style.TextColor), FontSize(style.FontSize), FlexGrow(1)).Render(ctx),
+		}
+		if s.action != "" {
+			children = append(children, Button(s.action, func() {
+				dismissSnackbar(id)
+				if s.onAction != nil {
+					s.onAction()
+				}
+			}, Variant(ButtonTextOnly)).Render(ctx))
+		}
+
+		return &Node{
+			Type: "Snackbar",
+			Props: map[string]any{
+				"key":       fmt.Sprintf("snack-%d", id),
+				"duration":  s.duration,
+				"onDismiss": registerCallback(func() { dismissSnackbar(id) }),
+			},
+			Style:    style,
+			Children: children,
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// FocusManager moves focus between inputs from Go. Inputs join with
+// Field(name); Next and Previous follow the order in which they first
+// rendered. Commands travel through the "focus" system event.
+//
+//	focus := UseFocusManager(ctx)
+//	Input(name, "Name", setName, focus.Field("name"), ReturnKey(ReturnNext), OnSubmit(func(string) { focus.Next() }))
+//	Input(email, "Email", setEmail, focus.Field("email"), ReturnKey(ReturnDone))
+type FocusManager struct {
+	ID      string
+	mu      sync.Mutex
+	order   []string
+	current string
+}
+
+var focusCounter int
+
+func UseFocusManager(ctx *Context) *FocusManager {
+	index := ctx.Cursor
+	ctx.Cursor++
+
+	if index >= len(ctx.slots) {
+		callbackMux.Lock()
+		id := fmt.Sprintf("focus_%d", focusCounter)
+		focusCounter++
+		callbackMux.Unlock()
+		ctx.slots = append(ctx.slots, &FocusManager{ID: id})
+	}
+	return ctx.slots[index].(*FocusManager)
+}
+
+func (f *FocusManager) Field(name string) InputProp {
+	f.mu.Lock()
+	if !slices.Contains(f.order, name) {
+		f.order = append(f.order, name)
+	}
+	f.mu.Unlock()
+	return inputFunc(func(n *InputNode) {
+		n.focus = f
+		n.focusName = name
+	})
+}
+
+func (f *FocusManager) Focus(name string) {
+	SendSystemEvent("focus", map[string]any{
+		"target": f.target(name),
+		"action": "focus",
+	})
+}
+
+// Blur drops focus from the current field, which closes the keyboard.
+func (f *FocusManager) Blur() {
+	current := f.Focused()
+	if current == "" {
+		return
+	}
+	SendSystemEvent("focus", map[string]any{
+		"target": f.target(current),
+		"action": "blur",
+	})
+}
+
+// Focused is the field that has focus, or "".
+func (f *FocusManager) Focused() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.current
+}
+
+// Next focuses the field after the current one; past the last field it
+// blurs, which closes the keyboard.
+func (f *FocusManager) Next() {
+	f.move(1)
+}
+
+func (f *FocusManager) Previous() {
+	f.move(-1)
+}
+
+func (f *FocusManager) move(step int) {
+	f.mu.Lock()
+	i := slices.Index(f.order, f.current) + step
+	var name string
+	if f.current != "" && i >= 0 && i < len(f.order) {
+		name = f.order[i]
+	} else if f.current == "" && len(f.order) > 0 {
+		name = f.order[0]
+	}
+	f.mu.Unlock()
+
+	if name == "" {
+		f.Blur()
+		return
+	}
+	f.Focus(name)
+}
+
+func (f *FocusManager) target(name string) string {
+	return f.ID + "/" + name
+}
+
+func (f *FocusManager) focused(name string) {
+	f.mu.Lock()
+	f.current = name
+	f.mu.Unlock()
+}
+
+func (f *FocusManager) blurred(name string) {
+	f.mu.Lock()
+	if f.current == name {
+		f.current = ""
+	}
+	f.mu.Unlock()
+}
@@ -0,0 +1,35 @@
+package core
+
+import "testing"
+
+// TestFocusNextFromSubmit follows the documented usage: OnSubmit calls
+// focus.Next(), and the host blurs and focuses the inputs before
+// SendSystemEvent returns, as el.focus() does in the browser.
+func TestFocusNextFromSubmit(t *testing.T) {
+	ctx := NewContext()
+	focus := UseFocusManager(ctx)
+	name := Input("", "Name", func(string) {}, focus.Field("name"), OnSubmit(func(string) { focus.Next() })).Render(ctx)
+	email := Input("", "Email", func(string) {}, focus.Field("email")).Render(ctx)
+
+	inputs := map[string]*Node{
+		focus.target("name"):  name,
+		focus.target("email"): email,
+	}
+	withHost(t, func(event string, data map[string]any) {
+		if event != "focus" {
+			return
+		}
+		if current := focus.Focused(); current != "" {
+			TriggerCallback(inputs[focus.target(current)].Props["onBlur"].(string))
+		}
+		if data["action"] == "focus" {
+			TriggerCallback(inputs[data["target"].(string)].Props["onFocus"].(string))
+		}
+	})
+
+	TriggerCallback(name.Props["onFocus"].(string))
+	noDeadlock(t, func() { TriggerTextCallback(name.Props["onSubmit"].(string), "Ada") })
+	if got := focus.Focused(); got != "email" {
+		t.Errorf("focused = %q, want %q", got, "email")
+	}
+}
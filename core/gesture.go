@@ -0,0 +1,108 @@
+package core
+
+type SwipeDirection string
+
+const (
+	SwipeLeft  SwipeDirection = "left"
+	SwipeRight SwipeDirection = "right"
+	SwipeUp    SwipeDirection = "up"
+	SwipeDown  SwipeDirection = "down"
+)
+
+type GestureState string
+
+const (
+	GestureBegan     GestureState = "began"
+	GestureChanged   GestureState = "changed"
+	GestureEnded     GestureState = "ended"
+	GestureCancelled GestureState = "cancelled"
+)
+
+// GestureEvent is what the host reports for a recognized gesture. Positions
+// are relative to the node, in CSS pixels / dp; Velocity is per second.
+// Discrete gestures (tap, long press, swipe) only report GestureEnded.
+type GestureEvent struct {
+	State       GestureState
+	Position    Point
+	Translation Point
+	Velocity    Point
+	Scale       float64 // pinch only; 1 at the start
+	Direction   SwipeDirection
+}
+
+// Gesture props work on any container (Row, Column, Box, Card, Scroll, Image).
+// They are collected under a single "gestures" prop; the host runs them
+// through a gesture arena: every recognizer between the touched element and
+// the root competes, and the innermost one whose gesture matches the
+// movement (a horizontal swipe, a vertical scroll, a second finger for a
+// pinch) wins while the others are cancelled. That is what lets a
+// swipe-to-delete row live inside a vertical Scroll.
+
+func OnTap(handler func(GestureEvent)) BehaviorProp {
+	return gesture("tap", handler)
+}
+
+func OnDoubleTap(handler func(GestureEvent)) BehaviorProp {
+	return gesture("doubleTap", handler)
+}
+
+func OnLongPress(handler func(GestureEvent)) BehaviorProp {
+	return gesture("longPress", handler)
+}
+
+// OnSwipe fires once when a fling in direction ends; it competes for the
+// matching axis only, so a horizontal swipe leaves vertical scrolling alone.
+func OnSwipe(direction SwipeDirection, handler func(GestureEvent)) BehaviorProp {
+	return gesture("swipe"+string(direction), handler)
+}
+
+// OnPan reports a drag as began/changed/ended, with the translation since it
+// began.
+func OnPan(handler func(GestureEvent)) BehaviorProp {
+	return gesture("pan", handler)
+}
+
+// OnPinch reports a two-finger pinch as began/changed/ended; Scale is
+// relative to the distance between the fingers when it began.
+func OnPinch(handler func(GestureEvent)) BehaviorProp {
+	return gesture("pinch", handler)
+}
+
+func gesture(name string, handler func(GestureEvent)) BehaviorProp {
+	return behaviorFunc(func(n *Node) {
+		if n.Props == nil {
+			n.Props = map[string]any{}
+		}
+		gestures, _ := n.Props["gestures"].(map[string]any)
+		if gestures == nil {
+			gestures = map[string]any{}
+			n.Props["gestures"] = gestures
+		}
+		gestures[name] = registerDataCallback(func(data map[string]any) {
+			handler(parseGestureEvent(data))
+		})
+	})
+}
+
+func parseGestureEvent(data map[string]any) GestureEvent {
+	e := GestureEvent{
+		State:       GestureState(stringField(data, "state")),
+		Position:    Point{X: floatField(data, "x"), Y: floatField(data, "y")},
+		Translation: Point{X: floatField(data, "translationX"), Y: floatField(data, "translationY")},
+		Velocity:    Point{X: floatField(data, "velocityX"), Y: floatField(data, "velocityY")},
+		Scale:       floatField(data, "scale"),
+		Direction:   SwipeDirection(stringField(data, "direction")),
+	}
+	if e.State == "" {
+		e.State = GestureEnded
+	}
+	return e
+}
+
+// applyBehaviors attaches event and gesture props to a rendered container.
+func applyBehaviors(n *Node, behaviors []BehaviorProp) *Node {
+	for _, b := range behaviors {
+		b.Apply(n)
+	}
+	return n
+}
@@ -0,0 +1,199 @@
+package core
+
+type TrackKind string
+
+const (
+	TrackFixed    TrackKind = "fixed"
+	TrackFlexible TrackKind = "flexible"
+	TrackAdaptive TrackKind = "adaptive"
+)
+
+// Track sizes one column of a Grid.
+type Track struct {
+	Kind TrackKind
+	Size float64 // px for Fixed, a share of the free space for Flexible
+	Min  float64 // Adaptive: the narrowest a column may get
+	Max  float64 // Adaptive: the widest a column may get; 0 shares the row
+}
+
+// Fixed is a column of px width.
+func Fixed(px float64) Track {
+	return Track{Kind: TrackFixed, Size: px}
+}
+
+// Flexible takes share of the width the fixed columns leave, like a CSS fr
+// unit: Flexible(2) is twice as wide as Flexible(1).
+func Flexible(share float64) Track {
+	return Track{Kind: TrackFlexible, Size: share}
+}
+
+// Adaptive fits as many columns of at least min px as the width allows and
+// shares the rest among them. It fills the whole row, so it is the only
+// track of its Grid.
+func Adaptive(min, max float64) Track {
+	return Track{Kind: TrackAdaptive, Min: min, Max: max}
+}
+
+// Columns is n equal Flexible columns.
+func Columns(n int) []Track {
+	tracks := make([]Track, n)
+	for i := range tracks {
+		tracks[i] = Flexible(1)
+	}
+	return tracks
+}
+
+func (t Track) props() map[string]any {
+	props := map[string]any{"kind": string(t.Kind)}
+	switch t.Kind {
+	case TrackAdaptive:
+		props["min"] = t.Min
+		if t.Max > 0 {
+			props["max"] = t.Max
+		}
+	default:
+		props["size"] = t.Size
+	}
+	return props
+}
+
+type GridProp interface {
+	Apply(*GridNode)
+}
+
+// GridNode places the children of a Grid or Stack inside their cells.
+type GridNode struct {
+	Horizontal Alignment
+	Vertical   Alignment
+}
+
+type gridFunc func(*GridNode)
+
+func (f gridFunc) Apply(n *GridNode) { f(n) }
+
+// ItemAlign places each child inside its cell: AlignStart, AlignCenter,
+// AlignEnd or AlignStretch to fill it.
+func ItemAlign(horizontal, vertical Alignment) GridProp {
+	return gridFunc(func(n *GridNode) {
+		n.Horizontal = horizontal
+		n.Vertical = vertical
+	})
+}
+
+type layoutItems struct {
+	styleProps []StyleProp
+	children   []View
+	behaviors  []BehaviorProp
+	grid       *GridNode
+}
+
+func splitLayoutProps(items []PropsAndChildren, grid *GridNode) layoutItems {
+	out := layoutItems{grid: grid}
+	for _, item := range items {
+		switch v := item.(type) {
+		case StyleProp:
+			out.styleProps = append(out.styleProps, v)
+		case GridProp:
+			v.Apply(out.grid)
+		case View:
+			out.children = append(out.children, v)
+		case BehaviorProp:
+			out.behaviors = append(out.behaviors, v)
+		}
+	}
+	return out
+}
+
+func (n *GridNode) apply(props map[string]any) {
+	props["justifyItems"] = string(n.Horizontal)
+	props["alignItems"] = string(n.Vertical)
+}
+
+// Grid lays its children out in columns, left to right and then down, each
+// row as tall as its tallest cell. Gap, RowGap and ColumnGap space the
+// cells; Span lets a child cover more than one. Children stretch to fill
+// their cells unless ItemAlign says otherwise.
+//
+//	core.Grid(core.Columns(3), core.Gap(8), photos...)
+//	core.Grid([]core.Track{core.Fixed(80), core.Flexible(1)}, label, field)
+//	core.Grid([]core.Track{core.Adaptive(120, 0)}, cards...)
+func Grid(columns []Track, props ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		items := splitLayoutProps(props, &GridNode{Horizontal: AlignStretch, Vertical: AlignStretch})
+		style := themedStyle(ctx, "grid", Style{Display: DisplayGrid}, items.styleProps)
+		resolveStyle(ctx, style)
+
+		tracks := make([]map[string]any, len(columns))
+		for i, t := range columns {
+			tracks[i] = t.props()
+		}
+		nodeProps := map[string]any{"columns": tracks}
+		items.grid.apply(nodeProps)
+
+		return applyBehaviors(&Node{
+			Type:     "Grid",
+			Props:    nodeProps,
+			Style:    style,
+			Children: renderAll(ctx, items.children),
+		}, items.behaviors)
+	})
+}
+
+// Span makes child cover columns columns and rows rows of its Grid.
+func Span(columns, rows int, child View) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		return &Node{
+			Type: "GridItem",
+			Props: map[string]any{
+				"columns": max(columns, 1),
+				"rows":    max(rows, 1),
+			},
+			Children: []*Node{child.Render(ctx)},
+		}
+	})
+}
+
+// Wrap lays its children out in a row and starts a new one when they run
+// out of width, for chips and tags. RowGap and ColumnGap (or Gap) space
+// them; Justify aligns each line.
+func Wrap(props ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		items := splitLayoutProps(props, &GridNode{})
+		base := Style{Display: DisplayFlex, FlexDirection: FlexRow, FlexWrap: "wrap", AlignItems: AlignItemsCenter}
+		style := themedStyle(ctx, "wrap", base, items.styleProps)
+		resolveStyle(ctx, style)
+
+		return applyBehaviors(&Node{
+			Type:     "Wrap",
+			Props:    map[string]any{},
+			Style:    style,
+			Children: renderAll(ctx, items.children),
+		}, items.behaviors)
+	})
+}
+
+// Stack draws its children on top of each other, the last one in front,
+// and is as big as the biggest. Children are centered unless ItemAlign says
+// otherwise; on the web a Stack is a one-cell Grid.
+func Stack(props ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		items := splitLayoutProps(props, &GridNode{Horizontal: AlignCenter, Vertical: AlignCenter})
+		style := themedStyle(ctx, "stack", Style{Display: DisplayGrid}, items.styleProps)
+		resolveStyle(ctx, style)
+
+		nodeProps := map[string]any{}
+		items.grid.apply(nodeProps)
+
+		return applyBehaviors(&Node{
+			Type:     "Stack",
+			Props:    nodeProps,
+			Style:    style,
+			Children: renderAll(ctx, items.children),
+		}, items.behaviors)
+	})
+}
+
+// ZStack is Stack, under the name SwiftUI users look for.
+func ZStack(props ...PropsAndChildren) View {
+	return Stack(props...)
+}
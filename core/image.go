@@ -1,19 +1,202 @@
 package core
 
-func Image(src string, styleProps ...StyleProp) View {
+import (
+	"fmt"
+	"sync"
+)
+
+type ImageContentMode string
+
+const (
+	ContentCover   ImageContentMode = "cover"
+	ContentContain ImageContentMode = "contain"
+	ContentFill    ImageContentMode = "fill"
+)
+
+type ImageProp interface {
+	Apply(*ImageNode)
+}
+
+type ImageNode struct {
+	ContentMode ImageContentMode
+	Placeholder View
+	Error       View
+	FadeIn      int // ms
+	AspectRatio float64
+	ThumbWidth  int
+	ThumbHeight int
+}
+
+// ImageProcessor turns a source into a (usually smaller) renderable source.
+// It is used for thumbnails; see the imagecache package for the default
+// memory + disk implementation. Thumbnail runs off the render path and may
+// take as long as it needs; the result should be a short URL, not the image
+// itself, since it travels in every patch that touches the node.
+type ImageProcessor interface {
+	Thumbnail(src string, width, height int) (string, error)
+}
+
+var (
+	imageProcessorMux sync.RWMutex
+	imageProcessor    ImageProcessor
+)
+
+// Thumbnails by "WxH:src": the processor's answer once it has one, the
+// original src when it failed. pending holds the ones being generated.
+var thumbnails = struct {
+	mu      sync.Mutex
+	ready   map[string]string
+	pending map[string]bool
+}{
+	ready:   map[string]string{},
+	pending: map[string]bool{},
+}
+
+func SetImageProcessor(p ImageProcessor) {
+	imageProcessorMux.Lock()
+	imageProcessor = p
+	imageProcessorMux.Unlock()
+
+	thumbnails.mu.Lock()
+	thumbnails.ready = map[string]string{}
+	thumbnails.mu.Unlock()
+}
+
+func Image(src string, propsAndStyles ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Camera
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
+		var styleProps []StyleProp
+		var behaviors []BehaviorProp
+		node := &ImageNode{ContentMode: ContentCover}
+
+		for _, item := range propsAndStyles {
+			switch v := item.(type) {
+			case StyleProp:
+				styleProps = append(styleProps, v)
+			case ImageProp:
+				v.Apply(node)
+			case BehaviorProp:
+				behaviors = append(behaviors, v)
+			}
+		}
+
+		if node.ThumbWidth > 0 || node.ThumbHeight > 0 {
+			src = thumbnailSource(src, node.ThumbWidth, node.ThumbHeight)
+		}
+
+		props := map[string]any{
+			"src":         ResolveAsset(src),
+			"contentMode": string(node.ContentMode),
+		}
+		if node.FadeIn > 0 {
+			props["fadeIn"] = node.FadeIn
+		}
+		if node.AspectRatio > 0 {
+			props["aspectRatio"] = node.AspectRatio
 		}
 
-		return &Node{
-			Type: "Image",
-			Props: map[string]any{
-				"src": src,
-			},
-			Style: style,
+		var children []*Node
+		if node.Placeholder != nil {
+			children = append(children, &Node{
+				Type:     "ImagePlaceholder",
+				Children: []*Node{node.Placeholder.Render(ctx)},
+			})
 		}
+		if node.Error != nil {
+			children = append(children, &Node{
+				Type:     "ImageError",
+				Children: []*Node{node.Error.Render(ctx)},
+			})
+		}
+
+		style := themedStyle(ctx, "image", ctx.Theme().Components.Image, styleProps)
+		resolveStyle(ctx, style)
+
+		return applyBehaviors(&Node{
+			Type:     "Image",
+			Props:    props,
+			Style:    style,
+			Children: children,
+		}, behaviors)
+	})
+}
+
+// thumbnailSource never blocks the render: the first time it sees a
+// thumbnail it asks the processor in the background and returns src, then
+// marks the root context dirty so the next render swaps the thumbnail in.
+func thumbnailSource(src string, width, height int) string {
+	imageProcessorMux.RLock()
+	p := imageProcessor
+	imageProcessorMux.RUnlock()
+
+	if p == nil {
+		return src
+	}
+	key := fmt.Sprintf("%dx%d:%s", width, height, src)
+
+	thumbnails.mu.Lock()
+	defer thumbnails.mu.Unlock()
+
+	if out, ok := thumbnails.ready[key]; ok {
+		return out
+	}
+	if !thumbnails.pending[key] {
+		thumbnails.pending[key] = true
+		go func() {
+			out, err := p.Thumbnail(src, width, height)
+			if err != nil || out == "" {
+				out = src
+			}
+			thumbnails.mu.Lock()
+			thumbnails.ready[key] = out
+			delete(thumbnails.pending, key)
+			thumbnails.mu.Unlock()
+			MarkRootDirty()
+		}()
+	}
+	return src
+}
+
+type imageFunc func(*ImageNode)
+
+func (f imageFunc) Apply(n *ImageNode) { f(n) }
+
+func ContentMode(mode ImageContentMode) ImageProp {
+	return imageFunc(func(n *ImageNode) {
+		n.ContentMode = mode
+	})
+}
+
+func Placeholder(view View) ImageProp {
+	return imageFunc(func(n *ImageNode) {
+		n.Placeholder = view
+	})
+}
+
+func ErrorView(view View) ImageProp {
+	return imageFunc(func(n *ImageNode) {
+		n.Error = view
+	})
+}
+
+func FadeIn(ms int) ImageProp {
+	return imageFunc(func(n *ImageNode) {
+		n.FadeIn = ms
+	})
+}
+
+// AspectRatio is width / height, e.g. 16.0/9.
+func AspectRatio(ratio float64) ImageProp {
+	return imageFunc(func(n *ImageNode) {
+		n.AspectRatio = ratio
+	})
+}
+
+// Thumbnail asks the registered ImageProcessor for a downscaled copy that fits
+// in width x height. A zero dimension keeps the aspect ratio. The image shows
+// its original source until the thumbnail is ready.
+func Thumbnail(width, height int) ImageProp {
+	return imageFunc(func(n *ImageNode) {
+		n.ThumbWidth = width
+		n.ThumbHeight = height
 	})
 }
@@ -5,23 +5,26 @@ import (
 	"strconv"
 )
 
-func Input(value string, placeholder string, onChange func(string), styleProps ...StyleProp) View {
+func Input(value string, placeholder string, onChange func(string), props ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Input
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
-		}
+		styleProps, config := splitInputProps(props)
+		style := themedStyle(ctx, "input", ctx.Theme().Components.Input, styleProps)
 
-		id := registerTextCallback(onChange)
+		resolveStyle(ctx, style)
+
+		nodeProps := map[string]any{
+			"value":       config.display(value),
+			"placeholder": placeholder,
+		}
+		if !style.Disabled {
+			nodeProps["onChange"] = config.register(config.changed(onChange))
+		}
+		config.apply(style, nodeProps)
+		stateProps(style, nodeProps)
 
 		return &Node{
-			Type: "Input",
-			Props: map[string]any{
-				"value":       value,
-				"placeholder": placeholder,
-				"onChange":    id,
-			},
+			Type:  "Input",
+			Props: nodeProps,
 			Style: style,
 		}
 	})
@@ -29,89 +32,106 @@ func Input(value string, placeholder string, onChange func(string), styleProps .
 
 func Checkbox(checked bool, onToggle func(bool), styleProps ...StyleProp) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.CheckBox
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
-		}
+		style := themedStyle(ctx, "checkbox", ctx.Theme().Components.CheckBox, styleProps)
 
-		id := registerBoolCallback(onToggle)
+		resolveStyle(ctx, style)
+
+		props := map[string]any{
+			"checked": checked,
+		}
+		if !style.Disabled {
+			props["onToggle"] = registerBoolCallback(onToggle)
+		}
+		stateProps(style, props)
 
 		return &Node{
-			Type: "Checkbox",
-			Props: map[string]any{
-				"checked":  checked,
-				"onToggle": id,
-			},
+			Type:  "Checkbox",
+			Props: props,
 			Style: style,
 		}
 	})
 }
 
-func InputPassword(value string, placeholder string, onChange func(string), styleProps ...StyleProp) View {
+// InputPassword is an Input that starts secure; Secure(false) reveals the
+// text, for a show-password toggle.
+func InputPassword(value string, placeholder string, onChange func(string), props ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Input
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
-		}
+		styleProps, config := splitInputProps(props, Secure(true))
+		style := themedStyle(ctx, "input", ctx.Theme().Components.Input, styleProps)
+
+		resolveStyle(ctx, style)
 
-		id := registerTextCallback(onChange)
+		nodeProps := map[string]any{
+			"value":       config.display(value),
+			"placeholder": placeholder,
+		}
+		if !style.Disabled {
+			nodeProps["onChange"] = config.register(config.changed(onChange))
+		}
+		config.apply(style, nodeProps)
+		stateProps(style, nodeProps)
 
 		return &Node{
-			Type: "InputPassword",
-			Props: map[string]any{
-				"value":       value,
-				"placeholder": placeholder,
-				"onChange":    id,
-			},
+			Type:  "InputPassword",
+			Props: nodeProps,
 			Style: style,
 		}
 	})
 }
 
-func NumericInput(value int, onChange func(int), styleProps ...StyleProp) View {
+// NumericInput opens the number keyboard. Text that is not an integer
+// doesn't reach onChange; OnInvalid gets it instead, so the form can say why.
+// Clearing the field is not an error and is ignored.
+func NumericInput(value int, onChange func(int), props ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Input
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
-		}
+		styleProps, config := splitInputProps(props, InputKeyboard(KeyboardNumber))
+		style := themedStyle(ctx, "input", ctx.Theme().Components.Input, styleProps)
 
-		id := registerTextCallback(func(val string) {
-			if n, err := strconv.Atoi(val); err == nil {
-				onChange(n)
-			}
-		})
+		resolveStyle(ctx, style)
+
+		nodeProps := map[string]any{
+			"value": fmt.Sprintf("%d", value),
+		}
+		if !style.Disabled {
+			nodeProps["onChange"] = config.register(func(val string) {
+				if n, err := strconv.Atoi(val); err == nil {
+					onChange(n)
+				} else if val != "" && config.OnInvalid != nil {
+					config.OnInvalid(val)
+				}
+			})
+		}
+		config.apply(style, nodeProps)
+		stateProps(style, nodeProps)
 
 		return &Node{
-			Type: "NumericInput",
-			Props: map[string]any{
-				"value":    fmt.Sprintf("%d", value),
-				"onChange": id,
-			},
+			Type:  "NumericInput",
+			Props: nodeProps,
 			Style: style,
 		}
 	})
 }
 
-func TextArea(value string, onChange func(string), rows int, styleProps ...StyleProp) View {
+func TextArea(value string, onChange func(string), rows int, props ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.TextArea
-		style := &base
-		for _, sp := range styleProps {
-			sp.Apply(style)
-		}
+		styleProps, config := splitInputProps(props)
+		style := themedStyle(ctx, "textarea", ctx.Theme().Components.TextArea, styleProps)
+
+		resolveStyle(ctx, style)
 
-		id := registerTextCallback(onChange)
+		nodeProps := map[string]any{
+			"value": config.display(value),
+			"rows":  rows,
+		}
+		if !style.Disabled {
+			nodeProps["onChange"] = config.register(config.changed(onChange))
+		}
+		config.apply(style, nodeProps)
+		stateProps(style, nodeProps)
 
 		return &Node{
-			Type: "TextArea",
-			Props: map[string]any{
-				"value":    value,
-				"rows":     rows,
-				"onChange": id,
-			},
+			Type:  "TextArea",
+			Props: nodeProps,
 			Style: style,
 		}
 	})
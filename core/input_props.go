@@ -0,0 +1,238 @@
+package core
+
+// Input, InputPassword, NumericInput and TextArea take InputProps next to
+// their style props.
+
+type KeyboardType string
+
+const (
+	KeyboardText    KeyboardType = "text"
+	KeyboardEmail   KeyboardType = "email"
+	KeyboardPhone   KeyboardType = "phone"
+	KeyboardNumber  KeyboardType = "number"
+	KeyboardDecimal KeyboardType = "decimal"
+	KeyboardURL     KeyboardType = "url"
+)
+
+type ReturnKeyType string
+
+const (
+	ReturnDone   ReturnKeyType = "done"
+	ReturnNext   ReturnKeyType = "next"
+	ReturnGo     ReturnKeyType = "go"
+	ReturnSearch ReturnKeyType = "search"
+	ReturnSend   ReturnKeyType = "send"
+)
+
+type Capitalize string
+
+const (
+	CapitalizeNone       Capitalize = "none"
+	CapitalizeSentences  Capitalize = "sentences"
+	CapitalizeWords      Capitalize = "words"
+	CapitalizeCharacters Capitalize = "characters"
+)
+
+type InputProp interface {
+	Apply(*InputNode)
+}
+
+type InputNode struct {
+	Keyboard    KeyboardType
+	ReturnKey   ReturnKeyType
+	OnSubmit    func(string)
+	MaxLength   int
+	Capitalize  Capitalize
+	AutoCorrect *bool
+	Secure      bool
+	AutoFocus   bool
+	OnFocus     func()
+	OnBlur      func()
+	OnInvalid   func(string)
+	OnInput     func(InputEvent)
+	Mask        Mask
+	Selection   *TextRange
+	Composition *TextRange
+
+	focus     *FocusManager
+	focusName string
+}
+
+type inputFunc func(*InputNode)
+
+func (f inputFunc) Apply(n *InputNode) { f(n) }
+
+func InputKeyboard(t KeyboardType) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.Keyboard = t
+	})
+}
+
+func ReturnKey(t ReturnKeyType) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.ReturnKey = t
+	})
+}
+
+// OnSubmit fires with the current text when the return key is pressed
+// (Enter on a physical keyboard; TextArea keeps Enter for new lines).
+func OnSubmit(fn func(string)) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.OnSubmit = fn
+	})
+}
+
+// MaxLength limits the characters the host lets the user type; with a Mask
+// it counts the formatted text.
+func MaxLength(chars int) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.MaxLength = chars
+	})
+}
+
+func AutoCapitalize(c Capitalize) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.Capitalize = c
+	})
+}
+
+func AutoCorrect(enabled bool) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.AutoCorrect = &enabled
+	})
+}
+
+// Secure hides the text as it is typed.
+func Secure(enabled bool) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.Secure = enabled
+	})
+}
+
+// AutoFocus focuses the input, and opens the keyboard, when it mounts.
+func AutoFocus(enabled bool) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.AutoFocus = enabled
+	})
+}
+
+func OnFocus(fn func()) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.OnFocus = fn
+	})
+}
+
+func OnBlur(fn func()) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.OnBlur = fn
+	})
+}
+
+// OnInvalid receives text a NumericInput could not parse.
+func OnInvalid(fn func(string)) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.OnInvalid = fn
+	})
+}
+
+// WithMask formats the value for display; onChange receives it unformatted.
+func WithMask(m Mask) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.Mask = m
+	})
+}
+
+// splitInputProps separates style props from input props; defaults are
+// applied first so the caller's props win.
+func splitInputProps(items []PropsAndChildren, defaults ...InputProp) ([]StyleProp, *InputNode) {
+	var styleProps []StyleProp
+	config := &InputNode{}
+	for _, d := range defaults {
+		d.Apply(config)
+	}
+	for _, item := range items {
+		switch v := item.(type) {
+		case StyleProp:
+			styleProps = append(styleProps, v)
+		case InputProp:
+			v.Apply(config)
+		}
+	}
+	return styleProps, config
+}
+
+func (n *InputNode) display(value string) string {
+	if n.Mask == nil {
+		return value
+	}
+	return n.Mask.Format(value)
+}
+
+func (n *InputNode) changed(onChange func(string)) func(string) {
+	if n.Mask == nil {
+		return onChange
+	}
+	return func(text string) {
+		onChange(n.Mask.Raw(text))
+	}
+}
+
+func (n *InputNode) apply(style *Style, props map[string]any) {
+	if n.Keyboard != "" {
+		props["keyboard"] = string(n.Keyboard)
+	}
+	if n.ReturnKey != "" {
+		props["returnKey"] = string(n.ReturnKey)
+	}
+	if n.MaxLength > 0 {
+		props["maxLength"] = n.MaxLength
+	}
+	if n.Capitalize != "" {
+		props["autoCapitalize"] = string(n.Capitalize)
+	}
+	if n.AutoCorrect != nil {
+		props["autoCorrect"] = *n.AutoCorrect
+	}
+	if n.Secure {
+		props["secure"] = true
+	}
+	if n.AutoFocus {
+		props["autoFocus"] = true
+	}
+	if n.focus != nil {
+		props["focusId"] = n.focus.target(n.focusName)
+	}
+	props["seq"] = inputAck()
+	if n.Selection != nil {
+		props["selection"] = []int{n.Selection.Start, n.Selection.End}
+	}
+	if n.Composition != nil {
+		props["composition"] = []int{n.Composition.Start, n.Composition.End}
+	}
+	if style.Disabled {
+		return
+	}
+	if n.OnSubmit != nil {
+		props["onSubmit"] = registerTextCallback(n.OnSubmit)
+	}
+	if n.OnFocus != nil || n.focus != nil {
+		props["onFocus"] = registerCallback(func() {
+			if n.focus != nil {
+				n.focus.focused(n.focusName)
+			}
+			if n.OnFocus != nil {
+				n.OnFocus()
+			}
+		})
+	}
+	if n.OnBlur != nil || n.focus != nil {
+		props["onBlur"] = registerCallback(func() {
+			if n.focus != nil {
+				n.focus.blurred(n.focusName)
+			}
+			if n.OnBlur != nil {
+				n.OnBlur()
+			}
+		})
+	}
+}
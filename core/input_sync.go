@@ -0,0 +1,95 @@
+package core
+
+import "sync"
+
+// Text inputs are controlled: every edit goes to Go and the value comes back
+// in the next render. The host numbers its edits and Go echoes, as the
+// "seq" prop of every input, the last number it has handled. A value whose
+// seq is older than the host's latest edit was rendered before Go saw that
+// edit, so the host keeps what the user typed instead of writing it back;
+// otherwise a fast typist on a slow device loses characters and the caret
+// jumps. Values Go changes on its own (a mask, a reset) carry the current
+// seq and are written, with the caret kept at the same distance from the
+// end unless Selection says where it goes.
+//
+// Edits arrive as {text, seq, selectionStart, selectionEnd} plus
+// composingStart/composingEnd while an IME composition is open. Offsets
+// count UTF-16 code units, as both hosts do.
+
+type TextRange struct {
+	Start int
+	End   int
+}
+
+type InputEvent struct {
+	Text      string
+	Selection TextRange
+	Composing *TextRange // the IME composition in progress, if any
+	Seq       int
+}
+
+// OnInput reports every edit with its selection and composition, next to
+// the plain onChange.
+func OnInput(fn func(InputEvent)) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.OnInput = fn
+	})
+}
+
+// Selection places the caret (Start == End) or selects a range. It is
+// applied when it changes, or together with a value Go rewrote.
+func Selection(start, end int) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.Selection = &TextRange{Start: start, End: end}
+	})
+}
+
+// Composition marks a range as the IME's composing text, e.g. to let the
+// user keep editing a suggestion Go inserted. Only Android supports it; the
+// web has no API to open a composition.
+func Composition(start, end int) InputProp {
+	return inputFunc(func(n *InputNode) {
+		n.Composition = &TextRange{Start: start, End: end}
+	})
+}
+
+var inputSeq = struct {
+	mu  sync.Mutex
+	ack int
+}{}
+
+func inputAck() int {
+	inputSeq.mu.Lock()
+	defer inputSeq.mu.Unlock()
+	return inputSeq.ack
+}
+
+func ackInput(seq int) {
+	inputSeq.mu.Lock()
+	inputSeq.ack = max(inputSeq.ack, seq)
+	inputSeq.mu.Unlock()
+}
+
+// register wires onChange, and OnInput when set, to the host's edits.
+func (n *InputNode) register(onChange func(string)) string {
+	return registerDataCallback(func(data map[string]any) {
+		e := parseInputEvent(data)
+		ackInput(e.Seq)
+		onChange(e.Text)
+		if n.OnInput != nil {
+			n.OnInput(e)
+		}
+	})
+}
+
+func parseInputEvent(data map[string]any) InputEvent {
+	e := InputEvent{
+		Text:      stringField(data, "text"),
+		Selection: TextRange{Start: intField(data, "selectionStart"), End: intField(data, "selectionEnd")},
+		Seq:       intField(data, "seq"),
+	}
+	if _, ok := data["composingStart"]; ok {
+		e.Composing = &TextRange{Start: intField(data, "composingStart"), End: intField(data, "composingEnd")}
+	}
+	return e
+}
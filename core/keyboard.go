@@ -0,0 +1,40 @@
+package core
+
+import "sync"
+
+type Keyboard struct {
+	Visible bool
+	Height  int // CSS pixels / dp covered at the bottom of the window
+
+	resized bool // the host already shrank the window to make room
+}
+
+var keyboard = struct {
+	mu    sync.Mutex
+	state Keyboard
+}{}
+
+// The host reports the on-screen keyboard as {"height": 291} whenever it
+// opens, resizes or closes (height 0): the visual viewport on the web, the
+// IME insets on Android. Android resizes the window around the keyboard and
+// adds "resized": true, so keyboard-aware Scrolls leave the padding to it.
+func init() {
+	OnSystemEvent("keyboard", func(data map[string]any) {
+		height := intField(data, "height")
+		resized, _ := data["resized"].(bool)
+		keyboard.mu.Lock()
+		keyboard.state = Keyboard{Visible: height > 0, Height: height, resized: resized}
+		keyboard.mu.Unlock()
+	})
+}
+
+func currentKeyboard() Keyboard {
+	keyboard.mu.Lock()
+	defer keyboard.mu.Unlock()
+	return keyboard.state
+}
+
+// UseKeyboard returns the last keyboard state reported by the host.
+func UseKeyboard(ctx *Context) Keyboard {
+	return currentKeyboard()
+}
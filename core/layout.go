@@ -6,71 +6,70 @@ type PropsAndChildren any
 
 func Row(stylePropsAndChildren ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Row
-		style := &base
+		var styleProps []StyleProp
 		var children []View
+		var behaviors []BehaviorProp
 
 		for _, item := range stylePropsAndChildren {
 			switch v := item.(type) {
 			case StyleProp:
-				v.Apply(style)
+				styleProps = append(styleProps, v)
 			case View:
 				children = append(children, v)
+			case BehaviorProp:
+				behaviors = append(behaviors, v)
 			}
 		}
 
-		return &Node{
+		style := themedStyle(ctx, "row", ctx.Theme().Components.Row, styleProps)
+		resolveStyle(ctx, style)
+
+		return applyBehaviors(&Node{
 			Type:     "Row",
 			Style:    style,
 			Children: renderAll(ctx, children),
-		}
+		}, behaviors)
 	})
 }
 
 func Card(stylePropsAndChildren ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Card
-		style := &base
+		var styleProps []StyleProp
 		var children []View
+		var behaviors []BehaviorProp
 
 		for _, item := range stylePropsAndChildren {
 			switch v := item.(type) {
 			case StyleProp:
-				v.Apply(style)
+				styleProps = append(styleProps, v)
 			case View:
 				children = append(children, v)
+			case BehaviorProp:
+				behaviors = append(behaviors, v)
 			}
 		}
 
-		return &Node{
+		style := themedStyle(ctx, "card", ctx.Theme().Components.Card, styleProps)
+		resolveStyle(ctx, style)
+
+		return applyBehaviors(&Node{
 			Type:     "Card",
 			Style:    style,
 			Children: renderAll(ctx, children),
-		}
+		}, behaviors)
 	})
 }
 
 func Spacer(size int) View {
 	return ComponentFunc(func(ctx *Context) *Node {
+		style := ctx.Theme().Components.Spacer
+		resolveStyle(ctx, &style)
 		return &Node{
 			Type: "Spacer",
 			Props: map[string]any{
-				"size": size,
+				"size": ctx.Theme().Spacing.resolve(size),
 			},
-		}
-	})
-}
-
-func Scroll(children ...View) View {
-	return ComponentFunc(func(ctx *Context) *Node {
-		var nodes []*Node
-		for _, child := range children {
-			nodes = append(nodes, child.Render(ctx))
-		}
-		return &Node{
-			Type:     "Scroll",
-			Props:    map[string]any{},
-			Children: nodes,
+			Style: &style,
 		}
 	})
 }
@@ -99,49 +98,55 @@ func Fragment(children ...View) View {
 
 func Column(stylePropsAndChildren ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		base := ctx.Theme().Components.Column
-		style := &base
+		var styleProps []StyleProp
 		var children []View
-		props := make(map[string]any)
+		var behaviors []BehaviorProp
 		for _, item := range stylePropsAndChildren {
 			switch v := item.(type) {
 			case StyleProp:
-				v.Apply(style)
+				styleProps = append(styleProps, v)
 			case View:
 				children = append(children, v)
 			case BehaviorProp:
-				v.Apply(&Node{Props: props})
+				behaviors = append(behaviors, v)
 			}
 		}
 
-		return &Node{
+		style := themedStyle(ctx, "column", ctx.Theme().Components.Column, styleProps)
+		resolveStyle(ctx, style)
+
+		return applyBehaviors(&Node{
 			Type:     "Column",
 			Style:    style,
 			Children: renderAll(ctx, children),
-		}
+		}, behaviors)
 	})
 }
 func Box(stylePropsAndChildren ...PropsAndChildren) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		style := &Style{}
+		var styleProps []StyleProp
 		var children []View
+		var behaviors []BehaviorProp
 
 		for _, item := range stylePropsAndChildren {
 			switch v := item.(type) {
 			case StyleProp:
-				v.Apply(style)
+				styleProps = append(styleProps, v)
 			case View:
 				children = append(children, v)
 			case BehaviorProp:
-				// future event handlers
+				behaviors = append(behaviors, v)
 			}
 		}
 
-		return &Node{
+		style := themedStyle(ctx, "box", ctx.Theme().Components.Box, styleProps)
+		resolveStyle(ctx, style)
+
+		return applyBehaviors(&Node{
 			Type:     "Box", // pode cair como "div" no runtime
 			Style:    style,
 			Children: renderAll(ctx, children),
-		}
+		}, behaviors)
 	})
 }
 func Divider(height int, color string) View {
@@ -0,0 +1,318 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+type LazyListProp interface {
+	Apply(*LazyListNode)
+}
+
+type LazyListNode struct {
+	EstimatedHeight float64 // px per item until the host measures it
+	Overscan        int     // items rendered beyond each edge of the viewport
+	IsHeader        func(index int) bool
+	Controller      *ListController
+	Style           []StyleProp
+}
+
+type lazyListFunc func(*LazyListNode)
+
+func (f lazyListFunc) Apply(n *LazyListNode) { f(n) }
+
+const (
+	defaultItemHeight = 48
+	defaultOverscan   = 4
+	defaultViewport   = 800 // px, until the host reports the real one
+)
+
+func EstimatedItemHeight(px float64) LazyListProp {
+	return lazyListFunc(func(n *LazyListNode) {
+		n.EstimatedHeight = px
+	})
+}
+
+func Overscan(items int) LazyListProp {
+	return lazyListFunc(func(n *LazyListNode) {
+		n.Overscan = items
+	})
+}
+
+// StickyHeaders pins the last header above the viewport to its top until the
+// next header pushes it away.
+func StickyHeaders(isHeader func(index int) bool) LazyListProp {
+	return lazyListFunc(func(n *LazyListNode) {
+		n.IsHeader = isHeader
+	})
+}
+
+func WithListController(c *ListController) LazyListProp {
+	return lazyListFunc(func(n *LazyListNode) {
+		n.Controller = c
+	})
+}
+
+// ListStyle styles the scroll area; the theme's Components.Scroll is the
+// starting point.
+func ListStyle(props ...StyleProp) LazyListProp {
+	return lazyListFunc(func(n *LazyListNode) {
+		n.Style = append(n.Style, props...)
+	})
+}
+
+// lazyListState is what the list remembers between renders: the host's
+// scroll position and viewport, and the item heights it has measured.
+type lazyListState struct {
+	mu       sync.Mutex
+	offset   float64
+	viewport float64
+	heights  map[int]float64
+	slots    int
+	offsets  []float64 // from the last render, for ScrollToIndex
+
+	// contexts give each slot, and the sticky header, hook slots of its own,
+	// so hooks in item views don't shift the cursor of the list's siblings.
+	contexts []*Context
+	header   *Context
+}
+
+// slotContext returns the context slot s renders items in, reset for this
+// render. ctx may be a fresh copy every render (WithTheme), so the theme
+// and config are taken from it each time.
+func (st *lazyListState) slotContext(ctx *Context, s int) *Context {
+	for len(st.contexts) <= s {
+		st.contexts = append(st.contexts, ctx.NewChildContext())
+	}
+	return resetChild(st.contexts[s], ctx)
+}
+
+func (st *lazyListState) headerContext(ctx *Context) *Context {
+	if st.header == nil {
+		st.header = ctx.NewChildContext()
+	}
+	return resetChild(st.header, ctx)
+}
+
+func resetChild(child, ctx *Context) *Context {
+	child.theme = ctx.theme
+	child.config = ctx.config
+	child.window = ctx.window
+	child.Cursor = 0
+	return child
+}
+
+func useLazyListState(ctx *Context) *lazyListState {
+	index := ctx.Cursor
+	ctx.Cursor++
+
+	if index >= len(ctx.slots) {
+		ctx.slots = append(ctx.slots, &lazyListState{heights: map[int]float64{}})
+	}
+	return ctx.slots[index].(*lazyListState)
+}
+
+// LazyList renders only the items inside the viewport plus Overscan on each
+// side. The host reports the scroll position ("onScroll") and item heights
+// ("onMeasure"); unmeasured items count as EstimatedHeight.
+//
+// Items are laid out in recycled slots: item i always lands in slot
+// i % slots, and a slot keeps its "key" prop, so scrolling one row patches
+// one slot's props and content instead of shifting every child. Each slot
+// renders in a context of its own, so item views may use hooks, but their
+// state belongs to the slot and moves with it; keep per-item state outside
+// the list.
+func LazyList(count int, item func(index int) View, props ...LazyListProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		config := &LazyListNode{
+			EstimatedHeight: defaultItemHeight,
+			Overscan:        defaultOverscan,
+		}
+		for _, p := range props {
+			p.Apply(config)
+		}
+
+		state := useLazyListState(ctx)
+		state.mu.Lock()
+		defer state.mu.Unlock()
+
+		offsets := make([]float64, count+1)
+		for i := 0; i < count; i++ {
+			h, ok := state.heights[i]
+			if !ok {
+				h = config.EstimatedHeight
+			}
+			offsets[i+1] = offsets[i] + h
+		}
+		state.offsets = offsets
+
+		viewport := state.viewport
+		if viewport <= 0 {
+			viewport = float64(ctx.windowSize().Height)
+		}
+		if viewport <= 0 {
+			viewport = defaultViewport
+		}
+		first := sort.Search(count, func(i int) bool { return offsets[i+1] > state.offset })
+		last := sort.Search(count, func(i int) bool { return offsets[i] >= state.offset+viewport })
+
+		// Slots only grow, and are sized for a full window even at the ends
+		// of the list, so they rarely reshuffle.
+		state.slots = max(state.slots, last-first+2*config.Overscan)
+		top := first // the header pins to the viewport, not the overscan
+		first = max(first-config.Overscan, 0)
+		last = min(last+config.Overscan, count)
+
+		var children []*Node
+		if config.IsHeader != nil {
+			children = append(children, stickyHeader(state.headerContext(ctx), config.IsHeader, item, offsets, top, count))
+		}
+		slots := make([]*Node, state.slots)
+		for s := range slots {
+			slots[s] = lazyItem(s, -1, 0, 0, nil)
+		}
+		for i := first; i < last; i++ {
+			s := i % state.slots
+			slots[s] = lazyItem(s, i, offsets[i], offsets[i+1]-offsets[i], item(i).Render(state.slotContext(ctx, s)))
+		}
+		children = append(children, slots...)
+
+		listProps := map[string]any{
+			"totalHeight": offsets[count],
+			"onScroll": registerDataCallback(func(data map[string]any) {
+				state.mu.Lock()
+				state.offset = floatField(data, "offset")
+				state.viewport = floatField(data, "viewport")
+				state.mu.Unlock()
+			}),
+			"onMeasure": registerDataCallback(func(data map[string]any) {
+				heights, _ := data["heights"].(map[string]any)
+				state.mu.Lock()
+				for k, v := range heights {
+					i, err := strconv.Atoi(k)
+					if h, ok := v.(float64); ok && err == nil {
+						state.heights[i] = h
+					}
+				}
+				state.mu.Unlock()
+			}),
+		}
+		if config.Controller != nil {
+			config.Controller.attach(state)
+			listProps["controller"] = config.Controller.ID
+		}
+
+		style := themedStyle(ctx, "scroll", ctx.Theme().Components.Scroll, config.Style)
+		resolveStyle(ctx, style)
+
+		return &Node{
+			Type:     "LazyList",
+			Props:    listProps,
+			Style:    style,
+			Children: children,
+		}
+	})
+}
+
+// lazyItem wraps an item in its slot. index -1 marks an unused slot, which
+// renderers hide. Positions travel as props, not styles, so they don't turn
+// into a CSS class per row.
+func lazyItem(slot, index int, top, height float64, content *Node) *Node {
+	n := &Node{
+		Type: "LazyItem",
+		Props: map[string]any{
+			"key":   "slot-" + strconv.Itoa(slot),
+			"index": index,
+		},
+	}
+	if content != nil {
+		n.Props["top"] = top
+		n.Props["height"] = height
+		n.Children = []*Node{content}
+	}
+	return n
+}
+
+// stickyHeader renders the header in charge of the top of the viewport as an
+// extra first child. The host keeps it at the scroll position, between its
+// own top and "stickyLimit", where the next header starts pushing it out.
+func stickyHeader(ctx *Context, isHeader func(int) bool, item func(int) View, offsets []float64, first, count int) *Node {
+	header := -1
+	for i := min(first, count-1); i >= 0; i-- {
+		if isHeader(i) {
+			header = i
+			break
+		}
+	}
+	if header < 0 {
+		n := lazyItem(0, -1, 0, 0, nil)
+		n.Props["key"] = "sticky"
+		return n
+	}
+	height := offsets[header+1] - offsets[header]
+	n := lazyItem(0, header, offsets[header], height, item(header).Render(ctx))
+	n.Props["key"] = "sticky"
+	n.Props["sticky"] = true
+	for i := header + 1; i < count; i++ {
+		if isHeader(i) {
+			n.Props["stickyLimit"] = offsets[i] - height
+			break
+		}
+	}
+	return n
+}
+
+// ListController scrolls a LazyList imperatively. It is a ScrollRef that also
+// knows the list's item offsets, so ScrollTo and ScrollToEnd work too.
+type ListController struct {
+	ScrollRef
+	mu    sync.Mutex
+	state *lazyListState
+}
+
+var listCounter int
+
+func UseListController(ctx *Context) *ListController {
+	index := ctx.Cursor
+	ctx.Cursor++
+
+	if index >= len(ctx.slots) {
+		callbackMux.Lock()
+		id := fmt.Sprintf("list_%d", listCounter)
+		listCounter++
+		callbackMux.Unlock()
+		ctx.slots = append(ctx.slots, &ListController{ScrollRef: ScrollRef{ID: id}})
+	}
+	return ctx.slots[index].(*ListController)
+}
+
+func (c *ListController) attach(state *lazyListState) {
+	c.mu.Lock()
+	c.state = state
+	c.mu.Unlock()
+}
+
+// ScrollToIndex brings item index to the top of the list. The target window
+// is rendered right away, before the host confirms the new position.
+func (c *ListController) ScrollToIndex(index int) {
+	c.mu.Lock()
+	state := c.state
+	c.mu.Unlock()
+	if state == nil {
+		return
+	}
+
+	state.mu.Lock()
+	if len(state.offsets) == 0 {
+		state.mu.Unlock()
+		return
+	}
+	index = max(0, min(index, len(state.offsets)-1))
+	offset := state.offsets[index]
+	state.offset = offset
+	state.mu.Unlock()
+
+	c.ScrollTo(0, offset, false)
+}
@@ -0,0 +1,26 @@
+package core
+
+import "testing"
+
+func TestStickyHeaderFollowsViewport(t *testing.T) {
+	ctx := NewContext()
+	list := LazyList(100, func(i int) View { return Text("row") },
+		StickyHeaders(func(i int) bool { return i%20 == 0 }),
+	)
+
+	node := list.Render(ctx)
+	TriggerDataCallback(node.Props["onScroll"].(string), map[string]any{
+		"offset":   22 * defaultItemHeight,
+		"viewport": 10 * defaultItemHeight,
+	})
+	ctx.Reset()
+	node = list.Render(ctx)
+
+	sticky := node.Children[0]
+	if got := sticky.Props["index"]; got != 20 {
+		t.Fatalf("pinned header = %v, want 20", got)
+	}
+	if got, want := sticky.Props["stickyLimit"], float64(39*defaultItemHeight); got != want {
+		t.Errorf("stickyLimit = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Mask formats an input's value for display. Format turns the raw value
+// into what the user sees; Raw goes back from whatever the host reports,
+// formatted or half-typed, to the raw value onChange receives.
+type Mask interface {
+	Format(raw string) string
+	Raw(text string) string
+}
+
+// PatternMask formats by a pattern where '#' is a digit, 'A' a letter and
+// '*' either; any other character is shown as is. The raw value holds only
+// the characters typed into slots, e.g. PatternMask("+258 ## ### ####")
+// shows "841234567" as "+258 84 123 4567".
+func PatternMask(pattern string) Mask {
+	return patternMask([]rune(pattern))
+}
+
+type patternMask []rune
+
+func isSlot(p rune) bool {
+	return p == '#' || p == 'A' || p == '*'
+}
+
+func fitsSlot(p, r rune) bool {
+	switch p {
+	case '#':
+		return unicode.IsDigit(r)
+	case 'A':
+		return unicode.IsLetter(r)
+	case '*':
+		return unicode.IsDigit(r) || unicode.IsLetter(r)
+	}
+	return false
+}
+
+func (m patternMask) Format(raw string) string {
+	in := []rune(raw)
+	var out []rune
+	for _, p := range m {
+		if len(in) == 0 {
+			break
+		}
+		if !isSlot(p) {
+			out = append(out, p)
+			continue
+		}
+		for len(in) > 0 && !fitsSlot(p, in[0]) {
+			in = in[1:]
+		}
+		if len(in) == 0 {
+			break
+		}
+		out = append(out, in[0])
+		in = in[1:]
+	}
+	// trailing literals only appear once the next slot is filled
+	return strings.TrimRightFunc(string(out), func(r rune) bool { return !unicode.IsDigit(r) && !unicode.IsLetter(r) })
+}
+
+// Raw drops the literal prefix the mask shows before the first slot, then
+// keeps the characters that fit a slot.
+func (m patternMask) Raw(text string) string {
+	prefix := m.prefix()
+	if strings.HasPrefix(prefix, text) {
+		return ""
+	}
+	text = strings.TrimPrefix(text, prefix)
+	slots := 0
+	for _, p := range m {
+		if isSlot(p) {
+			slots++
+		}
+	}
+	var raw []rune
+	for _, r := range text {
+		if len(raw) == slots {
+			break
+		}
+		if m.accepts(r) {
+			raw = append(raw, r)
+		}
+	}
+	return string(raw)
+}
+
+func (m patternMask) prefix() string {
+	for i, p := range m {
+		if isSlot(p) {
+			return string(m[:i])
+		}
+	}
+	return string(m)
+}
+
+func (m patternMask) accepts(r rune) bool {
+	for _, p := range m {
+		if fitsSlot(p, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrencyMask formats digits as an amount that fills from the right, like
+// a till: typing 4, 2, 7, 5, 0, 0, 0 shows "MZN 42,750.00". The raw value
+// is the amount in minor units as digits ("4275000").
+func CurrencyMask(code string, decimals int) Mask {
+	return currencyMask{code: code, decimals: decimals}
+}
+
+type currencyMask struct {
+	code     string
+	decimals int
+}
+
+func (m currencyMask) Format(raw string) string {
+	digits := strings.TrimLeft(m.Raw(raw), "0")
+	if digits == "" {
+		return ""
+	}
+	if len(digits) <= m.decimals {
+		digits = strings.Repeat("0", m.decimals-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-m.decimals], digits[len(digits)-m.decimals:]
+
+	var b strings.Builder
+	if m.code != "" {
+		b.WriteString(m.code + " ")
+	}
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	if m.decimals > 0 {
+		b.WriteString("." + frac)
+	}
+	return b.String()
+}
+
+func (m currencyMask) Raw(text string) string {
+	var digits []rune
+	for _, r := range text {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	return strings.TrimLeft(string(digits), "0")
+}
@@ -9,6 +9,7 @@ type ModalNode struct {
 	OnDismiss func()
 	Backdrop  string
 	Content   []View
+	Style     []StyleProp
 }
 
 func Modal(props ...ModalProp) View {
@@ -24,6 +25,9 @@ func Modal(props ...ModalProp) View {
 
 		children := renderAll(ctx, node.Content)
 
+		style := themedStyle(ctx, "modal", ctx.Theme().Components.Modal, node.Style)
+		resolveStyle(ctx, style)
+
 		propMap := map[string]any{
 			"visible":  node.Visible,
 			"backdrop": node.Backdrop,
@@ -36,6 +40,7 @@ func Modal(props ...ModalProp) View {
 		return &Node{
 			Type:     "Modal",
 			Props:    propMap,
+			Style:    style,
 			Children: children,
 		}
 	})
@@ -62,3 +67,11 @@ func Backdrop(color string) ModalProp {
 		m.Backdrop = color
 	})
 }
+
+// ModalStyle styles the modal's content box; the theme's Components.Modal is
+// the starting point.
+func ModalStyle(props ...StyleProp) ModalProp {
+	return modalFunc(func(m *ModalNode) {
+		m.Style = append(m.Style, props...)
+	})
+}
@@ -3,6 +3,7 @@ package core
 type Node struct {
 	Type     string
 	Props    map[string]any
-	Style    *Style
+	Style    *Style `json:",omitempty"`
+	Class    string `json:",omitempty"` // set instead of Style once compiled by the css package
 	Children []*Node
 }
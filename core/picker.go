@@ -0,0 +1,350 @@
+package core
+
+import (
+	"time"
+)
+
+type PickerProp interface {
+	Apply(*PickerNode)
+}
+
+// PickerNode configures Select and the date and time pickers. Min and Max
+// bound the pickers (the date part for dates, the clock for times); Locale
+// defaults to AppConfig.Locale and only changes how hosts display values.
+type PickerNode struct {
+	Placeholder string
+	Searchable  bool
+	Min         time.Time
+	Max         time.Time
+	Locale      string
+	Style       []StyleProp
+}
+
+type pickerFunc func(*PickerNode)
+
+func (f pickerFunc) Apply(n *PickerNode) { f(n) }
+
+func PickerPlaceholder(text string) PickerProp {
+	return pickerFunc(func(n *PickerNode) {
+		n.Placeholder = text
+	})
+}
+
+// Searchable adds a filter field to a Select's list, for long lists such as
+// currencies or countries.
+func Searchable(enabled bool) PickerProp {
+	return pickerFunc(func(n *PickerNode) {
+		n.Searchable = enabled
+	})
+}
+
+func MinDate(t time.Time) PickerProp {
+	return pickerFunc(func(n *PickerNode) {
+		n.Min = t
+	})
+}
+
+func MaxDate(t time.Time) PickerProp {
+	return pickerFunc(func(n *PickerNode) {
+		n.Max = t
+	})
+}
+
+// PickerLocale overrides AppConfig.Locale, as a BCP 47 tag ("pt-MZ").
+func PickerLocale(tag string) PickerProp {
+	return pickerFunc(func(n *PickerNode) {
+		n.Locale = tag
+	})
+}
+
+// PickerStyle styles the field; the theme's Components.Input is the starting
+// point, so pickers line up with the inputs around them.
+func PickerStyle(props ...StyleProp) PickerProp {
+	return pickerFunc(func(n *PickerNode) {
+		n.Style = append(n.Style, props...)
+	})
+}
+
+// picker applies props and renders the shared part of every picker node.
+func picker(ctx *Context, component string, props []PickerProp) (*PickerNode, map[string]any, *Style) {
+	config := &PickerNode{Locale: ctx.Config().Locale}
+	for _, p := range props {
+		p.Apply(config)
+	}
+	style := themedStyle(ctx, component, ctx.Theme().Components.Input, config.Style)
+	resolveStyle(ctx, style)
+
+	nodeProps := map[string]any{}
+	if config.Locale != "" {
+		nodeProps["locale"] = config.Locale
+	}
+	if config.Placeholder != "" {
+		nodeProps["placeholder"] = config.Placeholder
+	}
+	return config, nodeProps, style
+}
+
+// Select picks one option. The host shows a native picker, or a popover
+// with a filter field when Searchable.
+func Select[T comparable](selected T, options []Option[T], onSelect func(T), props ...PickerProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		config, nodeProps, style := picker(ctx, "select", props)
+
+		indices := []int{}
+		for i, opt := range options {
+			if opt.Value == selected {
+				indices = append(indices, i)
+				break
+			}
+		}
+		selectProps(nodeProps, options, indices, config.Searchable)
+		if !style.Disabled {
+			nodeProps["onChange"] = registerIntCallback(func(i int) {
+				if i >= 0 && i < len(options) && !options[i].Disabled {
+					onSelect(options[i].Value)
+				}
+			})
+		}
+		stateProps(style, nodeProps)
+
+		return &Node{
+			Type:  "Select",
+			Props: nodeProps,
+			Style: style,
+		}
+	})
+}
+
+// MultiSelect picks any number of options; onChange gets them in option
+// order.
+func MultiSelect[T comparable](selected []T, options []Option[T], onChange func([]T), props ...PickerProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		config, nodeProps, style := picker(ctx, "select", props)
+
+		chosen := make(map[T]bool, len(selected))
+		for _, v := range selected {
+			chosen[v] = true
+		}
+		indices := []int{}
+		for i, opt := range options {
+			if chosen[opt.Value] {
+				indices = append(indices, i)
+			}
+		}
+		selectProps(nodeProps, options, indices, config.Searchable)
+		nodeProps["multiple"] = true
+		if !style.Disabled {
+			nodeProps["onChange"] = registerDataCallback(func(data map[string]any) {
+				picked := map[int]bool{}
+				list, _ := data["indices"].([]any)
+				for _, v := range list {
+					if f, ok := v.(float64); ok {
+						picked[int(f)] = true
+					}
+				}
+				values := []T{}
+				for i, opt := range options {
+					// disabled options keep whatever state they had
+					if (picked[i] && !opt.Disabled) || (opt.Disabled && chosen[opt.Value]) {
+						values = append(values, opt.Value)
+					}
+				}
+				onChange(values)
+			})
+		}
+		stateProps(style, nodeProps)
+
+		return &Node{
+			Type:  "Select",
+			Props: nodeProps,
+			Style: style,
+		}
+	})
+}
+
+func selectProps[T comparable](props map[string]any, options []Option[T], selected []int, searchable bool) {
+	list := make([]map[string]any, len(options))
+	for i, opt := range options {
+		list[i] = map[string]any{"label": opt.Label}
+		if opt.Disabled {
+			list[i]["disabled"] = true
+		}
+	}
+	props["options"] = list
+	props["selected"] = selected
+	if searchable {
+		props["searchable"] = true
+	}
+}
+
+// Dates travel as "2006-01-02" and clock times as "15:04"; both compare
+// correctly as strings. The host answers with numbers ({year, month, day},
+// {hour, minute}), which keep the location and the rest of the value.
+const (
+	dateLayout  = "2006-01-02"
+	clockLayout = "15:04"
+)
+
+func formatPicked(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// clamp keeps t between Min and Max, compared in layout.
+func (n *PickerNode) clamp(t time.Time, layout string) time.Time {
+	if !n.Min.IsZero() && t.Format(layout) < n.Min.Format(layout) {
+		return n.snapTo(t, n.Min, layout)
+	}
+	if !n.Max.IsZero() && t.Format(layout) > n.Max.Format(layout) {
+		return n.snapTo(t, n.Max, layout)
+	}
+	return t
+}
+
+func (n *PickerNode) snapTo(t, bound time.Time, layout string) time.Time {
+	if layout == dateLayout {
+		return withDate(t, bound.Year(), bound.Month(), bound.Day())
+	}
+	return withClock(t, bound.Hour(), bound.Minute())
+}
+
+func (n *PickerNode) bounds(props map[string]any, layout string) {
+	if s := formatPicked(n.Min, layout); s != "" {
+		props["min"] = s
+	}
+	if s := formatPicked(n.Max, layout); s != "" {
+		props["max"] = s
+	}
+}
+
+// withDate moves t to another day; a zero t becomes local midnight.
+func withDate(t time.Time, year int, month time.Month, day int) time.Time {
+	if t.IsZero() {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	}
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// withClock moves t to another time of day; a zero t becomes today.
+func withClock(t time.Time, hour, minute int) time.Time {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+}
+
+// pickedDate is the day the host reported, on base's clock. A field the
+// user emptied comes as {cleared: true} and gives the zero time.
+func pickedDate(base time.Time, data map[string]any) time.Time {
+	if cleared(data) {
+		return time.Time{}
+	}
+	return withDate(base, intField(data, "year"), time.Month(intField(data, "month")), intField(data, "day"))
+}
+
+func cleared(data map[string]any) bool {
+	b, _ := data["cleared"].(bool)
+	return b
+}
+
+// DatePicker picks a day. The zero time shows the placeholder, and is what
+// onChange gets when the field is cleared; otherwise onChange keeps value's
+// clock and location.
+func DatePicker(value time.Time, onChange func(time.Time), props ...PickerProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		config, nodeProps, style := picker(ctx, "datepicker", props)
+
+		nodeProps["value"] = formatPicked(value, dateLayout)
+		config.bounds(nodeProps, dateLayout)
+		if !style.Disabled {
+			nodeProps["onChange"] = registerDataCallback(func(data map[string]any) {
+				picked := pickedDate(value, data)
+				if !picked.IsZero() {
+					picked = config.clamp(picked, dateLayout)
+				}
+				onChange(picked)
+			})
+		}
+		stateProps(style, nodeProps)
+
+		return &Node{
+			Type:  "DatePicker",
+			Props: nodeProps,
+			Style: style,
+		}
+	})
+}
+
+// TimePicker picks a time of day on value's date (today when value is zero).
+// Clearing the field reports the zero time.
+func TimePicker(value time.Time, onChange func(time.Time), props ...PickerProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		config, nodeProps, style := picker(ctx, "timepicker", props)
+
+		nodeProps["value"] = formatPicked(value, clockLayout)
+		config.bounds(nodeProps, clockLayout)
+		if !style.Disabled {
+			nodeProps["onChange"] = registerDataCallback(func(data map[string]any) {
+				if cleared(data) {
+					onChange(time.Time{})
+					return
+				}
+				picked := withClock(value, intField(data, "hour"), intField(data, "minute"))
+				onChange(config.clamp(picked, clockLayout))
+			})
+		}
+		stateProps(style, nodeProps)
+
+		return &Node{
+			Type:  "TimePicker",
+			Props: nodeProps,
+			Style: style,
+		}
+	})
+}
+
+// DateRangePicker picks a start and an end day. The host reports both
+// whenever one changes, as {start: {year, month, day}, end: {...}}, with
+// {cleared: true} for an empty day, which onChange gets as the zero time.
+// When the days cross, the one that didn't change follows the one that did.
+func DateRangePicker(start, end time.Time, onChange func(start, end time.Time), props ...PickerProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		config, nodeProps, style := picker(ctx, "datepicker", props)
+
+		nodeProps["start"] = formatPicked(start, dateLayout)
+		nodeProps["end"] = formatPicked(end, dateLayout)
+		config.bounds(nodeProps, dateLayout)
+		if !style.Disabled {
+			nodeProps["onChange"] = registerDataCallback(func(data map[string]any) {
+				from, to := start, end
+				if d, ok := data["start"].(map[string]any); ok {
+					if from = pickedDate(start, d); !from.IsZero() {
+						from = config.clamp(from, dateLayout)
+					}
+				}
+				if d, ok := data["end"].(map[string]any); ok {
+					if to = pickedDate(end, d); !to.IsZero() {
+						to = config.clamp(to, dateLayout)
+					}
+				}
+				if !from.IsZero() && !to.IsZero() && formatPicked(to, dateLayout) < formatPicked(from, dateLayout) {
+					if formatPicked(from, dateLayout) != formatPicked(start, dateLayout) {
+						to = withDate(to, from.Year(), from.Month(), from.Day())
+					} else {
+						from = withDate(from, to.Year(), to.Month(), to.Day())
+					}
+				}
+				onChange(from, to)
+			})
+		}
+		stateProps(style, nodeProps)
+
+		return &Node{
+			Type:  "DateRangePicker",
+			Props: nodeProps,
+			Style: style,
+		}
+	})
+}
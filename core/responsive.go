@@ -0,0 +1,130 @@
+package core
+
+import "sync"
+
+type WindowSize struct {
+	Width  int
+	Height int
+}
+
+type Breakpoint string
+
+const (
+	BreakpointMobile  Breakpoint = "mobile"
+	BreakpointTablet  Breakpoint = "tablet"
+	BreakpointDesktop Breakpoint = "desktop"
+)
+
+// Breakpoints are the minimum widths, in CSS pixels / dp, at which the tablet
+// and desktop layouts start.
+var Breakpoints = struct {
+	Tablet  int
+	Desktop int
+}{
+	Tablet:  600,
+	Desktop: 1024,
+}
+
+func BreakpointFor(width int) Breakpoint {
+	switch {
+	case width >= Breakpoints.Desktop:
+		return BreakpointDesktop
+	case width >= Breakpoints.Tablet:
+		return BreakpointTablet
+	}
+	return BreakpointMobile
+}
+
+var window = struct {
+	mu   sync.Mutex
+	size WindowSize
+}{}
+
+// The host reports the viewport (window.innerWidth on the web, the content
+// view in dp on Android) as {"width": 390, "height": 844} on start and on
+// every resize.
+func init() {
+	OnSystemEvent("resize", func(data map[string]any) {
+		SetWindowSize(intField(data, "width"), intField(data, "height"))
+	})
+}
+
+func SetWindowSize(width, height int) {
+	window.mu.Lock()
+	window.size = WindowSize{Width: width, Height: height}
+	window.mu.Unlock()
+}
+
+// WithWindowSizeOpt renders the context at a fixed size instead of the one
+// the host reports, e.g. to export static HTML for a given device.
+func WithWindowSizeOpt(size WindowSize) func(*Context) {
+	return func(ctx *Context) {
+		ctx.window = &size
+	}
+}
+
+// windowSize is the size set on the context with WithWindowSizeOpt, or else
+// the last one the host reported.
+func (ctx *Context) windowSize() WindowSize {
+	if ctx.window != nil {
+		return *ctx.window
+	}
+	window.mu.Lock()
+	defer window.mu.Unlock()
+	return window.size
+}
+
+// UseWindowSize returns the size the context renders at; both fields are 0
+// until the host first reports one (e.g. when exporting static HTML).
+func UseWindowSize(ctx *Context) WindowSize {
+	return ctx.windowSize()
+}
+
+// UseBreakpoint classifies the window width. Before the host reports a size
+// it returns BreakpointMobile.
+func UseBreakpoint(ctx *Context) Breakpoint {
+	return BreakpointFor(ctx.windowSize().Width)
+}
+
+// WhenBreakpoint layers props on the style while the window is in bp. Go
+// applies it at render time once the window size is known and hosts re-render
+// on resize; until then (static HTML export) it becomes a media query.
+func WhenBreakpoint(bp Breakpoint, props ...StyleProp) StyleProp {
+	return styleFunc(func(s *Style) {
+		// copy first: the map may still be shared with a theme default
+		responsive := make(ResponsiveStyle, len(s.Responsive)+1)
+		for k, v := range s.Responsive {
+			responsive[k] = v
+		}
+		state := responsive[string(bp)]
+		for _, sp := range props {
+			sp.Apply(&state)
+		}
+		responsive[string(bp)] = state
+		s.Responsive = responsive
+	})
+}
+
+// Responsive renders the view for the current breakpoint. A nil tablet view
+// falls back to mobile, and a nil desktop view to tablet.
+func Responsive(mobile, tablet, desktop View) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		views := []View{mobile, tablet, desktop}
+		index := 0
+		switch UseBreakpoint(ctx) {
+		case BreakpointTablet:
+			index = 1
+		case BreakpointDesktop:
+			index = 2
+		}
+		view := views[index]
+		for view == nil && index > 0 {
+			index--
+			view = views[index]
+		}
+		if view == nil {
+			return &Node{Type: "Fragment"}
+		}
+		return view.Render(ctx)
+	})
+}
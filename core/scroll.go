@@ -0,0 +1,227 @@
+package core
+
+import "fmt"
+
+type ScrollDirection string
+
+const (
+	ScrollVertical   ScrollDirection = "vertical"
+	ScrollHorizontal ScrollDirection = "horizontal"
+	ScrollBoth       ScrollDirection = "both"
+)
+
+type Size struct {
+	Width  float64
+	Height float64
+}
+
+// ScrollEvent reports where a Scroll is, in CSS pixels / dp.
+type ScrollEvent struct {
+	Offset      Point
+	ContentSize Size
+	Viewport    Size
+}
+
+type ScrollProp interface {
+	Apply(*ScrollNode)
+}
+
+type ScrollNode struct {
+	Direction     ScrollDirection
+	OnScroll      func(ScrollEvent)
+	Throttle      int // ms between OnScroll events
+	OnEndReached  func()
+	EndThreshold  float64 // px from the end at which OnEndReached fires
+	OnRefresh     func()
+	Refreshing    bool
+	KeyboardAware bool
+	Ref           *ScrollRef
+}
+
+type scrollFunc func(*ScrollNode)
+
+func (f scrollFunc) Apply(n *ScrollNode) { f(n) }
+
+const (
+	defaultScrollThrottle = 16 // ms, about one event per frame
+	defaultEndThreshold   = 200
+)
+
+func ScrollDir(d ScrollDirection) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.Direction = d
+	})
+}
+
+// OnScroll reports the position at most once per ScrollThrottle.
+func OnScroll(fn func(ScrollEvent)) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.OnScroll = fn
+	})
+}
+
+func ScrollThrottle(ms int) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.Throttle = ms
+	})
+}
+
+// OnEndReached fires when the end comes within EndReachedThreshold of the
+// viewport, once per content size, so a feed can load its next page.
+func OnEndReached(fn func()) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.OnEndReached = fn
+	})
+}
+
+func EndReachedThreshold(px float64) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.EndThreshold = px
+	})
+}
+
+// OnRefresh enables pull-to-refresh. The host shows its indicator while
+// Refreshing is true, so set it when the refresh starts and clear it when
+// the data arrives.
+func OnRefresh(fn func()) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.OnRefresh = fn
+	})
+}
+
+func Refreshing(refreshing bool) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.Refreshing = refreshing
+	})
+}
+
+// KeyboardAware pads the bottom of the scroll area by the height of the
+// on-screen keyboard, and the host keeps the focused input in view.
+func KeyboardAware(enabled bool) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.KeyboardAware = enabled
+	})
+}
+
+func WithScrollRef(ref *ScrollRef) ScrollProp {
+	return scrollFunc(func(n *ScrollNode) {
+		n.Ref = ref
+	})
+}
+
+func Scroll(stylePropsAndChildren ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		var styleProps []StyleProp
+		var children []View
+		var behaviors []BehaviorProp
+		node := &ScrollNode{
+			Direction:    ScrollVertical,
+			Throttle:     defaultScrollThrottle,
+			EndThreshold: defaultEndThreshold,
+		}
+		for _, item := range stylePropsAndChildren {
+			switch v := item.(type) {
+			case StyleProp:
+				styleProps = append(styleProps, v)
+			case View:
+				children = append(children, v)
+			case BehaviorProp:
+				behaviors = append(behaviors, v)
+			case ScrollProp:
+				v.Apply(node)
+			}
+		}
+
+		style := themedStyle(ctx, "scroll", ctx.Theme().Components.Scroll, styleProps)
+		resolveStyle(ctx, style)
+		if node.KeyboardAware {
+			if kb := currentKeyboard(); kb.Height > 0 && !kb.resized {
+				if style.Padding.Bottom == 0 {
+					style.Padding.Bottom = style.Padding.Vertical
+				}
+				style.Padding.Bottom += kb.Height
+			}
+		}
+
+		props := map[string]any{
+			"direction": string(node.Direction),
+		}
+		if node.OnScroll != nil {
+			props["onScroll"] = registerDataCallback(func(data map[string]any) {
+				node.OnScroll(parseScrollEvent(data))
+			})
+			props["scrollThrottle"] = node.Throttle
+		}
+		if node.OnEndReached != nil {
+			props["onEndReached"] = registerCallback(node.OnEndReached)
+			props["endThreshold"] = node.EndThreshold
+		}
+		if node.OnRefresh != nil {
+			props["onRefresh"] = registerCallback(node.OnRefresh)
+			props["refreshing"] = node.Refreshing
+		}
+		if node.KeyboardAware {
+			props["keyboardAware"] = true
+		}
+		if node.Ref != nil {
+			props["controller"] = node.Ref.ID
+		}
+
+		return applyBehaviors(&Node{
+			Type:     "Scroll",
+			Props:    props,
+			Style:    style,
+			Children: renderAll(ctx, children),
+		}, behaviors)
+	})
+}
+
+func parseScrollEvent(data map[string]any) ScrollEvent {
+	return ScrollEvent{
+		Offset:      Point{X: floatField(data, "x"), Y: floatField(data, "y")},
+		ContentSize: Size{Width: floatField(data, "contentWidth"), Height: floatField(data, "contentHeight")},
+		Viewport:    Size{Width: floatField(data, "viewportWidth"), Height: floatField(data, "viewportHeight")},
+	}
+}
+
+// ScrollRef scrolls a Scroll (or, through ListController, a LazyList)
+// imperatively. Commands travel through the "scroll" system event, addressed
+// by the ID the view exposes as its "controller" prop.
+type ScrollRef struct {
+	ID string
+}
+
+var scrollRefCounter int
+
+func UseScrollRef(ctx *Context) *ScrollRef {
+	index := ctx.Cursor
+	ctx.Cursor++
+
+	if index >= len(ctx.slots) {
+		callbackMux.Lock()
+		id := fmt.Sprintf("scroll_%d", scrollRefCounter)
+		scrollRefCounter++
+		callbackMux.Unlock()
+		ctx.slots = append(ctx.slots, &ScrollRef{ID: id})
+	}
+	return ctx.slots[index].(*ScrollRef)
+}
+
+func (r *ScrollRef) ScrollTo(x, y float64, animated bool) {
+	r.send("scrollTo", map[string]any{"x": x, "y": y, "animated": animated})
+}
+
+func (r *ScrollRef) ScrollToEnd(animated bool) {
+	r.send("scrollToEnd", map[string]any{"animated": animated})
+}
+
+func (r *ScrollRef) send(action string, extra map[string]any) {
+	payload := map[string]any{
+		"target": r.ID,
+		"action": action,
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	SendSystemEvent("scroll", payload)
+}
@@ -0,0 +1,341 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Option is one choice of a RadioGroup or SegmentedControl. Renderers only
+// see the label; the value stays in Go and comes back through the typed
+// callback.
+type Option[T comparable] struct {
+	Label    string
+	Value    T
+	Disabled bool
+}
+
+func Opt[T comparable](label string, value T) Option[T] {
+	return Option[T]{Label: label, Value: value}
+}
+
+// Switch is an on/off toggle; it renders with its SelectedStyle while on.
+func Switch(on bool, onToggle func(bool), styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		style := themedStyle(ctx, "switch", ctx.Theme().Components.Switch, append(styleProps[:len(styleProps):len(styleProps)], Selected(on)))
+
+		resolveStyle(ctx, style)
+
+		props := map[string]any{
+			"checked": on,
+		}
+		if !style.Disabled {
+			props["onToggle"] = registerBoolCallback(onToggle)
+		}
+		stateProps(style, props)
+
+		return &Node{
+			Type:  "Switch",
+			Props: props,
+			Style: style,
+		}
+	})
+}
+
+var radioGroupCounter int
+
+// radioGroupName is kept in a hook slot so a group keeps its name, and the
+// host its grouping, across renders.
+type radioGroupName string
+
+func useRadioGroupName(ctx *Context) radioGroupName {
+	index := ctx.Cursor
+	ctx.Cursor++
+
+	if index >= len(ctx.slots) {
+		callbackMux.Lock()
+		name := radioGroupName(fmt.Sprintf("radio_%d", radioGroupCounter))
+		radioGroupCounter++
+		callbackMux.Unlock()
+		ctx.slots = append(ctx.slots, name)
+	}
+	return ctx.slots[index].(radioGroupName)
+}
+
+// RadioGroup lists options as radio buttons, one per row, with selected
+// checked. styleProps style the group; each option takes Components.Radio.
+func RadioGroup[T comparable](selected T, options []Option[T], onSelect func(T), styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		style := themedStyle(ctx, "radiogroup", Style{}, styleProps)
+		resolveStyle(ctx, style)
+
+		// the host groups the radios by name for keyboard navigation
+		name := string(useRadioGroupName(ctx))
+
+		children := make([]*Node, len(options))
+		for i, opt := range options {
+			checked := opt.Value == selected
+			radio := themedStyle(ctx, "radio", ctx.Theme().Components.Radio, []StyleProp{
+				Selected(checked),
+				Disabled(style.Disabled || opt.Disabled),
+			})
+			resolveStyle(ctx, radio)
+
+			props := map[string]any{
+				"label":   opt.Label,
+				"checked": checked,
+				"name":    name,
+			}
+			if !radio.Disabled {
+				value := opt.Value
+				props["onSelect"] = registerCallback(func() { onSelect(value) })
+			}
+			stateProps(radio, props)
+			children[i] = &Node{Type: "Radio", Props: props, Style: radio}
+		}
+
+		props := map[string]any{}
+		stateProps(style, props)
+		return &Node{
+			Type:     "RadioGroup",
+			Props:    props,
+			Style:    style,
+			Children: children,
+		}
+	})
+}
+
+// SegmentedControl is a row of mutually exclusive segments, the selected one
+// in Components.Segment's SelectedStyle. styleProps style the track.
+func SegmentedControl[T comparable](selected T, options []Option[T], onSelect func(T), styleProps ...StyleProp) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		style := themedStyle(ctx, "segmented", ctx.Theme().Components.Segmented, styleProps)
+		resolveStyle(ctx, style)
+
+		children := make([]*Node, len(options))
+		for i, opt := range options {
+			segment := themedStyle(ctx, "segment", ctx.Theme().Components.Segment, []StyleProp{
+				Selected(opt.Value == selected),
+				Disabled(style.Disabled || opt.Disabled),
+			})
+			resolveStyle(ctx, segment)
+
+			props := map[string]any{
+				"label": opt.Label,
+			}
+			if !segment.Disabled {
+				value := opt.Value
+				props["onClick"] = registerCallback(func() { onSelect(value) })
+			}
+			stateProps(segment, props)
+			children[i] = &Node{Type: "Button", Props: props, Style: segment}
+		}
+
+		props := map[string]any{}
+		stateProps(style, props)
+		return &Node{
+			Type:     "SegmentedControl",
+			Props:    props,
+			Style:    style,
+			Children: children,
+		}
+	})
+}
+
+type SliderProp interface {
+	Apply(*SliderNode)
+}
+
+// SliderNode holds the bounds of a Slider or RangeSlider. Step 0 slides
+// continuously.
+type SliderNode struct {
+	Min  float64
+	Max  float64
+	Step float64
+}
+
+type sliderFunc func(*SliderNode)
+
+func (f sliderFunc) Apply(n *SliderNode) { f(n) }
+
+func SliderBounds(min, max float64) SliderProp {
+	return sliderFunc(func(n *SliderNode) {
+		n.Min = min
+		n.Max = max
+	})
+}
+
+func SliderStep(step float64) SliderProp {
+	return sliderFunc(func(n *SliderNode) {
+		n.Step = step
+	})
+}
+
+func splitSliderProps(items []PropsAndChildren) ([]StyleProp, *SliderNode) {
+	var styleProps []StyleProp
+	config := &SliderNode{Min: 0, Max: 1}
+	for _, item := range items {
+		switch v := item.(type) {
+		case StyleProp:
+			styleProps = append(styleProps, v)
+		case SliderProp:
+			v.Apply(config)
+		}
+	}
+	return styleProps, config
+}
+
+// snap clamps v to the bounds and rounds it to the nearest step, so Go never
+// sees a value the slider couldn't show.
+func (n *SliderNode) snap(v float64) float64 {
+	if n.Step > 0 {
+		v = n.Min + math.Round((v-n.Min)/n.Step)*n.Step
+		v = math.Round(v*1e9) / 1e9 // 0.1+0.2
+	}
+	return math.Max(n.Min, math.Min(v, n.Max))
+}
+
+func (n *SliderNode) apply(props map[string]any) {
+	props["min"] = n.Min
+	props["max"] = n.Max
+	if n.Step > 0 {
+		props["step"] = n.Step
+	}
+}
+
+// Slider picks a value between SliderBounds (0 to 1 by default). onChange
+// runs while the thumb moves, with the value snapped to SliderStep.
+func Slider(value float64, onChange func(float64), props ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		styleProps, config := splitSliderProps(props)
+		style := themedStyle(ctx, "slider", ctx.Theme().Components.Slider, styleProps)
+
+		resolveStyle(ctx, style)
+
+		nodeProps := map[string]any{
+			"value": config.snap(value),
+		}
+		config.apply(nodeProps)
+		if !style.Disabled {
+			nodeProps["onChange"] = registerNumberCallback(func(v float64) {
+				onChange(config.snap(v))
+			})
+		}
+		stateProps(style, nodeProps)
+
+		return &Node{
+			Type:  "Slider",
+			Props: nodeProps,
+			Style: style,
+		}
+	})
+}
+
+// RangeSlider is a Slider with two thumbs; the low one never passes the
+// high one.
+func RangeSlider(low, high float64, onChange func(low, high float64), props ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		styleProps, config := splitSliderProps(props)
+		style := themedStyle(ctx, "slider", ctx.Theme().Components.Slider, styleProps)
+
+		resolveStyle(ctx, style)
+
+		low, high = config.snap(low), config.snap(high)
+		nodeProps := map[string]any{
+			"low":  math.Min(low, high),
+			"high": math.Max(low, high),
+		}
+		config.apply(nodeProps)
+		if !style.Disabled {
+			nodeProps["onChange"] = registerDataCallback(func(data map[string]any) {
+				l := config.snap(floatField(data, "low"))
+				h := config.snap(floatField(data, "high"))
+				onChange(math.Min(l, h), math.Max(l, h))
+			})
+		}
+		stateProps(style, nodeProps)
+
+		return &Node{
+			Type:  "RangeSlider",
+			Props: nodeProps,
+			Style: style,
+		}
+	})
+}
+
+type StepperProp interface {
+	Apply(*StepperNode)
+}
+
+type StepperNode struct {
+	Min    int
+	Max    int
+	Step   int
+	Format func(int) string
+}
+
+type stepperFunc func(*StepperNode)
+
+func (f stepperFunc) Apply(n *StepperNode) { f(n) }
+
+func StepperBounds(min, max int) StepperProp {
+	return stepperFunc(func(n *StepperNode) {
+		n.Min = min
+		n.Max = max
+	})
+}
+
+func StepperStep(step int) StepperProp {
+	return stepperFunc(func(n *StepperNode) {
+		n.Step = step
+	})
+}
+
+// StepperFormat renders the value, e.g. "2 guests"; the default is the
+// number.
+func StepperFormat(format func(int) string) StepperProp {
+	return stepperFunc(func(n *StepperNode) {
+		n.Format = format
+	})
+}
+
+// Stepper shows value between a decrement and an increment button, which
+// switch off at StepperBounds (0 and up by default).
+func Stepper(value int, onChange func(int), props ...PropsAndChildren) View {
+	return ComponentFunc(func(ctx *Context) *Node {
+		var styleProps []StyleProp
+		config := &StepperNode{Min: 0, Max: math.MaxInt, Step: 1, Format: strconv.Itoa}
+		for _, item := range props {
+			switch v := item.(type) {
+			case StyleProp:
+				styleProps = append(styleProps, v)
+			case StepperProp:
+				v.Apply(config)
+			}
+		}
+		style := themedStyle(ctx, "stepper", ctx.Theme().Components.Stepper, styleProps)
+		resolveStyle(ctx, style)
+
+		step := func(delta int) {
+			onChange(max(config.Min, min(value+delta, config.Max)))
+		}
+		children := []*Node{
+			Button("−", func() { step(-config.Step) },
+				Variant(ButtonOutline), Disabled(style.Disabled || value <= config.Min)).Render(ctx),
+			Text(config.Format(value)).Render(ctx),
+			Button("+", func() { step(config.Step) },
+				Variant(ButtonOutline), Disabled(style.Disabled || value >= config.Max)).Render(ctx),
+		}
+
+		nodeProps := map[string]any{
+			"value": value,
+		}
+		stateProps(style, nodeProps)
+		return &Node{
+			Type:     "Stepper",
+			Props:    nodeProps,
+			Style:    style,
+			Children: children,
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package core
+
+// WhenHovered styles a component while the pointer is over it.
+func WhenHovered(props ...StyleProp) StyleProp {
+	return stateStyle(func(s *Style) **Style { return &s.HoverStyle }, props)
+}
+
+// WhenPressed styles a component while it is being pressed.
+func WhenPressed(props ...StyleProp) StyleProp {
+	return stateStyle(func(s *Style) **Style { return &s.PressedStyle }, props)
+}
+
+// WhenFocused styles a component while it has keyboard focus.
+func WhenFocused(props ...StyleProp) StyleProp {
+	return stateStyle(func(s *Style) **Style { return &s.FocusStyle }, props)
+}
+
+// WhenDisabled styles a component that was rendered with Disabled(true).
+func WhenDisabled(props ...StyleProp) StyleProp {
+	return stateStyle(func(s *Style) **Style { return &s.DisabledStyle }, props)
+}
+
+// WhenSelected styles a component that was rendered with Selected(true).
+func WhenSelected(props ...StyleProp) StyleProp {
+	return stateStyle(func(s *Style) **Style { return &s.SelectedStyle }, props)
+}
+
+func stateStyle(field func(*Style) **Style, props []StyleProp) StyleProp {
+	return styleFunc(func(s *Style) {
+		target := field(s)
+		state := Style{}
+		if *target != nil {
+			state = **target // theme defaults may share it
+		}
+		for _, sp := range props {
+			sp.Apply(&state)
+		}
+		*target = &state
+	})
+}
+
+// Disabled turns a Button or input off: it renders with its DisabledStyle and
+// its handlers are not registered, so no events reach Go.
+func Disabled(disabled bool) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.Disabled = disabled
+	})
+}
+
+// Selected marks a component as selected so its SelectedStyle applies.
+func Selected(selected bool) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.Selected = selected
+	})
+}
+
+// stateProps copies the Disabled/Selected flags into the node props the
+// renderers read.
+func stateProps(style *Style, props map[string]any) {
+	if style.Disabled {
+		props["disabled"] = true
+	}
+	if style.Selected {
+		props["selected"] = true
+	}
+}
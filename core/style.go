@@ -1,37 +1,52 @@
 package core
 
 type Style struct {
-	FontSize     float64
-	FontWeight   Weight
-	TextColor    string
-	Background   string
-	Padding      EdgeInsets
-	Margin       EdgeInsets
-	BorderRadius float64
-	Shadow       float64
-	Align        Alignment
-	Display      DisplayMode
-	Width        string
-	Height       string
-	BorderColor  string
-	BorderWidth  float64
-	Position     Position
-	Top          string
-	Left         string
-	Right        string
-	Bottom       string
-	ZIndex       int
-	Overflow     string // "hidden", "scroll", "visible"
-	WhiteSpace   string // "nowrap", "normal", "pre-line"
-	LineHeight   int
-	MaxWidth     string
-	Gap          float64
-	Transition   string // "all 0.3s ease"
-	Animation    string // "bounce 2s infinite"
-
-	HoverStyle   *Style
-	FocusStyle   *Style
-	PseudoStates map[string]Style // ":hover", ":focus"
+	FontSize       float64
+	FontWeight     Weight
+	FontFamily     string
+	FontStyle      FontStyle
+	LetterSpacing  float64
+	TextAlign      TextAlignment
+	TextDecoration TextDecoration
+	TextTransform  TextTransform
+	TextColor      string
+	Background     string
+	Padding        EdgeInsets
+	Margin         EdgeInsets
+	BorderRadius   float64
+	Shadow         float64
+	Align          Alignment
+	Display        DisplayMode
+	Width          string
+	Height         string
+	BorderColor    string
+	BorderWidth    float64
+	Position       Position
+	Top            string
+	Left           string
+	Right          string
+	Bottom         string
+	ZIndex         int
+	Overflow       string // "hidden", "scroll", "visible"
+	WhiteSpace     string // "nowrap", "normal", "pre-line"
+	LineHeight     int
+	MaxWidth       string
+	Gap            float64
+	Transition     string // "all 0.3s ease"; web only, see Animated
+	Animation      string // "bounce 2s infinite"; web only
+
+	// State styles are layered on the style while the element is in that
+	// state; see WhenHovered and friends.
+	HoverStyle    *Style
+	FocusStyle    *Style
+	PressedStyle  *Style
+	DisabledStyle *Style
+	SelectedStyle *Style
+	PseudoStates  map[string]Style // ":hover", ":focus"
+
+	// Responsive holds styles layered on while the window is in a given
+	// breakpoint; see WhenBreakpoint.
+	Responsive ResponsiveStyle
 
 	FlexDirection  FlexDirection
 	JustifyContent JustifyContent
@@ -45,14 +60,63 @@ type Style struct {
 	FlexBasis      string
 	FlexShrink     float64
 	FlexGrow       float64
+
+	// Variant selects a named style from the theme (a typography role for
+	// Text); it is resolved during render and never sent to renderers.
+	Variant string `json:"-"`
+
+	// Disabled and Selected put the component in that state: the renderer
+	// applies DisabledStyle/SelectedStyle and a disabled component drops its
+	// event handlers.
+	Disabled bool `json:"-"`
+	Selected bool `json:"-"`
+
+	// Unset lists fields to clear when this style is merged onto another;
+	// see Merge and Unset.
+	Unset StyleField `json:"-"`
 }
 
 type Weight int
 
 const (
-	Light  Weight = 200
-	Normal Weight = 400
-	Bold   Weight = 700
+	Light    Weight = 200
+	Normal   Weight = 400
+	Medium   Weight = 500
+	SemiBold Weight = 600
+	Bold     Weight = 700
+)
+
+type FontStyle string
+
+const (
+	FontNormal FontStyle = "normal"
+	FontItalic FontStyle = "italic"
+)
+
+type TextAlignment string
+
+const (
+	TextAlignLeft    TextAlignment = "left"
+	TextAlignCenter  TextAlignment = "center"
+	TextAlignRight   TextAlignment = "right"
+	TextAlignJustify TextAlignment = "justify"
+)
+
+type TextDecoration string
+
+const (
+	DecorationNone        TextDecoration = "none"
+	DecorationUnderline   TextDecoration = "underline"
+	DecorationLineThrough TextDecoration = "line-through"
+)
+
+type TextTransform string
+
+const (
+	TransformNone       TextTransform = "none"
+	TransformUppercase  TextTransform = "uppercase"
+	TransformLowercase  TextTransform = "lowercase"
+	TransformCapitalize TextTransform = "capitalize"
 )
 
 type EdgeInsets struct {
@@ -68,68 +132,27 @@ func (f styleFunc) Apply(s *Style) {
 	f(s)
 }
 
+// UseStyle merges s into the target; see Style.Merge.
 func UseStyle(s Style) StyleProp {
 	return styleFunc(func(target *Style) {
-		// Merge only non-zero fields
-		if s.FontSize != 0 {
-			target.FontSize = s.FontSize
-		}
-		if s.FontWeight != 0 {
-			target.FontWeight = s.FontWeight
-		}
-		if s.TextColor != "" {
-			target.TextColor = s.TextColor
-		}
-		if s.Background != "" {
-			target.Background = s.Background
-		}
-		if s.BorderRadius != 0 {
-			target.BorderRadius = s.BorderRadius
-		}
-		if s.Shadow != 0 {
-			target.Shadow = s.Shadow
-		}
-		if s.Align != "" {
-			target.Align = s.Align
-		}
-		if s.Display != "" {
-			target.Display = s.Display
-		}
-		if s.Padding != (EdgeInsets{}) {
-			target.Padding = s.Padding
-		}
-		if s.Margin != (EdgeInsets{}) {
-			target.Margin = s.Margin
-		}
-		if s.Bottom != "" {
-			target.Bottom = s.Bottom
-		}
-		if s.Left != "" {
-			target.Left = s.Left
-		}
-		if s.Right != "" {
-			target.Right = s.Right
-		}
-		if s.ZIndex != 0 {
-			target.ZIndex = s.ZIndex
-		}
-
+		*target = target.Merge(s)
 	})
 }
-func PrimaryColor() string { return "#007AFF" }
-func DangerColor() string  { return "#FF3B30" }
+
+func PrimaryColor() string { return Token("colors.primary") }
+func DangerColor() string  { return Token("colors.error") }
 func RoundedShadowBox() StyleProp {
 	return UseStyle(Style{
 		BorderRadius: 12,
 		Shadow:       2,
-		Background:   "#FFFFFF",
+		Background:   Token("colors.background"),
 	})
 }
 
 var TextInputStyle = UseStyle(Style{
 	FontSize:     16,
-	TextColor:    "#000000",
-	Background:   "#FFFFFF",
+	TextColor:    Token("colors.textPrimary"),
+	Background:   Token("colors.background"),
 	Padding:      EdgeInsets{Top: 10, Bottom: 10, Left: 12, Right: 12},
 	BorderRadius: 8,
 	Shadow:       1,
@@ -163,11 +186,13 @@ const (
 type DisplayMode string
 
 const (
-	DisplayVisible DisplayMode = "visible"
-	DisplayHidden  DisplayMode = "hidden"
-	DisplayNone    DisplayMode = "none"
-	DisplayInline  DisplayMode = "inline"
-	DisplayBlock   DisplayMode = "block"
+	DisplayVisible     DisplayMode = "visible"
+	DisplayHidden      DisplayMode = "hidden"
+	DisplayNone        DisplayMode = "none"
+	DisplayInline      DisplayMode = "inline"
+	DisplayBlock       DisplayMode = "block"
+	DisplayInlineBlock DisplayMode = "inline-block"
+	DisplayGrid        DisplayMode = "grid"
 )
 
 type JustifyContent string
@@ -200,12 +225,3 @@ const (
 	PositionFixed    Position = "fixed"
 	PositionSticky   Position = "sticky"
 )
-
-//func Responsive(breakpoint string, style Style) StyleProp {
-//	return styleFunc(func(s *Style) {
-//		if s.Responsive == nil {
-//			s.Responsive = make(ResponsiveStyle)
-//		}
-//		s.Responsive[breakpoint] = style
-//	})
-//}
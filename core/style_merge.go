@@ -0,0 +1,286 @@
+package core
+
+import "strings"
+
+// StyleField names one Style property. Fields combine into a mask, e.g.
+// FieldShadow|FieldBorderWidth, which is how a style says "reset this to its
+// zero value" instead of "leave it alone".
+type StyleField uint64
+
+const (
+	FieldFontSize StyleField = 1 << iota
+	FieldFontWeight
+	FieldFontFamily
+	FieldFontStyle
+	FieldLetterSpacing
+	FieldTextAlign
+	FieldTextDecoration
+	FieldTextTransform
+	FieldTextColor
+	FieldBackground
+	FieldPadding
+	FieldMargin
+	FieldBorderRadius
+	FieldShadow
+	FieldAlign
+	FieldDisplay
+	FieldWidth
+	FieldHeight
+	FieldBorderColor
+	FieldBorderWidth
+	FieldPosition
+	FieldTop
+	FieldLeft
+	FieldRight
+	FieldBottom
+	FieldZIndex
+	FieldOverflow
+	FieldWhiteSpace
+	FieldLineHeight
+	FieldMaxWidth
+	FieldGap
+	FieldTransition
+	FieldAnimation
+	FieldHoverStyle
+	FieldFocusStyle
+	FieldPressedStyle
+	FieldDisabledStyle
+	FieldSelectedStyle
+	FieldPseudoStates
+	FieldResponsive
+	FieldFlexDirection
+	FieldJustifyContent
+	FieldAlignItems
+	FieldMinHeight
+	FieldMinWidth
+	FieldColumnGap
+	FieldRowGap
+	FieldFlexWrap
+	FieldAlignSelf
+	FieldFlexBasis
+	FieldFlexShrink
+	FieldFlexGrow
+	FieldVariant
+	FieldDisabled
+	FieldSelected
+)
+
+// styleFieldNames follows the bit order of the Field constants.
+var styleFieldNames = [...]string{
+	"FontSize",
+	"FontWeight",
+	"FontFamily",
+	"FontStyle",
+	"LetterSpacing",
+	"TextAlign",
+	"TextDecoration",
+	"TextTransform",
+	"TextColor",
+	"Background",
+	"Padding",
+	"Margin",
+	"BorderRadius",
+	"Shadow",
+	"Align",
+	"Display",
+	"Width",
+	"Height",
+	"BorderColor",
+	"BorderWidth",
+	"Position",
+	"Top",
+	"Left",
+	"Right",
+	"Bottom",
+	"ZIndex",
+	"Overflow",
+	"WhiteSpace",
+	"LineHeight",
+	"MaxWidth",
+	"Gap",
+	"Transition",
+	"Animation",
+	"HoverStyle",
+	"FocusStyle",
+	"PressedStyle",
+	"DisabledStyle",
+	"SelectedStyle",
+	"PseudoStates",
+	"Responsive",
+	"FlexDirection",
+	"JustifyContent",
+	"AlignItems",
+	"MinHeight",
+	"MinWidth",
+	"ColumnGap",
+	"RowGap",
+	"FlexWrap",
+	"AlignSelf",
+	"FlexBasis",
+	"FlexShrink",
+	"FlexGrow",
+	"Variant",
+	"Disabled",
+	"Selected",
+}
+
+func (m StyleField) Has(f StyleField) bool { return m&f != 0 }
+
+// Names lists the fields in the mask, e.g. ["Shadow", "BorderWidth"].
+func (m StyleField) Names() []string {
+	var names []string
+	for i, name := range styleFieldNames {
+		if m.Has(1 << i) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ParseStyleField looks a field up by name, ignoring case ("shadow",
+// "borderWidth").
+func ParseStyleField(name string) (StyleField, bool) {
+	for i, n := range styleFieldNames {
+		if strings.EqualFold(n, name) {
+			return 1 << i, true
+		}
+	}
+	return 0, false
+}
+
+// Unset clears the given fields. When the style carrying it is merged onto
+// another one (theme variants, UseStyle, With), the same fields are cleared
+// there too, so a variant can drop a shadow its base declares.
+func Unset(fields ...StyleField) StyleProp {
+	return styleFunc(func(s *Style) {
+		var mask StyleField
+		for _, f := range fields {
+			mask |= f
+		}
+		*s = s.Merge(Style{Unset: mask})
+	})
+}
+
+// Merge layers other on top of s. Non-zero fields of other win; zero fields
+// keep the value from s unless other lists them in Unset. Padding and Margin
+// merge per side, state styles merge recursively and PseudoStates and Responsive
+// merge per key.
+func (s Style) Merge(other Style) Style {
+	out := s
+	u := other.Unset
+
+	mergeField(&out.FontSize, other.FontSize, u.Has(FieldFontSize))
+	mergeField(&out.FontWeight, other.FontWeight, u.Has(FieldFontWeight))
+	mergeField(&out.FontFamily, other.FontFamily, u.Has(FieldFontFamily))
+	mergeField(&out.FontStyle, other.FontStyle, u.Has(FieldFontStyle))
+	mergeField(&out.LetterSpacing, other.LetterSpacing, u.Has(FieldLetterSpacing))
+	mergeField(&out.TextAlign, other.TextAlign, u.Has(FieldTextAlign))
+	mergeField(&out.TextDecoration, other.TextDecoration, u.Has(FieldTextDecoration))
+	mergeField(&out.TextTransform, other.TextTransform, u.Has(FieldTextTransform))
+	mergeField(&out.TextColor, other.TextColor, u.Has(FieldTextColor))
+	mergeField(&out.Background, other.Background, u.Has(FieldBackground))
+	mergeField(&out.BorderRadius, other.BorderRadius, u.Has(FieldBorderRadius))
+	mergeField(&out.Shadow, other.Shadow, u.Has(FieldShadow))
+	mergeField(&out.Align, other.Align, u.Has(FieldAlign))
+	mergeField(&out.Display, other.Display, u.Has(FieldDisplay))
+	mergeField(&out.Width, other.Width, u.Has(FieldWidth))
+	mergeField(&out.Height, other.Height, u.Has(FieldHeight))
+	mergeField(&out.BorderColor, other.BorderColor, u.Has(FieldBorderColor))
+	mergeField(&out.BorderWidth, other.BorderWidth, u.Has(FieldBorderWidth))
+	mergeField(&out.Position, other.Position, u.Has(FieldPosition))
+	mergeField(&out.Top, other.Top, u.Has(FieldTop))
+	mergeField(&out.Left, other.Left, u.Has(FieldLeft))
+	mergeField(&out.Right, other.Right, u.Has(FieldRight))
+	mergeField(&out.Bottom, other.Bottom, u.Has(FieldBottom))
+	mergeField(&out.ZIndex, other.ZIndex, u.Has(FieldZIndex))
+	mergeField(&out.Overflow, other.Overflow, u.Has(FieldOverflow))
+	mergeField(&out.WhiteSpace, other.WhiteSpace, u.Has(FieldWhiteSpace))
+	mergeField(&out.LineHeight, other.LineHeight, u.Has(FieldLineHeight))
+	mergeField(&out.MaxWidth, other.MaxWidth, u.Has(FieldMaxWidth))
+	mergeField(&out.Gap, other.Gap, u.Has(FieldGap))
+	mergeField(&out.Transition, other.Transition, u.Has(FieldTransition))
+	mergeField(&out.Animation, other.Animation, u.Has(FieldAnimation))
+	mergeField(&out.FlexDirection, other.FlexDirection, u.Has(FieldFlexDirection))
+	mergeField(&out.JustifyContent, other.JustifyContent, u.Has(FieldJustifyContent))
+	mergeField(&out.AlignItems, other.AlignItems, u.Has(FieldAlignItems))
+	mergeField(&out.MinHeight, other.MinHeight, u.Has(FieldMinHeight))
+	mergeField(&out.MinWidth, other.MinWidth, u.Has(FieldMinWidth))
+	mergeField(&out.ColumnGap, other.ColumnGap, u.Has(FieldColumnGap))
+	mergeField(&out.RowGap, other.RowGap, u.Has(FieldRowGap))
+	mergeField(&out.FlexWrap, other.FlexWrap, u.Has(FieldFlexWrap))
+	mergeField(&out.AlignSelf, other.AlignSelf, u.Has(FieldAlignSelf))
+	mergeField(&out.FlexBasis, other.FlexBasis, u.Has(FieldFlexBasis))
+	mergeField(&out.FlexShrink, other.FlexShrink, u.Has(FieldFlexShrink))
+	mergeField(&out.FlexGrow, other.FlexGrow, u.Has(FieldFlexGrow))
+	mergeField(&out.Variant, other.Variant, u.Has(FieldVariant))
+	mergeField(&out.Disabled, other.Disabled, u.Has(FieldDisabled))
+	mergeField(&out.Selected, other.Selected, u.Has(FieldSelected))
+
+	out.Padding = mergeInsets(s.Padding, other.Padding, u.Has(FieldPadding))
+	out.Margin = mergeInsets(s.Margin, other.Margin, u.Has(FieldMargin))
+	out.HoverStyle = mergeNested(s.HoverStyle, other.HoverStyle, u.Has(FieldHoverStyle))
+	out.FocusStyle = mergeNested(s.FocusStyle, other.FocusStyle, u.Has(FieldFocusStyle))
+	out.PressedStyle = mergeNested(s.PressedStyle, other.PressedStyle, u.Has(FieldPressedStyle))
+	out.DisabledStyle = mergeNested(s.DisabledStyle, other.DisabledStyle, u.Has(FieldDisabledStyle))
+	out.SelectedStyle = mergeNested(s.SelectedStyle, other.SelectedStyle, u.Has(FieldSelectedStyle))
+	out.PseudoStates = mergeStates(s.PseudoStates, other.PseudoStates, u.Has(FieldPseudoStates))
+	out.Responsive = mergeStates(s.Responsive, other.Responsive, u.Has(FieldResponsive))
+
+	out.Unset = s.Unset | other.Unset
+	return out
+}
+
+func mergeField[T comparable](dst *T, v T, unset bool) {
+	var zero T
+	switch {
+	case v != zero:
+		*dst = v
+	case unset:
+		*dst = zero
+	}
+}
+
+func mergeInsets(base, other EdgeInsets, unset bool) EdgeInsets {
+	if unset {
+		base = EdgeInsets{}
+	}
+	mergeField(&base.Top, other.Top, false)
+	mergeField(&base.Right, other.Right, false)
+	mergeField(&base.Bottom, other.Bottom, false)
+	mergeField(&base.Left, other.Left, false)
+	mergeField(&base.Horizontal, other.Horizontal, false)
+	mergeField(&base.Vertical, other.Vertical, false)
+	return base
+}
+
+func mergeNested(base, other *Style, unset bool) *Style {
+	if unset {
+		base = nil
+	}
+	switch {
+	case other == nil:
+		return base
+	case base == nil:
+		copied := Style{}.Merge(*other)
+		return &copied
+	}
+	merged := base.Merge(*other)
+	return &merged
+}
+
+func mergeStates[M ~map[string]Style](base, other M, unset bool) M {
+	if unset {
+		base = nil
+	}
+	if len(other) == 0 {
+		return base
+	}
+	out := make(M, len(base)+len(other))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range other {
+		out[k] = out[k].Merge(v)
+	}
+	return out
+}
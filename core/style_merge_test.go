@@ -0,0 +1,310 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+// scalarFields are the Style fields Merge treats as plain values.
+func scalarFields(t *testing.T) []string {
+	t.Helper()
+	var names []string
+	for _, name := range styleFieldNames {
+		f, ok := reflect.TypeOf(Style{}).FieldByName(name)
+		if !ok {
+			t.Fatalf("styleFieldNames lists %q, which Style does not have", name)
+		}
+		switch f.Type.Kind() {
+		case reflect.Struct, reflect.Pointer, reflect.Map:
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// sample returns a non-zero value of the field's type; n tells two samples
+// apart.
+func sample(t *testing.T, typ reflect.Type, n int) reflect.Value {
+	t.Helper()
+	v := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		v.SetString("v" + string(rune('0'+n)))
+	case reflect.Int:
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		v.SetFloat(float64(n) + 0.5)
+	case reflect.Bool:
+		v.SetBool(true)
+	default:
+		t.Fatalf("no sample for %s", typ)
+	}
+	return v
+}
+
+func TestStyleFieldNamesCoverStyle(t *testing.T) {
+	typ := reflect.TypeOf(Style{})
+	if got, want := len(styleFieldNames), typ.NumField()-1; got != want {
+		t.Fatalf("styleFieldNames has %d names, Style has %d mergeable fields", got, want)
+	}
+	for i, name := range styleFieldNames {
+		f, ok := ParseStyleField(name)
+		if !ok || f != 1<<i {
+			t.Errorf("ParseStyleField(%q) = %v, %v; want %v", name, f, ok, StyleField(1<<i))
+		}
+	}
+}
+
+func TestMergeScalarFields(t *testing.T) {
+	for _, name := range scalarFields(t) {
+		t.Run(name, func(t *testing.T) {
+			typ, _ := reflect.TypeOf(Style{}).FieldByName(name)
+
+			var base, other Style
+			reflect.ValueOf(&base).Elem().FieldByName(name).Set(sample(t, typ.Type, 1))
+			reflect.ValueOf(&other).Elem().FieldByName(name).Set(sample(t, typ.Type, 2))
+
+			won := base.Merge(other)
+			if got := reflect.ValueOf(won).FieldByName(name).Interface(); got != reflect.ValueOf(other).FieldByName(name).Interface() {
+				t.Errorf("non-zero value lost: got %v", got)
+			}
+
+			kept := base.Merge(Style{})
+			if got := reflect.ValueOf(kept).FieldByName(name).Interface(); got != reflect.ValueOf(base).FieldByName(name).Interface() {
+				t.Errorf("zero value overwrote the base: got %v", got)
+			}
+
+			field, _ := ParseStyleField(name)
+			cleared := base.Merge(Style{Unset: field})
+			if !reflect.ValueOf(cleared).FieldByName(name).IsZero() {
+				t.Errorf("Unset did not clear the field")
+			}
+		})
+	}
+}
+
+func TestMergeInsets(t *testing.T) {
+	tests := []struct {
+		name        string
+		base, other EdgeInsets
+		unset       bool
+		want        EdgeInsets
+	}{
+		{
+			name:  "per side",
+			base:  EdgeInsets{Top: 1, Right: 2, Bottom: 3, Left: 4},
+			other: EdgeInsets{Right: 20, Left: 40},
+			want:  EdgeInsets{Top: 1, Right: 20, Bottom: 3, Left: 40},
+		},
+		{
+			name:  "horizontal and vertical",
+			base:  EdgeInsets{Horizontal: 8, Vertical: 4},
+			other: EdgeInsets{Vertical: 12},
+			want:  EdgeInsets{Horizontal: 8, Vertical: 12},
+		},
+		{
+			name:  "sides next to axes",
+			base:  EdgeInsets{Horizontal: 8},
+			other: EdgeInsets{Top: 2},
+			want:  EdgeInsets{Top: 2, Horizontal: 8},
+		},
+		{
+			name:  "empty keeps base",
+			base:  EdgeInsets{Top: 1, Horizontal: 2},
+			other: EdgeInsets{},
+			want:  EdgeInsets{Top: 1, Horizontal: 2},
+		},
+		{
+			name:  "unset then other",
+			base:  EdgeInsets{Top: 1, Horizontal: 2},
+			other: EdgeInsets{Left: 3},
+			unset: true,
+			want:  EdgeInsets{Left: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mask StyleField
+			if tt.unset {
+				mask = FieldPadding | FieldMargin
+			}
+			got := Style{Padding: tt.base, Margin: tt.base}.Merge(Style{Padding: tt.other, Margin: tt.other, Unset: mask})
+			if got.Padding != tt.want {
+				t.Errorf("Padding = %+v, want %+v", got.Padding, tt.want)
+			}
+			if got.Margin != tt.want {
+				t.Errorf("Margin = %+v, want %+v", got.Margin, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeNestedStyles(t *testing.T) {
+	nested := []struct {
+		name  string
+		field StyleField
+		get   func(*Style) **Style
+	}{
+		{"HoverStyle", FieldHoverStyle, func(s *Style) **Style { return &s.HoverStyle }},
+		{"FocusStyle", FieldFocusStyle, func(s *Style) **Style { return &s.FocusStyle }},
+		{"PressedStyle", FieldPressedStyle, func(s *Style) **Style { return &s.PressedStyle }},
+		{"DisabledStyle", FieldDisabledStyle, func(s *Style) **Style { return &s.DisabledStyle }},
+		{"SelectedStyle", FieldSelectedStyle, func(s *Style) **Style { return &s.SelectedStyle }},
+	}
+	for _, n := range nested {
+		t.Run(n.name, func(t *testing.T) {
+			var base, other Style
+			*n.get(&base) = &Style{Background: "#fff", TextColor: "#000"}
+			*n.get(&other) = &Style{TextColor: "#f00"}
+
+			got := *n.get(ptr(base.Merge(other)))
+			if got == nil || got.Background != "#fff" || got.TextColor != "#f00" {
+				t.Errorf("merge = %+v, want background from base and color from other", got)
+			}
+			if (*n.get(&base)).TextColor != "#000" {
+				t.Errorf("merge mutated the base")
+			}
+
+			if got := *n.get(ptr(base.Merge(Style{}))); got == nil || got.Background != "#fff" {
+				t.Errorf("nil other dropped the base: %+v", got)
+			}
+
+			fresh := *n.get(ptr(Style{}.Merge(other)))
+			if fresh == nil || fresh.TextColor != "#f00" {
+				t.Errorf("nil base: got %+v", fresh)
+			}
+			if fresh == *n.get(&other) {
+				t.Errorf("nil base: result aliases other")
+			}
+
+			if got := *n.get(ptr(base.Merge(Style{Unset: n.field}))); got != nil {
+				t.Errorf("Unset left %+v", got)
+			}
+		})
+	}
+}
+
+func TestMergeStateMaps(t *testing.T) {
+	base := Style{
+		PseudoStates: map[string]Style{
+			":hover": {Background: "#eee", TextColor: "#000"},
+			":focus": {BorderColor: "#00f"},
+		},
+		Responsive: ResponsiveStyle{
+			"mobile":  {FontSize: 14, Padding: EdgeInsets{Horizontal: 8}},
+			"desktop": {FontSize: 18},
+		},
+	}
+	other := Style{
+		PseudoStates: map[string]Style{
+			":hover":  {TextColor: "#f00"},
+			":active": {Shadow: 1},
+		},
+		Responsive: ResponsiveStyle{
+			"mobile": {FontSize: 12},
+			"tablet": {FontSize: 16},
+		},
+	}
+	got := base.Merge(other)
+
+	wantStates := map[string]Style{
+		":hover":  {Background: "#eee", TextColor: "#f00"},
+		":focus":  {BorderColor: "#00f"},
+		":active": {Shadow: 1},
+	}
+	if !reflect.DeepEqual(got.PseudoStates, wantStates) {
+		t.Errorf("PseudoStates = %+v, want %+v", got.PseudoStates, wantStates)
+	}
+	wantResponsive := ResponsiveStyle{
+		"mobile":  {FontSize: 12, Padding: EdgeInsets{Horizontal: 8}},
+		"tablet":  {FontSize: 16},
+		"desktop": {FontSize: 18},
+	}
+	if !reflect.DeepEqual(got.Responsive, wantResponsive) {
+		t.Errorf("Responsive = %+v, want %+v", got.Responsive, wantResponsive)
+	}
+	if len(base.PseudoStates) != 2 || base.PseudoStates[":hover"].TextColor != "#000" {
+		t.Errorf("merge mutated the base maps: %+v", base.PseudoStates)
+	}
+
+	cleared := base.Merge(Style{Unset: FieldPseudoStates | FieldResponsive})
+	if cleared.PseudoStates != nil || cleared.Responsive != nil {
+		t.Errorf("Unset left %+v and %+v", cleared.PseudoStates, cleared.Responsive)
+	}
+
+	replaced := base.Merge(Style{Unset: FieldResponsive, Responsive: ResponsiveStyle{"tablet": {FontSize: 16}}})
+	if want := (ResponsiveStyle{"tablet": {FontSize: 16}}); !reflect.DeepEqual(replaced.Responsive, want) {
+		t.Errorf("Unset and set: Responsive = %+v, want %+v", replaced.Responsive, want)
+	}
+}
+
+func TestUnsetMask(t *testing.T) {
+	base := Style{
+		Background:   "#fff",
+		Shadow:       2,
+		BorderWidth:  1,
+		FontSize:     16,
+		Padding:      EdgeInsets{Top: 4, Horizontal: 8},
+		HoverStyle:   &Style{Background: "#eee"},
+		PseudoStates: map[string]Style{":hover": {Background: "#eee"}},
+		Responsive:   ResponsiveStyle{"mobile": {FontSize: 14}},
+	}
+	mask := FieldShadow | FieldBorderWidth | FieldPadding | FieldHoverStyle | FieldPseudoStates | FieldResponsive
+
+	var s Style
+	Unset(FieldShadow, FieldBorderWidth, FieldPadding, FieldHoverStyle, FieldPseudoStates, FieldResponsive).Apply(&s)
+	if s.Unset != mask {
+		t.Fatalf("Unset(...) mask = %v, want %v", s.Unset, mask)
+	}
+
+	got := base.Merge(s)
+	want := Style{Background: "#fff", FontSize: 16, Unset: mask}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge = %+v, want %+v", got, want)
+	}
+
+	// the mask travels with the result, so it clears a later base too
+	if again := base.Merge(got); again.Shadow != 0 || again.HoverStyle != nil {
+		t.Errorf("mask lost after one merge: %+v", again)
+	}
+}
+
+func TestStyleFieldNamesRoundTrip(t *testing.T) {
+	tests := []struct {
+		mask  StyleField
+		names []string
+	}{
+		{0, nil},
+		{FieldShadow, []string{"Shadow"}},
+		{FieldFontSize | FieldSelected, []string{"FontSize", "Selected"}},
+		{FieldShadow | FieldBorderWidth | FieldResponsive, []string{"Shadow", "BorderWidth", "Responsive"}},
+	}
+	for _, tt := range tests {
+		if got := tt.mask.Names(); !reflect.DeepEqual(got, tt.names) {
+			t.Errorf("%v.Names() = %v, want %v", tt.mask, got, tt.names)
+		}
+		var parsed StyleField
+		for _, name := range tt.names {
+			f, ok := ParseStyleField(name)
+			if !ok {
+				t.Fatalf("ParseStyleField(%q) failed", name)
+			}
+			parsed |= f
+		}
+		if parsed != tt.mask {
+			t.Errorf("round trip of %v gave %v", tt.mask, parsed)
+		}
+	}
+
+	for name, want := range map[string]StyleField{"shadow": FieldShadow, "borderWidth": FieldBorderWidth, "BORDERWIDTH": FieldBorderWidth} {
+		if f, ok := ParseStyleField(name); !ok || f != want {
+			t.Errorf("ParseStyleField(%q) = %v, %v; want %v", name, f, ok, want)
+		}
+	}
+	if _, ok := ParseStyleField("unset"); ok {
+		t.Errorf("ParseStyleField accepted a name that is not a field")
+	}
+}
+
+func ptr(s Style) *Style { return &s }
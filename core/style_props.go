@@ -58,14 +58,6 @@ func Overflow(value string) StyleProp {
 		s.Overflow = value // "hidden", "scroll", "visible"
 	})
 }
-func Responsive(breakpoint string, style Style) StyleProp {
-	return styleFunc(func(s *Style) {
-		if s.PseudoStates == nil {
-			s.PseudoStates = make(map[string]Style)
-		}
-		s.PseudoStates[breakpoint] = style
-	})
-}
 func FontSize(size float64) StyleProp {
 	return styleFunc(func(s *Style) {
 		s.FontSize = size
@@ -212,7 +204,51 @@ func PaddingVertical(px int) StyleProp {
 }
 
 func (s Style) With(other Style) Style {
-	merged := s
-	UseStyle(other).Apply(&merged)
-	return merged
+	return s.Merge(other)
+}
+
+func FontFamily(family string) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.FontFamily = family
+	})
+}
+
+func Italic() StyleProp {
+	return styleFunc(func(s *Style) {
+		s.FontStyle = FontItalic
+	})
+}
+
+func LetterSpacing(px float64) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.LetterSpacing = px
+	})
+}
+
+func TextAlign(a TextAlignment) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.TextAlign = a
+	})
+}
+
+func Decoration(d TextDecoration) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.TextDecoration = d
+	})
+}
+
+func Transform(t TextTransform) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.TextTransform = t
+	})
+}
+
+// Variant picks a named style from the theme: a typography role for Text,
+// e.g. Text("Hi", Variant(TextHeadline)), or a component variant such as
+// Button("Cancel", fn, Variant(ButtonOutline)). Inline props still win over
+// the variant.
+func Variant(name string) StyleProp {
+	return styleFunc(func(s *Style) {
+		s.Variant = name
+	})
 }
@@ -1,5 +1,66 @@
 package core
 
+import "sync"
+
+type SystemEvent struct {
+	Name string         `json:"name"`
+	Data map[string]any `json:"data"`
+}
+
+var (
+	sysEventMux     sync.Mutex
+	sysEventHandler func(name string, data map[string]any)
+	pendingEvents   []SystemEvent
+)
+
+// SetSystemEventHandler installs the native channel used to deliver system
+// events (toasts, camera commands...). Without a handler events are queued
+// until the host drains them with DrainSystemEvents.
+func SetSystemEventHandler(fn func(name string, data map[string]any)) {
+	sysEventMux.Lock()
+	sysEventHandler = fn
+	sysEventMux.Unlock()
+}
+
 func SendSystemEvent(name string, data map[string]any) {
-	// send to a native chanel
+	sysEventMux.Lock()
+	handler := sysEventHandler
+	if handler == nil {
+		pendingEvents = append(pendingEvents, SystemEvent{Name: name, Data: data})
+	}
+	sysEventMux.Unlock()
+
+	if handler != nil {
+		handler(name, data)
+	}
+}
+
+func DrainSystemEvents() []SystemEvent {
+	sysEventMux.Lock()
+	defer sysEventMux.Unlock()
+
+	out := pendingEvents
+	pendingEvents = nil
+	return out
+}
+
+var systemListeners = map[string][]func(map[string]any){}
+
+// OnSystemEvent subscribes to events reported by the host (appearance,
+// window size...). Hosts deliver them through ReceiveSystemEvent and then
+// re-render.
+func OnSystemEvent(name string, fn func(data map[string]any)) {
+	sysEventMux.Lock()
+	systemListeners[name] = append(systemListeners[name], fn)
+	sysEventMux.Unlock()
+}
+
+func ReceiveSystemEvent(name string, data map[string]any) {
+	sysEventMux.Lock()
+	listeners := append([]func(map[string]any){}, systemListeners[name]...)
+	sysEventMux.Unlock()
+
+	for _, fn := range listeners {
+		fn(data)
+	}
 }
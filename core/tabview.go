@@ -7,6 +7,7 @@ type TabViewNode struct {
 	OnTabChange   func(int)
 	Tabs          []TabItem
 	Content       []View
+	Style         []StyleProp
 }
 
 type TabItem struct {
@@ -43,9 +44,13 @@ func TabView(props ...TabViewProp) View {
 			propMap["onTabChange"] = registerIntCallback(node.OnTabChange)
 		}
 
+		style := themedStyle(ctx, "tabview", ctx.Theme().Components.TabView, node.Style)
+		resolveStyle(ctx, style)
+
 		return &Node{
 			Type:     "TabView",
 			Props:    propMap,
+			Style:    style,
 			Children: renderAll(ctx, node.Content),
 		}
 	})
@@ -79,6 +84,14 @@ func Content(views ...View) TabViewProp {
 	})
 }
 
+// TabViewStyle styles the tab bar; the theme's Components.TabView is the
+// starting point.
+func TabViewStyle(props ...StyleProp) TabViewProp {
+	return tabViewFunc(func(t *TabViewNode) {
+		t.Style = append(t.Style, props...)
+	})
+}
+
 func Tab(label string, icon string) TabItem {
 	return TabItem{Label: label, Icon: icon}
 }
@@ -100,9 +113,10 @@ func registerIntCallback(fn func(int)) string {
 
 func TriggerIntCallback(id string, val int) {
 	callbackMux.Lock()
-	defer callbackMux.Unlock()
+	fn, ok := intCallbacks[id]
+	callbackMux.Unlock()
 
-	if fn, ok := intCallbacks[id]; ok {
+	if ok {
 		fn(val)
 	}
 }
@@ -2,12 +2,21 @@ package core
 
 func Text(content string, styleProps ...StyleProp) View {
 	return ComponentFunc(func(ctx *Context) *Node {
+		base := ctx.Theme().Components.Text
+		style := themedStyle(ctx, "text", base, styleProps)
 
-		style := &Style{}
-		for _, sp := range styleProps {
-			sp.Apply(style)
+		// A typography role is layered on the Text default like a component
+		// variant; inline props are replayed on top so they keep precedence.
+		if role, ok := ctx.Theme().Typography.Role(style.Variant); ok {
+			merged := base.With(role)
+			style = &merged
+			for _, sp := range styleProps {
+				sp.Apply(style)
+			}
 		}
 
+		resolveStyle(ctx, style)
+
 		return &Node{
 			Type:  "Text",
 			Props: map[string]any{"content": content},
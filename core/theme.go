@@ -5,6 +5,16 @@ type Theme struct {
 	Typography Typography
 	Spacing    SpacingScale
 	Components ComponentDefaults
+	Fonts      []FontFace
+}
+
+// FontFace registers one face of a font family. Source is usually an asset
+// reference, e.g. Asset("fonts/Inter-Bold.woff2").
+type FontFace struct {
+	Family string
+	Source string
+	Weight Weight
+	Style  FontStyle
 }
 
 type ColorPalette struct {
@@ -18,10 +28,48 @@ type ColorPalette struct {
 }
 
 type Typography struct {
+	Display  Style
+	Headline Style
 	Title    Style
 	Subtitle Style
 	Body     Style
+	Label    Style
 	Caption  Style
+	Overline Style
+}
+
+// Typography roles, used with Variant.
+const (
+	TextDisplay  = "display"
+	TextHeadline = "headline"
+	TextTitle    = "title"
+	TextSubtitle = "subtitle"
+	TextBody     = "body"
+	TextLabel    = "label"
+	TextCaption  = "caption"
+	TextOverline = "overline"
+)
+
+func (t Typography) Role(name string) (Style, bool) {
+	switch name {
+	case TextDisplay:
+		return t.Display, true
+	case TextHeadline:
+		return t.Headline, true
+	case TextTitle:
+		return t.Title, true
+	case TextSubtitle:
+		return t.Subtitle, true
+	case TextBody:
+		return t.Body, true
+	case TextLabel:
+		return t.Label, true
+	case TextCaption:
+		return t.Caption, true
+	case TextOverline:
+		return t.Overline, true
+	}
+	return Style{}, false
 }
 
 type SpacingScale struct {
@@ -35,26 +83,116 @@ type ComponentDefaults struct {
 	Column   Style
 	Row      Style
 	Camera   Style
+	Image    Style
 	CheckBox Style
 	TextArea Style
 	Text     Style
+	Modal    Style
+	TabView  Style
+	Spacer   Style
+	Scroll   Style
+	Box      Style
+
+	// Selection controls (core/selection.go). Segment styles each option of
+	// a SegmentedControl; Segmented is the track around them.
+	Switch    Style
+	Radio     Style
+	Slider    Style
+	Stepper   Style
+	Segmented Style
+	Segment   Style
+
+	// Feedback components (core/feedback.go). Progress is a ProgressBar's
+	// track, its fill takes the track's TextColor; Spinner and Skeleton
+	// animate through Animation with keyframes the renderers ship.
+	Snackbar Style
+	Badge    Style
+	Avatar   Style
+	Progress Style
+	Spinner  Style
+	Skeleton Style
+
+	// Variants holds named alternatives per component, keyed by component
+	// ("button", "card", ...) and then by variant name. A variant only lists
+	// what differs from the component's default and is selected with Variant.
+	Variants map[string]map[string]Style
+}
+
+// Component variants shipped with the built-in themes.
+const (
+	ButtonPrimary   = "primary"
+	ButtonSecondary = "secondary"
+	ButtonOutline   = "outline"
+	ButtonDanger    = "danger"
+	ButtonTextOnly  = "text"
+
+	CardElevated = "elevated"
+	CardOutlined = "outlined"
+)
+
+func (c ComponentDefaults) Variant(component, name string) (Style, bool) {
+	v, ok := c.Variants[component][name]
+	return v, ok
+}
+
+// themedStyle builds a component style from the theme default, the variant
+// picked with Variant(...) and the inline props, in increasing precedence.
+func themedStyle(ctx *Context, component string, base Style, props []StyleProp) *Style {
+	style := base
+	for _, sp := range props {
+		sp.Apply(&style)
+	}
+	if v, ok := ctx.Theme().Components.Variant(component, style.Variant); ok {
+		style = base.With(v)
+		for _, sp := range props {
+			sp.Apply(&style)
+		}
+	}
+	return &style
 }
 
+// WithTheme renders children with theme. They render in a child context
+// kept in a hook slot, so their own hooks keep their state across renders.
 func WithTheme(theme *Theme, children ...View) View {
 	return ComponentFunc(func(ctx *Context) *Node {
-		newCtx := ctx.WithTheme(theme)
+		newCtx := resetChild(UseChildContext(ctx), ctx)
+		newCtx.theme = theme
 		var rendered []*Node
 		for _, child := range children {
 			rendered = append(rendered, child.Render(newCtx))
 		}
+		props := map[string]any{}
+		if len(theme.Fonts) > 0 {
+			props["fonts"] = fontProps(theme.Fonts)
+		}
 		return &Node{
 			Type:     "Theme",
-			Props:    map[string]any{},
+			Props:    props,
 			Children: rendered,
 		}
 	})
 }
 
+func fontProps(faces []FontFace) []map[string]any {
+	out := make([]map[string]any, 0, len(faces))
+	for _, f := range faces {
+		weight, style := f.Weight, f.Style
+		if weight == 0 {
+			weight = Normal
+		}
+		if style == "" {
+			style = FontNormal
+		}
+		out = append(out, map[string]any{
+			"family": f.Family,
+			"src":    ResolveAsset(f.Source),
+			"weight": int(weight),
+			"style":  string(style),
+		})
+	}
+	return out
+}
+
 var DefaultTheme = &Theme{
 	Colors: ColorPalette{
 		Primary:       "#007AFF",   // iOS system blue
@@ -66,30 +204,55 @@ var DefaultTheme = &Theme{
 		Error:         "#FF3B30",   // iOS system red
 	},
 	Typography: Typography{
+		Display: Style{
+			FontSize:   34,
+			FontWeight: Bold,
+			TextColor:  Token("colors.textPrimary"),
+			Display:    DisplayBlock,
+		},
+		Headline: Style{
+			FontSize:   17,
+			FontWeight: SemiBold,
+			TextColor:  Token("colors.textPrimary"),
+			Display:    DisplayBlock,
+		},
 		Title: Style{
 			FontSize:   28,
 			FontWeight: Bold,
-			TextColor:  "#000000",
+			TextColor:  Token("colors.textPrimary"),
 			Display:    DisplayBlock,
 		},
 		Subtitle: Style{
 			FontSize:   22,
 			FontWeight: Normal,
-			TextColor:  "#3C3C4399",
+			TextColor:  Token("colors.textSecondary"),
 			Display:    DisplayBlock,
 		},
 		Body: Style{
 			FontSize:   17,
 			FontWeight: Normal,
-			TextColor:  "#000000",
+			TextColor:  Token("colors.textPrimary"),
 			Display:    DisplayBlock,
 		},
+		Label: Style{
+			FontSize:   15,
+			FontWeight: Medium,
+			TextColor:  Token("colors.textPrimary"),
+		},
 		Caption: Style{
 			FontSize:   13,
 			FontWeight: Normal,
-			TextColor:  "#3C3C4399",
+			TextColor:  Token("colors.textSecondary"),
 			Display:    DisplayBlock,
 		},
+		Overline: Style{
+			FontSize:      11,
+			FontWeight:    Medium,
+			TextColor:     Token("colors.textSecondary"),
+			LetterSpacing: 0.5,
+			TextTransform: TransformUppercase,
+			Display:       DisplayBlock,
+		},
 	},
 	Spacing: SpacingScale{
 		XS: 4,
@@ -103,15 +266,19 @@ var DefaultTheme = &Theme{
 			FontSize:     17,
 			FontWeight:   Normal,
 			TextColor:    "#FFFFFF",
-			Background:   "#007AFF",
+			Background:   Token("colors.primary"),
 			Padding:      EdgeInsets{Top: 10, Bottom: 10, Left: 16, Right: 16},
 			BorderRadius: 8,
 			Shadow:       1,
 			Align:        AlignCenter,
 			Display:      DisplayInline,
+			DisabledStyle: &Style{
+				Background: "#D1D1D6",
+				TextColor:  "#8E8E93",
+			},
 		},
 		Card: Style{
-			Background:   "#FFFFFF",
+			Background:   Token("colors.background"),
 			Padding:      EdgeInsets{Top: 16, Bottom: 16, Left: 16, Right: 16},
 			Margin:       EdgeInsets{Top: 8, Bottom: 8, Left: 8, Right: 8},
 			BorderRadius: 12,
@@ -121,15 +288,20 @@ var DefaultTheme = &Theme{
 		Input: Style{
 			FontSize:     17,
 			FontWeight:   Normal,
-			TextColor:    "#000000",
-			Background:   "#FFFFFF",
+			TextColor:    Token("colors.textPrimary"),
+			Background:   Token("colors.background"),
 			Padding:      EdgeInsets{Top: 8, Bottom: 8, Left: 12, Right: 12},
 			BorderRadius: 6,
 			Shadow:       0,
 			Display:      DisplayBlock,
+			FocusStyle:   &Style{BorderColor: Token("colors.primary"), BorderWidth: 1},
+			DisabledStyle: &Style{
+				Background: Token("colors.surface"),
+				TextColor:  Token("colors.textSecondary"),
+			},
 		},
 		CheckBox: Style{
-			Background:   "#FFFFFF",
+			Background:   Token("colors.background"),
 			BorderRadius: 6,
 			Shadow:       0,
 			Display:      DisplayInline,
@@ -137,8 +309,8 @@ var DefaultTheme = &Theme{
 		TextArea: Style{
 			FontSize:     17,
 			FontWeight:   Normal,
-			TextColor:    "#000000",
-			Background:   "#FFFFFF",
+			TextColor:    Token("colors.textPrimary"),
+			Background:   Token("colors.background"),
 			Padding:      EdgeInsets{Top: 12, Bottom: 12, Left: 12, Right: 12},
 			BorderRadius: 6,
 			Display:      DisplayBlock,
@@ -154,14 +326,155 @@ var DefaultTheme = &Theme{
 			Background: "#000000",
 			Display:    DisplayBlock,
 		},
+		Image: Style{
+			Display:  DisplayBlock,
+			MaxWidth: "100%",
+		},
 		Text: Style{
-			FontSize:     17,
-			FontWeight:   Normal,
-			TextColor:    "#000000",
-			Background:   "#FFFFFF",
-			Padding:      EdgeInsets{Top: 12, Bottom: 12, Left: 12, Right: 12},
+			FontSize:   17,
+			FontWeight: Normal,
+			TextColor:  Token("colors.textPrimary"),
+		},
+		Modal: Style{
+			Background:   Token("colors.background"),
+			Padding:      EdgeInsets{Top: 24, Bottom: 24, Left: 24, Right: 24},
+			BorderRadius: 14,
+			Shadow:       3,
+			MaxWidth:     "90%",
+		},
+		TabView: Style{
+			Background: Token("colors.surface"),
+			TextColor:  Token("colors.textSecondary"),
+			FontSize:   10,
+			FontWeight: Medium,
+		},
+		Spacer: Style{},
+		Scroll: Style{
+			Overflow: "auto",
+			FlexGrow: 1,
+		},
+		Box: Style{},
+		Switch: Style{
+			Background:    "#78788029",
+			TextColor:     "#FFFFFF",
+			Width:         "51px",
+			Height:        "31px",
+			BorderRadius:  16,
+			SelectedStyle: &Style{Background: Token("colors.secondary")},
+		},
+		Radio: Style{
+			FontSize:      17,
+			TextColor:     Token("colors.textPrimary"),
+			Padding:       EdgeInsets{Top: 8, Bottom: 8},
+			Display:       DisplayBlock,
+			SelectedStyle: &Style{TextColor: Token("colors.primary")},
+		},
+		Slider: Style{
+			TextColor: Token("colors.primary"),
+			Width:     "100%",
+			Display:   DisplayBlock,
+		},
+		Stepper: Style{
+			Display:    DisplayFlex,
+			AlignItems: AlignItemsCenter,
+			Gap:        12,
+		},
+		Segmented: Style{
+			Background:   "#7676801F",
+			Padding:      EdgeInsets{Top: 2, Bottom: 2, Left: 2, Right: 2},
+			BorderRadius: 9,
+			Display:      DisplayFlex,
+		},
+		Segment: Style{
+			FontSize:      13,
+			FontWeight:    Medium,
+			TextColor:     Token("colors.textPrimary"),
+			Background:    "transparent",
+			Padding:       EdgeInsets{Top: 6, Bottom: 6, Left: 12, Right: 12},
+			BorderRadius:  7,
+			FlexGrow:      1,
+			SelectedStyle: &Style{Background: Token("colors.background"), Shadow: 1},
+		},
+		Snackbar: Style{
+			FontSize:       15,
+			TextColor:      "#FFFFFF",
+			Background:     "#323232F2",
+			Padding:        EdgeInsets{Top: 6, Bottom: 6, Left: 16, Right: 8},
+			BorderRadius:   12,
+			Shadow:         3,
+			Display:        DisplayFlex,
+			AlignItems:     AlignItemsCenter,
+			JustifyContent: JustifyBetween,
+			Gap:            8,
+			MinHeight:      "48px",
+			Position:       PositionFixed,
+			Left:           "16px",
+			Right:          "16px",
+			Bottom:         "16px",
+			ZIndex:         1000,
+		},
+		Badge: Style{
+			FontSize:     12,
+			FontWeight:   SemiBold,
+			TextColor:    "#FFFFFF",
+			Background:   Token("colors.error"),
+			Padding:      EdgeInsets{Left: 5, Right: 5},
+			BorderRadius: 9,
+			MinWidth:     "18px",
+			Height:       "18px",
+			LineHeight:   18,
+			TextAlign:    TextAlignCenter,
+			Position:     PositionAbsolute,
+			Top:          "-6px",
+			Right:        "-6px",
+		},
+		Avatar: Style{
+			Width:          "40px",
+			Height:         "40px",
+			BorderRadius:   20,
+			FontSize:       16,
+			FontWeight:     SemiBold,
+			TextColor:      "#FFFFFF",
+			Background:     "#8E8E93",
+			Display:        DisplayFlex,
+			AlignItems:     AlignItemsCenter,
+			JustifyContent: JustifyCenter,
+			Position:       PositionRelative,
+		},
+		Progress: Style{
+			TextColor:    Token("colors.primary"),
+			Background:   "#78788029",
+			Height:       "4px",
+			BorderRadius: 2,
+			Width:        "100%",
+			Overflow:     "hidden",
+			Position:     PositionRelative,
+		},
+		Spinner: Style{
+			TextColor: Token("colors.textSecondary"),
+			Width:     "20px",
+			Height:    "20px",
+			Animation: "gv-spin 0.8s linear infinite",
+		},
+		Skeleton: Style{
+			Background:   "#7878801F",
 			BorderRadius: 6,
-			Display:      DisplayBlock,
+			Height:       "16px",
+			Width:        "100%",
+			Animation:    "gv-pulse 1.5s ease-in-out infinite",
+		},
+		Variants: map[string]map[string]Style{
+			"button": {
+				ButtonPrimary:   {Background: Token("colors.primary")},
+				ButtonSecondary: {Background: Token("colors.secondary"), TextColor: "#FFFFFF"},
+				ButtonOutline:   {Background: "transparent", TextColor: Token("colors.primary"), BorderColor: Token("colors.primary"), BorderWidth: 1, Unset: FieldShadow},
+				ButtonDanger:    {Background: Token("colors.error"), TextColor: "#FFFFFF"},
+				ButtonTextOnly:  {Background: "transparent", TextColor: Token("colors.primary"), Padding: EdgeInsets{Top: 6, Bottom: 6, Left: 8, Right: 8}, Unset: FieldShadow},
+			},
+			"card": {
+				CardElevated: {Shadow: 3},
+				CardOutlined: {BorderColor: "#C6C6C8", BorderWidth: 1, Unset: FieldShadow},
+			},
 		},
 	},
 }
@@ -177,10 +490,14 @@ var MaterialTheme = &Theme{
 		Error:         "#B00020",
 	},
 	Typography: Typography{
-		Title:    Style{FontSize: 22, FontWeight: Bold, TextColor: "#212121"},
+		Display:  Style{FontSize: 36, FontWeight: Normal, TextColor: Token("colors.textPrimary")},
+		Headline: Style{FontSize: 24, FontWeight: Normal, TextColor: Token("colors.textPrimary")},
+		Title:    Style{FontSize: 22, FontWeight: Bold, TextColor: Token("colors.textPrimary")},
 		Subtitle: Style{FontSize: 18, FontWeight: Normal, TextColor: "#424242"},
 		Body:     Style{FontSize: 14, FontWeight: Normal, TextColor: "#333333"},
+		Label:    Style{FontSize: 14, FontWeight: Medium, TextColor: Token("colors.textPrimary"), LetterSpacing: 0.1},
 		Caption:  Style{FontSize: 12, FontWeight: Light, TextColor: "#888888"},
+		Overline: Style{FontSize: 10, FontWeight: Medium, TextColor: Token("colors.textSecondary"), LetterSpacing: 1.5, TextTransform: TransformUppercase},
 	},
 	Spacing: SpacingScale{
 		XS: 4,
@@ -191,13 +508,18 @@ var MaterialTheme = &Theme{
 	},
 	Components: ComponentDefaults{
 		Button: Style{
-			Background:   "#6200EE",
+			Background:   Token("colors.primary"),
 			TextColor:    "#FFFFFF",
 			Padding:      EdgeInsets{Top: 10, Bottom: 10, Left: 20, Right: 20},
 			BorderRadius: 4,
+			PressedStyle: &Style{Shadow: 4},
+			DisabledStyle: &Style{
+				Background: "#1F1F1F1F",
+				TextColor:  "#00000061",
+			},
 		},
 		Card: Style{
-			Background:   "#FFFFFF",
+			Background:   Token("colors.background"),
 			BorderRadius: 8,
 			Shadow:       1,
 			Padding:      EdgeInsets{Top: 16, Bottom: 16, Left: 16, Right: 16},
@@ -205,6 +527,10 @@ var MaterialTheme = &Theme{
 		Input: Style{
 			Background: "#FAFAFA",
 			Padding:    EdgeInsets{Top: 10, Bottom: 10, Left: 12, Right: 12},
+			FocusStyle: &Style{BorderColor: Token("colors.primary"), BorderWidth: 2},
+			DisabledStyle: &Style{
+				TextColor: "#00000061",
+			},
 		},
 		Column: Style{
 			Padding: EdgeInsets{Top: 12, Bottom: 12, Left: 16, Right: 16},
@@ -216,19 +542,208 @@ var MaterialTheme = &Theme{
 			Background: "#000000",
 			Display:    DisplayBlock,
 		},
+		Image: Style{
+			Display:  DisplayBlock,
+			MaxWidth: "100%",
+		},
 
 		CheckBox: Style{
 			Display:      DisplayInline,
 			Margin:       EdgeInsets{Right: 8},
-			TextColor:    "#212121",
+			TextColor:    Token("colors.textPrimary"),
 			BorderRadius: 2,
 		},
 
 		TextArea: Style{
 			Background:   "#FAFAFA",
-			TextColor:    "#212121",
+			TextColor:    Token("colors.textPrimary"),
 			Padding:      EdgeInsets{Top: 8, Bottom: 8, Left: 12, Right: 12},
 			BorderRadius: 4,
 		},
+		Text: Style{
+			FontSize:   14,
+			FontWeight: Normal,
+			TextColor:  Token("colors.textPrimary"),
+		},
+		Modal: Style{
+			Background:   Token("colors.background"),
+			Padding:      EdgeInsets{Top: 24, Bottom: 24, Left: 24, Right: 24},
+			BorderRadius: 4,
+			Shadow:       4,
+			MaxWidth:     "90%",
+		},
+		TabView: Style{
+			Background: Token("colors.background"),
+			TextColor:  Token("colors.textSecondary"),
+			FontSize:   12,
+			FontWeight: Medium,
+			Shadow:     1,
+		},
+		Spacer: Style{},
+		Scroll: Style{
+			Overflow: "auto",
+			FlexGrow: 1,
+		},
+		Box: Style{},
+		Switch: Style{
+			Background:    "#9E9E9E80",
+			TextColor:     "#FAFAFA",
+			Width:         "36px",
+			Height:        "20px",
+			BorderRadius:  10,
+			SelectedStyle: &Style{Background: Token("colors.primary")},
+		},
+		Radio: Style{
+			FontSize:      14,
+			TextColor:     Token("colors.textPrimary"),
+			Padding:       EdgeInsets{Top: 12, Bottom: 12},
+			Display:       DisplayBlock,
+			SelectedStyle: &Style{TextColor: Token("colors.primary")},
+		},
+		Slider: Style{
+			TextColor: Token("colors.primary"),
+			Width:     "100%",
+			Display:   DisplayBlock,
+		},
+		Stepper: Style{
+			Display:    DisplayFlex,
+			AlignItems: AlignItemsCenter,
+			Gap:        8,
+		},
+		Segmented: Style{
+			BorderColor:  "#0000001F",
+			BorderWidth:  1,
+			BorderRadius: 4,
+			Display:      DisplayFlex,
+		},
+		Segment: Style{
+			FontSize:      14,
+			FontWeight:    Medium,
+			TextColor:     Token("colors.textPrimary"),
+			Background:    "transparent",
+			Padding:       EdgeInsets{Top: 8, Bottom: 8, Left: 16, Right: 16},
+			FlexGrow:      1,
+			SelectedStyle: &Style{Background: "#6200EE1F", TextColor: Token("colors.primary")},
+		},
+		Snackbar: Style{
+			FontSize:       14,
+			TextColor:      "#FFFFFFDE",
+			Background:     "#323232",
+			Padding:        EdgeInsets{Top: 6, Bottom: 6, Left: 16, Right: 8},
+			BorderRadius:   4,
+			Shadow:         4,
+			Display:        DisplayFlex,
+			AlignItems:     AlignItemsCenter,
+			JustifyContent: JustifyBetween,
+			Gap:            8,
+			MinHeight:      "48px",
+			Position:       PositionFixed,
+			Left:           "8px",
+			Right:          "8px",
+			Bottom:         "8px",
+			ZIndex:         1000,
+		},
+		Badge: Style{
+			FontSize:     11,
+			FontWeight:   Medium,
+			TextColor:    "#FFFFFF",
+			Background:   Token("colors.error"),
+			Padding:      EdgeInsets{Left: 4, Right: 4},
+			BorderRadius: 8,
+			MinWidth:     "16px",
+			Height:       "16px",
+			LineHeight:   16,
+			TextAlign:    TextAlignCenter,
+			Position:     PositionAbsolute,
+			Top:          "-4px",
+			Right:        "-4px",
+		},
+		Avatar: Style{
+			Width:          "40px",
+			Height:         "40px",
+			BorderRadius:   20,
+			FontSize:       16,
+			FontWeight:     Medium,
+			TextColor:      "#FFFFFF",
+			Background:     Token("colors.primary"),
+			Display:        DisplayFlex,
+			AlignItems:     AlignItemsCenter,
+			JustifyContent: JustifyCenter,
+			Position:       PositionRelative,
+		},
+		Progress: Style{
+			TextColor:  Token("colors.primary"),
+			Background: "#6200EE3D",
+			Height:     "4px",
+			Width:      "100%",
+			Overflow:   "hidden",
+			Position:   PositionRelative,
+		},
+		Spinner: Style{
+			TextColor: Token("colors.primary"),
+			Width:     "40px",
+			Height:    "40px",
+			Animation: "gv-spin 1.4s linear infinite",
+		},
+		Skeleton: Style{
+			Background:   "#0000001F",
+			BorderRadius: 4,
+			Height:       "16px",
+			Width:        "100%",
+			Animation:    "gv-pulse 1.5s ease-in-out infinite",
+		},
+		Variants: map[string]map[string]Style{
+			"button": {
+				ButtonPrimary:   {Background: Token("colors.primary")},
+				ButtonSecondary: {Background: Token("colors.secondary"), TextColor: "#000000"},
+				ButtonOutline:   {Background: "transparent", TextColor: Token("colors.primary"), BorderColor: "#0000001F", BorderWidth: 1, Unset: FieldShadow},
+				ButtonDanger:    {Background: Token("colors.error"), TextColor: "#FFFFFF"},
+				ButtonTextOnly:  {Background: "transparent", TextColor: Token("colors.primary"), Padding: EdgeInsets{Top: 6, Bottom: 6, Left: 8, Right: 8}, Unset: FieldShadow},
+			},
+			"card": {
+				CardElevated: {Shadow: 4},
+				CardOutlined: {BorderColor: "#0000001F", BorderWidth: 1, Unset: FieldShadow},
+			},
+		},
 	},
 }
+
+var DefaultDarkTheme = DefaultTheme.WithPalette(ColorPalette{
+	Primary:       "#0A84FF",   // iOS system blue (dark)
+	Secondary:     "#30D158",   // iOS system green (dark)
+	Background:    "#000000",   // black
+	Surface:       "#1C1C1E",   // elevated gray
+	TextPrimary:   "#FFFFFF",   // white
+	TextSecondary: "#EBEBF599", // secondary label (dark)
+	Error:         "#FF453A",   // iOS system red (dark)
+})
+
+var MaterialDarkTheme = materialDark()
+
+func materialDark() *Theme {
+	t := MaterialTheme.WithPalette(ColorPalette{
+		Primary:       "#BB86FC",
+		Secondary:     "#03DAC6",
+		Background:    "#121212",
+		Surface:       "#1E1E1E",
+		TextPrimary:   "#E1E1E1",
+		TextSecondary: "#A0A0A0",
+		Error:         "#CF6679",
+	})
+	t.Typography.Subtitle.TextColor = Token("colors.textPrimary")
+	t.Typography.Body.TextColor = Token("colors.textPrimary")
+	t.Typography.Caption.TextColor = Token("colors.textSecondary")
+	t.Components.Button.TextColor = "#000000"
+	t.Components.Input.Background = Token("colors.surface")
+	t.Components.TextArea.Background = Token("colors.surface")
+	t.Components.Button.DisabledStyle = &Style{Background: "#FFFFFF1F", TextColor: "#FFFFFF61"}
+	t.Components.Input.DisabledStyle = &Style{TextColor: "#FFFFFF61"}
+	t.Components.Progress.Background = "#BB86FC3D"
+	t.Components.Skeleton.Background = "#FFFFFF1F"
+	return t
+}
+
+var (
+	DefaultThemes  = ThemePair{Light: DefaultTheme, Dark: DefaultDarkTheme}
+	MaterialThemes = ThemePair{Light: MaterialTheme, Dark: MaterialDarkTheme}
+)
@@ -0,0 +1,159 @@
+package core
+
+import "strings"
+
+const tokenPrefix = "$"
+
+// Token references a theme value by path, e.g. TextColor(Token("colors.primary")).
+// It is resolved against the nearest theme while rendering, so switching
+// themes repaints without touching component code.
+func Token(path string) string {
+	return tokenPrefix + path
+}
+
+func IsToken(v string) bool {
+	return strings.HasPrefix(v, tokenPrefix)
+}
+
+// Spacing tokens. They are reserved sentinel values accepted wherever a
+// spacing number is (Padding, Margin, Gap...) and are replaced by the theme's
+// SpacingScale during render.
+const (
+	spaceToken = -1 << 20
+
+	SpaceXS = spaceToken - 1
+	SpaceSM = spaceToken - 2
+	SpaceMD = spaceToken - 3
+	SpaceLG = spaceToken - 4
+	SpaceXL = spaceToken - 5
+)
+
+func (t *Theme) Token(path string) (string, bool) {
+	group, name, ok := strings.Cut(strings.TrimPrefix(path, tokenPrefix), ".")
+	if !ok {
+		return "", false
+	}
+	switch group {
+	case "colors":
+		return t.Colors.token(name)
+	}
+	return "", false
+}
+
+func (c ColorPalette) token(name string) (string, bool) {
+	switch name {
+	case "primary":
+		return c.Primary, true
+	case "secondary":
+		return c.Secondary, true
+	case "background":
+		return c.Background, true
+	case "surface":
+		return c.Surface, true
+	case "textPrimary":
+		return c.TextPrimary, true
+	case "textSecondary":
+		return c.TextSecondary, true
+	case "error":
+		return c.Error, true
+	}
+	return "", false
+}
+
+func (s SpacingScale) resolve(v int) int {
+	switch v {
+	case SpaceXS:
+		return s.XS
+	case SpaceSM:
+		return s.SM
+	case SpaceMD:
+		return s.MD
+	case SpaceLG:
+		return s.LG
+	case SpaceXL:
+		return s.XL
+	}
+	return v
+}
+
+// resolveStyle finishes a component style at render time: every token is
+// replaced with the theme value, so renderers only ever see concrete values.
+//
+// Once the window size is known the style for the current breakpoint is
+// layered on and Responsive is dropped, so renderers do not apply it a second
+// time through media queries; the next render after a resize starts again
+// from the component's style. Without a size Responsive is kept for the
+// style compiler to turn into media queries.
+func resolveStyle(ctx *Context, s *Style) {
+	if s == nil {
+		return
+	}
+	if size := ctx.windowSize(); size.Width > 0 && s.Responsive != nil {
+		if bp, ok := s.Responsive[string(BreakpointFor(size.Width))]; ok {
+			*s = s.Merge(bp)
+		}
+		s.Responsive = nil
+	}
+	s.resolveTokens(ctx.Theme())
+}
+
+func (s *Style) resolveTokens(t *Theme) {
+	color := func(v *string) {
+		if !IsToken(*v) {
+			return
+		}
+		resolved, _ := t.Token(*v)
+		*v = resolved
+	}
+	space := func(v *int) {
+		*v = t.Spacing.resolve(*v)
+	}
+	spaceF := func(v *float64) {
+		if *v <= spaceToken-1 && *v >= spaceToken-5 {
+			*v = float64(t.Spacing.resolve(int(*v)))
+		}
+	}
+	insets := func(e *EdgeInsets) {
+		space(&e.Top)
+		space(&e.Right)
+		space(&e.Bottom)
+		space(&e.Left)
+		space(&e.Horizontal)
+		space(&e.Vertical)
+	}
+
+	color(&s.TextColor)
+	color(&s.Background)
+	color(&s.BorderColor)
+	insets(&s.Padding)
+	insets(&s.Margin)
+	spaceF(&s.Gap)
+	spaceF(&s.RowGap)
+	spaceF(&s.ColumnGap)
+	spaceF(&s.BorderRadius)
+
+	// nested styles may be shared with the theme, so resolve copies
+	for _, nested := range []**Style{&s.HoverStyle, &s.FocusStyle, &s.PressedStyle, &s.DisabledStyle, &s.SelectedStyle} {
+		if *nested != nil {
+			copied := **nested
+			copied.resolveTokens(t)
+			*nested = &copied
+		}
+	}
+	if s.PseudoStates != nil {
+		states := make(map[string]Style, len(s.PseudoStates))
+		for k, ps := range s.PseudoStates {
+			ps.resolveTokens(t)
+			states[k] = ps
+		}
+		s.PseudoStates = states
+	}
+	if s.Responsive != nil {
+		responsive := make(ResponsiveStyle, len(s.Responsive))
+		for k, rs := range s.Responsive {
+			rs.resolveTokens(t)
+			responsive[k] = rs
+		}
+		s.Responsive = responsive
+	}
+}
@@ -0,0 +1,219 @@
+// Package css compiles resolved styles into shared CSS classes for the web
+// renderers. Each distinct style gets one class named after a hash of its
+// rules, so a feed of a thousand identical cards ships a single rule.
+package css
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// State selectors; "&" stands for the class.
+var stateSelectors = []struct {
+	selector string
+	style    func(*core.Style) *core.Style
+}{
+	{"&:hover:not(:disabled):not([aria-disabled=true])", func(s *core.Style) *core.Style { return s.HoverStyle }},
+	{"&:focus", func(s *core.Style) *core.Style { return s.FocusStyle }},
+	{"&:active:not(:disabled):not([aria-disabled=true])", func(s *core.Style) *core.Style { return s.PressedStyle }},
+	{"&[aria-selected=true]", func(s *core.Style) *core.Style { return s.SelectedStyle }},
+	{"&:disabled, &[aria-disabled=true]", func(s *core.Style) *core.Style { return s.DisabledStyle }},
+}
+
+func breakpointQueries() []struct {
+	breakpoint core.Breakpoint
+	query      string
+} {
+	return []struct {
+		breakpoint core.Breakpoint
+		query      string
+	}{
+		{core.BreakpointMobile, fmt.Sprintf("(max-width: %dpx)", core.Breakpoints.Tablet-1)},
+		{core.BreakpointTablet, fmt.Sprintf("(min-width: %dpx) and (max-width: %dpx)", core.Breakpoints.Tablet, core.Breakpoints.Desktop-1)},
+		{core.BreakpointDesktop, fmt.Sprintf("(min-width: %dpx)", core.Breakpoints.Desktop)},
+	}
+}
+
+type rule struct {
+	media    string
+	selector string
+	body     string
+}
+
+func (r rule) render(class string) string {
+	out := fmt.Sprintf("%s { %s }", strings.ReplaceAll(r.selector, "&", "."+class), r.body)
+	if r.media != "" {
+		out = fmt.Sprintf("@media %s { %s }", r.media, out)
+	}
+	return out
+}
+
+// Compiler hands out classes and remembers the rules behind them. The base
+// rule comes first and state rules after it, so states win on equal
+// specificity without !important.
+//
+// Rules are kept until Evict drops the classes that went out of use; without
+// it the sheet grows by one class per distinct style, which is fine for a
+// one-off export but not for a long-running app whose styles carry data
+// (a progress width, a color picked by the user).
+type Compiler struct {
+	mu      sync.Mutex
+	classes map[string]string   // rules key -> class
+	owners  map[string]string   // class -> rules key, to detect hash collisions
+	rules   map[string][]string // class -> rendered rules
+	order   []string            // classes in the order they were added
+	pending []string            // classes added since the last Drain
+	used    map[string]bool     // classes handed out since the last Evict
+}
+
+func New() *Compiler {
+	return &Compiler{
+		classes: map[string]string{},
+		owners:  map[string]string{},
+		rules:   map[string][]string{},
+		used:    map[string]bool{},
+	}
+}
+
+// Class returns the class for s, adding its rules on first use. Styles that
+// render to no CSS get "".
+func (c *Compiler) Class(s *core.Style) string {
+	rules := compile(s)
+	if len(rules) == 0 {
+		return ""
+	}
+	key := fmt.Sprint(rules)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if class, ok := c.classes[key]; ok {
+		c.used[class] = true
+		return class
+	}
+	class := className(key)
+	for i := 1; c.owners[class] != ""; i++ {
+		class = className(key) + "-" + strconv.Itoa(i)
+	}
+	c.classes[key] = class
+	c.owners[class] = key
+	for _, r := range rules {
+		c.rules[class] = append(c.rules[class], r.render(class))
+	}
+	c.order = append(c.order, class)
+	c.pending = append(c.pending, class)
+	c.used[class] = true
+	return class
+}
+
+// Apply replaces every Style in the tree with its class, so renderers and
+// patches only carry class names.
+func (c *Compiler) Apply(n *core.Node) {
+	if n == nil {
+		return
+	}
+	if n.Style != nil {
+		n.Class = c.Class(n.Style)
+		n.Style = nil
+	}
+	for _, child := range n.Children {
+		c.Apply(child)
+	}
+}
+
+// CSS returns the whole sheet, one rule per line.
+func (c *Compiler) CSS() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var b strings.Builder
+	for _, class := range c.order {
+		for _, r := range c.rules[class] {
+			b.WriteString(r)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// Drain returns the rules added since the previous call, for hosts that
+// inject them incrementally.
+func (c *Compiler) Drain() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []string
+	for _, class := range c.pending {
+		out = append(out, c.rules[class]...)
+	}
+	c.pending = nil
+	return out
+}
+
+// Evict forgets the classes that were not handed out since the previous
+// call and returns them, so the host can delete their rules. Hosts that
+// apply the whole tree on every render call it after each one, which bounds
+// the sheet by the styles on screen.
+func (c *Compiler) Evict() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted []string
+	kept := c.order[:0]
+	for _, class := range c.order {
+		if c.used[class] {
+			kept = append(kept, class)
+			continue
+		}
+		evicted = append(evicted, class)
+		delete(c.classes, c.owners[class])
+		delete(c.owners, class)
+		delete(c.rules, class)
+	}
+	c.order = kept
+	c.used = map[string]bool{}
+
+	pending := c.pending[:0]
+	for _, class := range c.pending {
+		if c.owners[class] != "" {
+			pending = append(pending, class)
+		}
+	}
+	c.pending = pending
+	return evicted
+}
+
+func compile(s *core.Style) []rule {
+	if s == nil {
+		return nil
+	}
+	var rules []rule
+	add := func(media, selector string, style *core.Style) {
+		if style == nil {
+			return
+		}
+		if d := Declarations(style); len(d) > 0 {
+			rules = append(rules, rule{media, selector, strings.Join(d, "; ") + ";"})
+		}
+	}
+
+	add("", "&", s)
+	for _, bq := range breakpointQueries() {
+		if rs, ok := s.Responsive[string(bq.breakpoint)]; ok {
+			add(bq.query, "&", &rs)
+		}
+	}
+	for _, st := range stateSelectors {
+		add("", st.selector, st.style(s))
+	}
+	return rules
+}
+
+func className(key string) string {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return "gv-" + strconv.FormatUint(uint64(h.Sum32()), 36)
+}
@@ -0,0 +1,133 @@
+package css
+
+import (
+	"fmt"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// Declarations renders the plain properties of a style as CSS declarations,
+// e.g. ["color:#000", "padding:8px 16px 8px 16px"]. State, breakpoint and
+// pseudo styles are not included; Compiler turns those into separate rules.
+func Declarations(s *core.Style) []string {
+	if s == nil {
+		return nil
+	}
+	var d decls
+
+	d.str("color", s.TextColor)
+	d.str("background", s.Background)
+	d.px("font-size", s.FontSize)
+	if s.FontWeight != 0 {
+		d.add("font-weight", fmt.Sprint(int(s.FontWeight)))
+	}
+	if s.FontFamily != "" {
+		d.add("font-family", fmt.Sprintf("'%s'", s.FontFamily))
+	}
+	d.str("font-style", string(s.FontStyle))
+	d.px("letter-spacing", s.LetterSpacing)
+	if s.LineHeight != 0 {
+		d.add("line-height", fmt.Sprintf("%dpx", s.LineHeight))
+	}
+	d.str("text-decoration", string(s.TextDecoration))
+	d.str("text-transform", string(s.TextTransform))
+	switch {
+	case s.TextAlign != "":
+		d.add("text-align", string(s.TextAlign))
+	case s.Align == core.AlignCenter:
+		d.add("text-align", "center")
+	case s.Align == core.AlignStart:
+		d.add("text-align", "left")
+	case s.Align == core.AlignEnd:
+		d.add("text-align", "right")
+	}
+	d.str("white-space", s.WhiteSpace)
+
+	d.str("display", string(s.Display))
+	d.str("position", string(s.Position))
+	d.str("top", s.Top)
+	d.str("right", s.Right)
+	d.str("bottom", s.Bottom)
+	d.str("left", s.Left)
+	if s.ZIndex != 0 {
+		d.add("z-index", fmt.Sprint(s.ZIndex))
+	}
+	d.str("width", s.Width)
+	d.str("height", s.Height)
+	d.str("min-width", s.MinWidth)
+	d.str("min-height", s.MinHeight)
+	d.str("max-width", s.MaxWidth)
+	d.str("overflow", s.Overflow)
+	d.insets("padding", s.Padding)
+	d.insets("margin", s.Margin)
+
+	d.str("flex-direction", string(s.FlexDirection))
+	d.str("justify-content", string(s.JustifyContent))
+	d.str("align-items", string(s.AlignItems))
+	d.str("align-self", string(s.AlignSelf))
+	d.str("flex-wrap", s.FlexWrap)
+	d.str("flex-basis", s.FlexBasis)
+	d.num("flex-grow", s.FlexGrow)
+	d.num("flex-shrink", s.FlexShrink)
+	d.px("gap", s.Gap)
+	d.px("row-gap", s.RowGap)
+	d.px("column-gap", s.ColumnGap)
+
+	d.px("border-radius", s.BorderRadius)
+	if s.BorderWidth != 0 {
+		color := s.BorderColor
+		if color == "" {
+			color = "currentColor"
+		}
+		d.add("border", fmt.Sprintf("%gpx solid %s", s.BorderWidth, color))
+	} else {
+		d.str("border-color", s.BorderColor)
+	}
+	if s.Shadow != 0 {
+		d.add("box-shadow", fmt.Sprintf("0 %gpx %gpx rgba(0,0,0,0.2)", s.Shadow, s.Shadow*2))
+	}
+	d.str("transition", s.Transition)
+	d.str("animation", s.Animation)
+
+	return d
+}
+
+type decls []string
+
+func (d *decls) add(prop, value string) {
+	*d = append(*d, prop+":"+value)
+}
+
+func (d *decls) str(prop, value string) {
+	if value != "" {
+		d.add(prop, value)
+	}
+}
+
+func (d *decls) px(prop string, value float64) {
+	if value != 0 {
+		d.add(prop, fmt.Sprintf("%gpx", value))
+	}
+}
+
+func (d *decls) num(prop string, value float64) {
+	if value != 0 {
+		d.add(prop, fmt.Sprintf("%g", value))
+	}
+}
+
+// insets resolves Horizontal/Vertical as fallbacks for unset sides.
+func (d *decls) insets(prop string, e core.EdgeInsets) {
+	if e == (core.EdgeInsets{}) {
+		return
+	}
+	side := func(v, fallback int) int {
+		if v == 0 {
+			return fallback
+		}
+		return v
+	}
+	d.add(prop, fmt.Sprintf("%dpx %dpx %dpx %dpx",
+		side(e.Top, e.Vertical), side(e.Right, e.Horizontal),
+		side(e.Bottom, e.Vertical), side(e.Left, e.Horizontal)))
+}
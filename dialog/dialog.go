@@ -0,0 +1,283 @@
+// Package dialog shows alerts, confirmations, prompts and action sheets and
+// reports the answer. The host draws them: native dialogs on Android, a
+// modal over the page on the web.
+//
+//	core.Button("Transfer", func() {
+//		dialog.Confirm("Transfer 500 MT?", "This can't be undone.", func(ok bool) {
+//			if ok {
+//				transfer()
+//			}
+//		}, dialog.ConfirmLabel("Transfer"), dialog.Destructive())
+//	})
+//
+// Every call also returns a channel that receives the same answer, for code
+// that would rather wait in a goroutine:
+//
+//	go func() {
+//		if name, ok := (<-dialog.Prompt("Rename", "", nil)).Get(); ok {
+//			rename(name)
+//		}
+//	}()
+//
+// The callback runs on the event that brought the answer, so state it sets
+// renders right away; a goroutine waiting on the channel should mark the
+// context dirty.
+package dialog
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/GraHms/govinci/core"
+)
+
+type Kind string
+
+const (
+	KindAlert       Kind = "alert"
+	KindConfirm     Kind = "confirm"
+	KindPrompt      Kind = "prompt"
+	KindActionSheet Kind = "actionSheet"
+)
+
+// Cancelled is the index an ActionSheet reports when dismissed without a
+// choice.
+const Cancelled = -1
+
+// Action is one entry of an ActionSheet. Destructive ones are drawn in red.
+type Action struct {
+	Label       string
+	Destructive bool
+}
+
+// Request is what the host is asked to show; it travels as the "dialog"
+// system event.
+type Request struct {
+	ID          string
+	Kind        Kind
+	Title       string
+	Message     string
+	Confirm     string // label of the confirming button
+	Cancel      string // label of the cancelling button; empty on an alert
+	Destructive bool
+	Value       string // a prompt's initial text
+	Placeholder string
+	Keyboard    core.KeyboardType
+	Secure      bool
+	Actions     []Action
+}
+
+// Answer is what the host reports back: whether the dialog was confirmed,
+// the text of a prompt and the index of the chosen action.
+type Answer struct {
+	Confirmed bool
+	Value     string
+	Index     int
+}
+
+// PromptResult is the answer to a Prompt; OK is false when it was
+// cancelled.
+type PromptResult struct {
+	Value string
+	OK    bool
+}
+
+func (r PromptResult) Get() (string, bool) { return r.Value, r.OK }
+
+type Option interface {
+	Apply(*Request)
+}
+
+type optionFunc func(*Request)
+
+func (f optionFunc) Apply(r *Request) { f(r) }
+
+// ConfirmLabel names the confirming button ("OK" by default).
+func ConfirmLabel(label string) Option {
+	return optionFunc(func(r *Request) {
+		r.Confirm = label
+	})
+}
+
+// CancelLabel names the cancelling button ("Cancel" by default).
+func CancelLabel(label string) Option {
+	return optionFunc(func(r *Request) {
+		r.Cancel = label
+	})
+}
+
+// Destructive draws the confirming button in red, for deletes and
+// transfers.
+func Destructive() Option {
+	return optionFunc(func(r *Request) {
+		r.Destructive = true
+	})
+}
+
+// DefaultValue fills a Prompt's field.
+func DefaultValue(text string) Option {
+	return optionFunc(func(r *Request) {
+		r.Value = text
+	})
+}
+
+func Placeholder(text string) Option {
+	return optionFunc(func(r *Request) {
+		r.Placeholder = text
+	})
+}
+
+// Keyboard picks the keyboard of a Prompt's field.
+func Keyboard(t core.KeyboardType) Option {
+	return optionFunc(func(r *Request) {
+		r.Keyboard = t
+	})
+}
+
+// Secure hides what is typed in a Prompt, for PINs and passwords.
+func Secure() Option {
+	return optionFunc(func(r *Request) {
+		r.Secure = true
+	})
+}
+
+// Alert tells the user something; onClose runs once it is dismissed.
+func Alert(title, message string, onClose func(), opts ...Option) <-chan struct{} {
+	out := make(chan struct{}, 1)
+	show(request(KindAlert, title, message, "", opts), func(Answer) {
+		if onClose != nil {
+			onClose()
+		}
+		out <- struct{}{}
+	})
+	return out
+}
+
+// Confirm asks a yes/no question; onResult gets true when confirmed.
+func Confirm(title, message string, onResult func(bool), opts ...Option) <-chan bool {
+	out := make(chan bool, 1)
+	show(request(KindConfirm, title, message, "Cancel", opts), func(a Answer) {
+		if onResult != nil {
+			onResult(a.Confirmed)
+		}
+		out <- a.Confirmed
+	})
+	return out
+}
+
+// Prompt asks for a line of text; ok is false when it was cancelled.
+func Prompt(title, message string, onResult func(value string, ok bool), opts ...Option) <-chan PromptResult {
+	out := make(chan PromptResult, 1)
+	show(request(KindPrompt, title, message, "Cancel", opts), func(a Answer) {
+		if !a.Confirmed {
+			a.Value = ""
+		}
+		if onResult != nil {
+			onResult(a.Value, a.Confirmed)
+		}
+		out <- PromptResult{Value: a.Value, OK: a.Confirmed}
+	})
+	return out
+}
+
+// ActionSheet offers a list of actions; onSelect gets the index of the
+// chosen one, or Cancelled. title may be empty.
+func ActionSheet(title string, actions []Action, onSelect func(index int), opts ...Option) <-chan int {
+	out := make(chan int, 1)
+	req := request(KindActionSheet, title, "", "Cancel", opts)
+	req.Actions = actions
+	show(req, func(a Answer) {
+		index := a.Index
+		if !a.Confirmed || index < 0 || index >= len(actions) {
+			index = Cancelled
+		}
+		if onSelect != nil {
+			onSelect(index)
+		}
+		out <- index
+	})
+	return out
+}
+
+func request(kind Kind, title, message, cancel string, opts []Option) *Request {
+	r := &Request{Kind: kind, Title: title, Message: message, Confirm: "OK", Cancel: cancel}
+	for _, opt := range opts {
+		opt.Apply(r)
+	}
+	return r
+}
+
+var (
+	mu      sync.Mutex
+	counter int
+	pending = map[string]func(Answer){}
+	fake    *Fake
+)
+
+func show(r *Request, answer func(Answer)) {
+	mu.Lock()
+	counter++
+	r.ID = fmt.Sprintf("dialog_%d", counter)
+	f := fake
+	if f == nil {
+		pending[r.ID] = answer
+	}
+	mu.Unlock()
+
+	if f != nil {
+		answer(f.answer(*r))
+		return
+	}
+	core.SendSystemEvent("dialog", r.payload())
+}
+
+func (r *Request) payload() map[string]any {
+	data := map[string]any{
+		"id":      r.ID,
+		"kind":    string(r.Kind),
+		"title":   r.Title,
+		"message": r.Message,
+		"confirm": r.Confirm,
+		"cancel":  r.Cancel,
+	}
+	if r.Destructive {
+		data["destructive"] = true
+	}
+	if r.Kind == KindPrompt {
+		data["value"] = r.Value
+		data["placeholder"] = r.Placeholder
+		data["keyboard"] = string(r.Keyboard)
+		data["secure"] = r.Secure
+	}
+	if r.Kind == KindActionSheet {
+		actions := make([]map[string]any, len(r.Actions))
+		for i, a := range r.Actions {
+			actions[i] = map[string]any{"label": a.Label, "destructive": a.Destructive}
+		}
+		data["actions"] = actions
+	}
+	return data
+}
+
+// The host answers with the "dialog" system event: {id, confirmed, value,
+// index}. Each dialog is answered once; a repeat is dropped.
+func init() {
+	core.OnSystemEvent("dialog", func(data map[string]any) {
+		id, _ := data["id"].(string)
+		mu.Lock()
+		answer := pending[id]
+		delete(pending, id)
+		mu.Unlock()
+		if answer == nil {
+			return
+		}
+
+		a := Answer{Index: Cancelled}
+		a.Confirmed, _ = data["confirmed"].(bool)
+		a.Value, _ = data["value"].(string)
+		if index, ok := data["index"].(float64); ok {
+			a.Index = int(index)
+		}
+		answer(a)
+	})
+}
@@ -0,0 +1,61 @@
+package dialog
+
+import "sync"
+
+// Fake answers dialogs on the spot instead of sending them to a host, so
+// flows that ask the user can run in tests:
+//
+//	fake := &dialog.Fake{Confirm: true}
+//	defer dialog.UseFake(fake)()
+//	app.Transfer(500)
+//	// fake.Requests()[0].Title == "Transfer 500 MT?"
+//
+// Answer, when set, decides per request; otherwise every Confirm and Prompt
+// gets Confirm, every Prompt Text and every ActionSheet Action.
+type Fake struct {
+	Confirm bool
+	Text    string
+	Action  int
+	Answer  func(Request) Answer
+
+	mu       sync.Mutex
+	requests []Request
+}
+
+// UseFake routes every dialog to f until the returned function restores the
+// host.
+func UseFake(f *Fake) (restore func()) {
+	mu.Lock()
+	previous := fake
+	fake = f
+	mu.Unlock()
+	return func() {
+		mu.Lock()
+		fake = previous
+		mu.Unlock()
+	}
+}
+
+// Requests lists the dialogs shown so far, oldest first.
+func (f *Fake) Requests() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Request(nil), f.requests...)
+}
+
+func (f *Fake) answer(r Request) Answer {
+	f.mu.Lock()
+	f.requests = append(f.requests, r)
+	f.mu.Unlock()
+
+	if f.Answer != nil {
+		return f.Answer(r)
+	}
+	switch r.Kind {
+	case KindAlert:
+		return Answer{Confirmed: true}
+	case KindActionSheet:
+		return Answer{Confirmed: f.Action != Cancelled, Index: f.Action}
+	}
+	return Answer{Confirmed: f.Confirm, Value: f.Text}
+}
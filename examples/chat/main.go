@@ -3,15 +3,26 @@ package main
 import (
 	. "github.com/GraHms/govinci/core"
 	"github.com/GraHms/govinci/htmlout"
-	"os"
+	"github.com/GraHms/govinci/imagecache"
+	"io"
+	"net/http"
 )
 
 func main() {
-	ctx := NewContext()
-	node := SocialApp().Render(ctx)
-	html := htmlout.ExportHTML(node)
+	// feed thumbnails are generated once, in the background, and reused
+	// from .govinci/images; the first visit shows the originals
+	thumbs := imagecache.New(imagecache.Options{
+		Dir:   ".govinci/images",
+		Fetch: true,
+	})
+	SetImageProcessor(thumbs)
 
-	os.WriteFile("social.html", []byte(html), 0644)
+	http.Handle("/_govinci/thumbs/", thumbs)
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		node := SocialApp().Render(NewContext())
+		io.WriteString(w, htmlout.ExportHTML(node))
+	})
+	http.ListenAndServe(":8080", nil)
 }
 
 func SocialApp() View {
@@ -49,7 +60,14 @@ func FeedPost(imgURL, username, caption string) View {
 				Text(username, FontWeight(Bold)),
 			),
 			Spacer(8),
-			Image(imgURL),
+			Image(imgURL,
+				Thumbnail(400, 0),
+				ContentMode(ContentCover),
+				AspectRatio(1),
+				FadeIn(200),
+				Placeholder(Box(BackgroundColor("#EEEEEE"), Height("100%"))),
+				ErrorView(Text("Image unavailable", FontSize(12))),
+			),
 			Spacer(8),
 			Text(caption, FontSize(14)),
 			Spacer(8),
@@ -26,45 +26,60 @@ func App(ctx *core.Context) core.View {
 		core.Scroll(
 			core.Column(
 				HeaderSection(ctx),
-				core.Spacer(24),
-				BalanceCard(ctx),
-				core.Spacer(24),
-				ActionsSection(ctx),
-				core.Spacer(28),
-				TransactionList(ctx),
+				core.Spacer(core.SpaceLG),
+				Dashboard(ctx),
 			),
 		),
 	)
 }
 
+// Dashboard stacks the overview and the transactions on phones and shows
+// them side by side from tablet width up.
+func Dashboard(ctx *core.Context) core.View {
+	return core.Box(
+		core.Display(core.DisplayFlex),
+		core.FlexDir(core.FlexColumn),
+		core.Gap(28),
+		core.WhenBreakpoint(core.BreakpointTablet, core.FlexDir(core.FlexRow)),
+		core.WhenBreakpoint(core.BreakpointDesktop, core.FlexDir(core.FlexRow)),
+		core.Column(
+			core.FlexGrow(1),
+			BalanceCard(ctx),
+			core.Spacer(core.SpaceLG),
+			ActionsSection(ctx),
+		),
+		core.Column(
+			core.FlexGrow(1),
+			TransactionList(ctx),
+		),
+	)
+}
+
 func HeaderSection(ctx *core.Context) core.View {
-	t := ctx.Theme()
 	return core.Column(
 		core.Image("https://dummyimage.com/60x60/6200EE/ffffff&text=G"),
 		core.Spacer(12),
-		core.Text("Govinci Wallet", core.FontSize(t.Typography.Title.FontSize), core.FontWeight(t.Typography.Title.FontWeight), core.TextColor(t.Colors.TextPrimary)),
+		core.Text("Govinci Wallet", core.Variant(core.TextTitle), core.TextColor(core.Token("colors.textPrimary"))),
 		core.Spacer(4),
-		core.Text("Welcome back, Ismael", core.FontSize(15), core.TextColor(t.Colors.TextSecondary)),
+		core.Text("Welcome back, Ismael", core.FontSize(15), core.TextColor(core.Token("colors.textSecondary"))),
 	)
 }
 
 func BalanceCard(ctx *core.Context) core.View {
-	t := ctx.Theme()
 	return core.Card(
 		core.Column(
-			core.Text("Available Balance", core.FontSize(12), core.TextColor(t.Colors.TextSecondary)),
+			core.Text("Available Balance", core.FontSize(12), core.TextColor(core.Token("colors.textSecondary"))),
 			core.Spacer(8),
-			core.Text("MZN 42,750.00", core.FontSize(24), core.FontWeight(core.Bold), core.TextColor(t.Colors.Primary)),
+			core.Text("MZN 42,750.00", core.FontSize(24), core.FontWeight(core.Bold), core.TextColor(core.Token("colors.primary"))),
 		),
 	)
 }
 
 func ActionsSection(ctx *core.Context) core.View {
-	t := ctx.Theme()
 	return core.Row(
-		MaterialButton("Transfer", t.Colors.Primary, "#FFF", func() {}),
+		MaterialButton("Transfer", core.Token("colors.primary"), "#FFF", func() {}),
 		core.Spacer(12),
-		MaterialButton("Recharge", "#FFF", t.Colors.Secondary, func() {}),
+		MaterialButton("Recharge", "#FFF", core.Token("colors.secondary"), func() {}),
 	)
 }
 
@@ -79,13 +94,12 @@ func MaterialButton(label string, bg string, fg string, onClick func()) core.Vie
 }
 
 func TransactionList(ctx *core.Context) core.View {
-	t := ctx.Theme()
 	return core.Column(
-		core.Text("Recent Transactions", core.TextColor(t.Colors.TextPrimary), core.FontSize(16), core.FontWeight(core.Bold)),
+		core.Text("Recent Transactions", core.TextColor(core.Token("colors.textPrimary")), core.FontSize(16), core.FontWeight(core.Bold)),
 		core.Spacer(16),
-		TransactionItem("Farmácia", "-750 MZN", t.Colors.Error),
-		TransactionItem("Transferência recebida", "+10,000 MZN", t.Colors.Secondary),
-		TransactionItem("Recarga de saldo", "+3,500 MZN", t.Colors.Secondary),
+		TransactionItem("Farmácia", "-750 MZN", core.Token("colors.error")),
+		TransactionItem("Transferência recebida", "+10,000 MZN", core.Token("colors.secondary")),
+		TransactionItem("Recarga de saldo", "+3,500 MZN", core.Token("colors.secondary")),
 	)
 }
 
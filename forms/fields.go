@@ -0,0 +1,56 @@
+package forms
+
+import (
+	"reflect"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// The field components wrap the core inputs: they read the value from the
+// form, write edits back through Set and show the field's error under the
+// input once it has been touched. Style and input props (masks, keyboard
+// types...) pass through to the input.
+
+func (f *Form[T]) Input(name, placeholder string, props ...core.PropsAndChildren) core.View {
+	return f.withError(name, core.Input(f.text(name), placeholder, f.setter(name), props...))
+}
+
+func (f *Form[T]) InputPassword(name, placeholder string, props ...core.PropsAndChildren) core.View {
+	return f.withError(name, core.InputPassword(f.text(name), placeholder, f.setter(name), props...))
+}
+
+func (f *Form[T]) TextArea(name string, rows int, props ...core.PropsAndChildren) core.View {
+	return f.withError(name, core.TextArea(f.text(name), f.setter(name), rows, props...))
+}
+
+// NumericInput binds an integer field.
+func (f *Form[T]) NumericInput(name string, props ...core.PropsAndChildren) core.View {
+	value := int(reflect.ValueOf(f.Value(name)).Int())
+	return f.withError(name, core.NumericInput(value, func(v int) { f.Set(name, v) }, props...))
+}
+
+func (f *Form[T]) Checkbox(name string, styleProps ...core.StyleProp) core.View {
+	checked := reflect.ValueOf(f.Value(name)).Bool()
+	return f.withError(name, core.Checkbox(checked, func(v bool) { f.Set(name, v) }, styleProps...))
+}
+
+// ErrorText renders the field's error in the theme's error color, or nothing.
+func (f *Form[T]) ErrorText(name string) core.View {
+	msg := f.Error(name)
+	return core.If(msg != "", core.Text(msg,
+		core.Variant(core.TextCaption),
+		core.TextColor(core.Token("colors.error")),
+	))
+}
+
+func (f *Form[T]) withError(name string, input core.View) core.View {
+	return core.Column(input, f.ErrorText(name))
+}
+
+func (f *Form[T]) text(name string) string {
+	return reflect.ValueOf(f.Value(name)).String()
+}
+
+func (f *Form[T]) setter(name string) func(string) {
+	return func(v string) { f.Set(name, v) }
+}
@@ -0,0 +1,332 @@
+// Package forms binds a struct to input components, validates its fields and
+// tracks the state of a submit.
+//
+//	type Signup struct {
+//		Email string `form:"email"`
+//		Age   int
+//		Terms bool
+//	}
+//
+//	form := forms.New(ctx, Signup{})
+//	form.Validate("email", forms.Required("Email is required"), forms.Pattern(`^\S+@\S+$`, "Invalid email"))
+//	form.Validate("Age", forms.Min(18, "Must be 18 or older"))
+//
+//	return core.Column(
+//		form.Input("email", "Email"),
+//		form.NumericInput("Age"),
+//		form.Checkbox("Terms"),
+//		core.Button("Sign up", form.Submit(save), core.Disabled(form.Submitting())),
+//	)
+package forms
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// FieldState is what the form knows about one field. A field is touched
+// once the user edits it or the form is submitted, and dirty while its value
+// differs from the initial one. Error holds the first failing validator's
+// message.
+type FieldState struct {
+	Touched    bool
+	Dirty      bool
+	Error      string
+	Validating bool // an async validator is still running
+}
+
+type Form[T any] struct {
+	state *formState[T]
+}
+
+type formState[T any] struct {
+	mu         sync.Mutex
+	initial    T
+	values     T
+	fields     map[string]*field
+	submitting bool
+	submitErr  error
+}
+
+type field struct {
+	FieldState
+	index      []int
+	validators []Validator
+	run        int // bumped on every validation so late async results are dropped
+	pending    int // async validators of this run still out
+}
+
+// New returns the form kept in the next hook slot, starting from initial on
+// the first render. T must be a struct; its exported fields are addressed by
+// Go name or by their `form:"name"` tag.
+func New[T any](ctx *core.Context, initial T) *Form[T] {
+	slot := core.NewState(ctx, &formState[T]{})
+	state := slot.Get()
+	state.mu.Lock()
+	if state.fields == nil {
+		state.initial = initial
+		state.values = initial
+		state.fields = fieldsOf(reflect.TypeOf(initial))
+	}
+	state.mu.Unlock()
+	return &Form[T]{state: state}
+}
+
+func fieldsOf(t reflect.Type) map[string]*field {
+	fields := map[string]*field{}
+	if t.Kind() != reflect.Struct {
+		panic("forms: New needs a struct, got " + t.String())
+	}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		fd := &field{index: f.Index}
+		fields[f.Name] = fd
+		if tag, _, _ := strings.Cut(f.Tag.Get("form"), ","); tag != "" && tag != "-" {
+			fields[tag] = fd
+		}
+	}
+	return fields
+}
+
+func (f *Form[T]) lookup(name string) *field {
+	fd, ok := f.state.fields[name]
+	if !ok {
+		panic("forms: no field " + name + " in " + reflect.TypeOf(f.state.values).String())
+	}
+	return fd
+}
+
+// Validate sets the validators of a field, replacing the previous ones. Call
+// it on every render, like the field components themselves.
+func (f *Form[T]) Validate(name string, validators ...Validator) {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	f.lookup(name).validators = validators
+}
+
+func (f *Form[T]) Values() T {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.state.values
+}
+
+func (f *Form[T]) Value(name string) any {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.valueOf(f.lookup(name)).Interface()
+}
+
+func (f *Form[T]) valueOf(fd *field) reflect.Value {
+	return reflect.ValueOf(&f.state.values).Elem().FieldByIndex(fd.index)
+}
+
+// Set changes a field as if the user had typed the value: the field becomes
+// touched and is validated again.
+func (f *Form[T]) Set(name string, value any) {
+	f.state.mu.Lock()
+	fd := f.lookup(name)
+	v := f.valueOf(fd)
+	rv := reflect.ValueOf(value)
+	if !rv.CanConvert(v.Type()) || (rv.Kind() == reflect.String) != (v.Kind() == reflect.String) {
+		f.state.mu.Unlock()
+		panic("forms: cannot set " + name + " to a " + rv.Type().String())
+	}
+	v.Set(rv.Convert(v.Type()))
+	initial := reflect.ValueOf(f.state.initial).FieldByIndex(fd.index)
+	fd.Touched = true
+	fd.Dirty = !reflect.DeepEqual(v.Interface(), initial.Interface())
+	async := f.validate(fd)
+	f.state.mu.Unlock()
+	f.runAsync(fd, async)
+}
+
+func (f *Form[T]) Field(name string) FieldState {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.lookup(name).FieldState
+}
+
+// Error is the field's error once it has been touched, "" otherwise, which
+// is what a form usually shows.
+func (f *Form[T]) Error(name string) string {
+	s := f.Field(name)
+	if !s.Touched {
+		return ""
+	}
+	return s.Error
+}
+
+func (f *Form[T]) Dirty() bool {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	for _, fd := range f.state.fields {
+		if fd.Dirty {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether no field has an error or a pending async check.
+// Fields that were never validated count as valid until Submit checks them.
+func (f *Form[T]) Valid() bool {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	for _, fd := range f.state.fields {
+		if fd.Error != "" || fd.Validating {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Form[T]) Submitting() bool {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.state.submitting
+}
+
+// SubmitError is the error returned by the last submit handler.
+func (f *Form[T]) SubmitError() error {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	return f.state.submitErr
+}
+
+// Reset goes back to the initial values and forgets touched, dirty and
+// errors.
+func (f *Form[T]) Reset() {
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	f.state.values = f.state.initial
+	f.state.submitErr = nil
+	for _, fd := range f.state.fields {
+		fd.FieldState = FieldState{}
+		fd.run++
+		fd.pending = 0
+	}
+	core.MarkRootDirty()
+}
+
+// Submit returns a click handler. It touches and validates every field,
+// waits for async validators and, if everything passes, calls handler in the
+// background with the values. Submitting is true until handler returns;
+// clicks meanwhile are ignored.
+func (f *Form[T]) Submit(handler func(T) error) func() {
+	return func() {
+		f.state.mu.Lock()
+		if f.state.submitting {
+			f.state.mu.Unlock()
+			return
+		}
+		f.state.submitting = true
+		f.state.submitErr = nil
+		var pending []asyncCheck
+		for _, fd := range f.fieldList() {
+			fd.Touched = true
+			pending = append(pending, f.validate(fd)...)
+		}
+		f.state.mu.Unlock()
+
+		go func() {
+			for _, check := range pending {
+				f.finishAsync(check)
+			}
+
+			f.state.mu.Lock()
+			valid := true
+			for _, fd := range f.state.fields {
+				valid = valid && fd.Error == ""
+			}
+			values := f.state.values
+			f.state.mu.Unlock()
+
+			var err error
+			if valid {
+				err = handler(values)
+			}
+			f.state.mu.Lock()
+			f.state.submitting = false
+			f.state.submitErr = err
+			f.state.mu.Unlock()
+			core.MarkRootDirty()
+		}()
+	}
+}
+
+// fieldList returns every field once; tagged fields are in the map twice.
+func (f *Form[T]) fieldList() []*field {
+	seen := map[*field]bool{}
+	var out []*field
+	for _, fd := range f.state.fields {
+		if !seen[fd] {
+			seen[fd] = true
+			out = append(out, fd)
+		}
+	}
+	return out
+}
+
+type asyncCheck struct {
+	field *field
+	run   int
+	value any
+	check func(any) error
+}
+
+// validate runs the synchronous validators in order and stops at the first
+// error. When they pass it returns the async ones still to run. Callers hold
+// the lock.
+func (f *Form[T]) validate(fd *field) []asyncCheck {
+	fd.run++
+	fd.Error = ""
+	fd.Validating = false
+	value := f.valueOf(fd).Interface()
+	var async []asyncCheck
+	for _, v := range fd.validators {
+		if v.async {
+			async = append(async, asyncCheck{field: fd, run: fd.run, value: value, check: v.check})
+			continue
+		}
+		if err := v.check(value); err != nil {
+			fd.Error = err.Error()
+			return nil
+		}
+	}
+	fd.pending = len(async)
+	fd.Validating = fd.pending > 0
+	return async
+}
+
+func (f *Form[T]) runAsync(fd *field, checks []asyncCheck) {
+	if len(checks) == 0 {
+		return
+	}
+	go func() {
+		for _, check := range checks {
+			f.finishAsync(check)
+		}
+		core.MarkRootDirty()
+	}()
+}
+
+// finishAsync runs one async check and records its result unless the field
+// changed in the meantime.
+func (f *Form[T]) finishAsync(c asyncCheck) {
+	err := c.check(c.value)
+	f.state.mu.Lock()
+	defer f.state.mu.Unlock()
+	if c.field.run != c.run {
+		return
+	}
+	c.field.pending--
+	if err != nil && c.field.Error == "" {
+		c.field.Error = err.Error()
+	}
+	c.field.Validating = c.field.pending > 0 && c.field.Error == ""
+}
@@ -0,0 +1,92 @@
+package forms
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks a field value and returns an error whose text is shown
+// under the field. Async validators (a server round trip, say) run in the
+// background after the synchronous ones pass.
+type Validator struct {
+	check func(value any) error
+	async bool
+}
+
+// Custom wraps a synchronous check.
+func Custom(check func(value any) error) Validator {
+	return Validator{check: check}
+}
+
+// Async wraps a check that may block; it runs off the render goroutine and
+// its result is dropped if the field changes before it returns.
+func Async(check func(value any) error) Validator {
+	return Validator{check: check, async: true}
+}
+
+// Required fails on the zero value: "", 0, false. Strings made of spaces
+// count as empty.
+func Required(message string) Validator {
+	return Custom(func(value any) error {
+		if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+			return errors.New(message)
+		}
+		if value == nil || reflect.ValueOf(value).IsZero() {
+			return errors.New(message)
+		}
+		return nil
+	})
+}
+
+// Pattern fails when a string does not match expr. Empty strings pass, so
+// optional fields only need Required when they are not.
+func Pattern(expr string, message string) Validator {
+	re := regexp.MustCompile(expr)
+	return Custom(func(value any) error {
+		s, _ := value.(string)
+		if s != "" && !re.MatchString(s) {
+			return errors.New(message)
+		}
+		return nil
+	})
+}
+
+// Min fails when a number is below n, or a string is shorter than n
+// characters.
+func Min(n float64, message string) Validator {
+	return Custom(func(value any) error {
+		if v, ok := measure(value); ok && v < n {
+			return errors.New(message)
+		}
+		return nil
+	})
+}
+
+// Max fails when a number is above n, or a string is longer than n
+// characters.
+func Max(n float64, message string) Validator {
+	return Custom(func(value any) error {
+		if v, ok := measure(value); ok && v > n {
+			return errors.New(message)
+		}
+		return nil
+	})
+}
+
+func measure(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,72 @@
+package htmlout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// controlCSS draws the selection controls (core/selection.go) on top of the
+// native inputs. A control's text color is its accent; a Switch's knob.
+const controlCSS = `input[type=checkbox], input[type=radio], input[type=range] { accent-color: currentColor; }
+input[role=switch] { appearance: none; -webkit-appearance: none; position: relative; margin: 0; cursor: pointer; transition: background 0.2s; }
+input[role=switch]::before { content: ""; position: absolute; top: 2px; bottom: 2px; left: 2px; aspect-ratio: 1; border-radius: 50%; background: currentColor; box-shadow: 0 1px 3px rgba(0,0,0,0.3); transition: left 0.2s, transform 0.2s; }
+input[role=switch]:checked::before { left: calc(100% - 2px); transform: translateX(-100%); }
+label > input[type=radio] { margin: 0 8px 0 0; }
+[data-gv-range] { position: relative; min-height: 24px; }
+[data-gv-range] > input { position: absolute; left: 0; width: 100%; margin: 0; pointer-events: none; background: none; }
+[data-gv-range] > input::-webkit-slider-thumb { pointer-events: auto; }
+[data-gv-range] > input::-moz-range-thumb { pointer-events: auto; }
+`
+
+func usesControls(node *core.Node) bool {
+	if node == nil {
+		return false
+	}
+	switch node.Type {
+	case "Checkbox", "Switch", "Radio", "Slider", "RangeSlider":
+		return true
+	}
+	for _, child := range node.Children {
+		if usesControls(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func renderRadio(b *strings.Builder, node *core.Node, attrs, pad string) {
+	input := fmt.Sprintf(" name=\"%s\"", getStr(node.Props["name"]))
+	if checked, _ := node.Props["checked"].(bool); checked {
+		input += " checked"
+	}
+	if disabled, _ := node.Props["disabled"].(bool); disabled {
+		input += " disabled"
+	}
+	if id := getStr(node.Props["onSelect"]); id != "" {
+		input += fmt.Sprintf(" data-onselect=\"%s\"", id)
+	}
+	b.WriteString(fmt.Sprintf("%s<label%s><input type=\"radio\"%s />%s</label>\n", pad, attrs, input, getStr(node.Props["label"])))
+}
+
+// rangeAttrs renders min, max and step; without a step the slider is
+// continuous.
+func rangeAttrs(node *core.Node) string {
+	attrs := fmt.Sprintf(" min=\"%g\" max=\"%g\"", node.Props["min"], node.Props["max"])
+	if step, ok := node.Props["step"].(float64); ok {
+		return attrs + fmt.Sprintf(" step=\"%g\"", step)
+	}
+	return attrs + " step=\"any\""
+}
+
+func renderRangeSlider(b *strings.Builder, node *core.Node, attrs, pad string) {
+	thumb := rangeAttrs(node)
+	if disabled, _ := node.Props["disabled"].(bool); disabled {
+		thumb += " disabled"
+	}
+	b.WriteString(fmt.Sprintf("%s<div data-gv-range%s>\n", pad, attrs))
+	b.WriteString(fmt.Sprintf("%s  <input type=\"range\" value=\"%g\"%s />\n", pad, node.Props["low"], thumb))
+	b.WriteString(fmt.Sprintf("%s  <input type=\"range\" value=\"%g\"%s />\n", pad, node.Props["high"], thumb))
+	b.WriteString(fmt.Sprintf("%s</div>\n", pad))
+}
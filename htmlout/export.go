@@ -3,18 +3,37 @@ package htmlout
 import (
 	"fmt"
 	"github.com/GraHms/govinci/core"
+	"github.com/GraHms/govinci/css"
 	"strings"
 )
 
 func ExportHTML(node *core.Node) string {
+	var body strings.Builder
+	sheet := css.New()
+	renderNode(&body, sheet, node, 1)
+
 	var builder strings.Builder
-	builder.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<body>\n")
-	renderNode(&builder, node, 1)
+	builder.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n")
+	rules := sheet.CSS()
+	if usesControls(node) {
+		rules = controlCSS + rules
+	}
+	if usesFeedback(node) {
+		rules = feedbackCSS + rules
+	}
+	if usesStack(node) {
+		rules = stackCSS + rules
+	}
+	if rules != "" {
+		builder.WriteString("<head>\n<style>\n" + rules + "</style>\n</head>\n")
+	}
+	builder.WriteString("<body>\n")
+	builder.WriteString(body.String())
 	builder.WriteString("</body>\n</html>")
 	return builder.String()
 }
 
-func renderNode(b *strings.Builder, node *core.Node, indent int) {
+func renderNode(b *strings.Builder, sheet *css.Compiler, node *core.Node, indent int) {
 	pad := strings.Repeat("  ", indent)
 
 	tag := tagForType(node.Type)
@@ -25,12 +44,12 @@ func renderNode(b *strings.Builder, node *core.Node, indent int) {
 	// Special case for Spacer
 	if node.Type == "Spacer" {
 		if size, ok := node.Props["size"].(int); ok {
-			b.WriteString(fmt.Sprintf("%s<div style=\"height:%dpx\"></div>\n", pad, size))
+			b.WriteString(fmt.Sprintf("%s<div%s style=\"height:%dpx\"></div>\n", pad, classAttr(sheet.Class(node.Style)), size))
 			return
 		}
 	}
 
-	attrs := styleAttr(node.Style)
+	attrs := classAttr(sheet.Class(node.Style)) + stateAttrs(node) + feedbackAttrs(node) + layoutAttrs(node)
 
 	// Add dynamic attributes
 	if id, ok := node.Props["onClick"].(string); ok {
@@ -45,19 +64,14 @@ func renderNode(b *strings.Builder, node *core.Node, indent int) {
 
 	// Open tag
 	switch node.Type {
-	case "Input":
+	case "Input", "InputPassword":
 		val := getStr(node.Props["value"])
 		ph := getStr(node.Props["placeholder"])
-		b.WriteString(fmt.Sprintf("%s<input type=\"text\" value=\"%s\" placeholder=\"%s\"%s />\n", pad, val, ph, attrs))
-		return
-	case "InputPassword":
-		val := getStr(node.Props["value"])
-		ph := getStr(node.Props["placeholder"])
-		b.WriteString(fmt.Sprintf("%s<input type=\"password\" value=\"%s\" placeholder=\"%s\"%s />\n", pad, val, ph, attrs))
+		b.WriteString(fmt.Sprintf("%s<input type=\"%s\" value=\"%s\" placeholder=\"%s\"%s%s />\n", pad, inputType(node, "text"), val, ph, inputAttrs(node), attrs))
 		return
 	case "NumericInput":
 		val := getStr(node.Props["value"])
-		b.WriteString(fmt.Sprintf("%s<input type=\"number\" value=\"%s\"%s />\n", pad, val, attrs))
+		b.WriteString(fmt.Sprintf("%s<input type=\"%s\" value=\"%s\"%s%s />\n", pad, inputType(node, "number"), val, inputAttrs(node), attrs))
 		return
 	case "TextArea":
 		val := getStr(node.Props["value"])
@@ -65,7 +79,7 @@ func renderNode(b *strings.Builder, node *core.Node, indent int) {
 		if r, ok := node.Props["rows"].(int); ok {
 			rows = r
 		}
-		b.WriteString(fmt.Sprintf("%s<textarea rows=\"%d\"%s>%s</textarea>\n", pad, rows, attrs, val))
+		b.WriteString(fmt.Sprintf("%s<textarea rows=\"%d\"%s%s>%s</textarea>\n", pad, rows, inputAttrs(node), attrs, val))
 		return
 	case "Checkbox":
 		checked := ""
@@ -74,9 +88,41 @@ func renderNode(b *strings.Builder, node *core.Node, indent int) {
 		}
 		b.WriteString(fmt.Sprintf("%s<input type=\"checkbox\"%s%s />\n", pad, checked, attrs))
 		return
+	case "Switch":
+		checked := ""
+		if v, ok := node.Props["checked"].(bool); ok && v {
+			checked = " checked"
+		}
+		b.WriteString(fmt.Sprintf("%s<input type=\"checkbox\" role=\"switch\"%s%s />\n", pad, checked, attrs))
+		return
+	case "Radio":
+		renderRadio(b, node, attrs, pad)
+		return
+	case "RadioGroup":
+		attrs += " role=\"radiogroup\""
+	case "SegmentedControl", "Stepper":
+		attrs += " role=\"group\""
+	case "Slider":
+		b.WriteString(fmt.Sprintf("%s<input type=\"range\" value=\"%g\"%s%s />\n", pad, node.Props["value"], rangeAttrs(node), attrs))
+		return
+	case "RangeSlider":
+		renderRangeSlider(b, node, attrs, pad)
+		return
+	case "Select":
+		renderSelect(b, node, attrs, pad)
+		return
+	case "DatePicker":
+		renderDateInput(b, node, "date", getStr(node.Props["value"]), attrs, pad)
+		return
+	case "TimePicker":
+		renderDateInput(b, node, "time", getStr(node.Props["value"]), attrs, pad)
+		return
+	case "DateRangePicker":
+		renderDateRange(b, node, attrs, pad)
+		return
 	case "Image":
 		if src, ok := node.Props["src"].(string); ok {
-			b.WriteString(fmt.Sprintf("%s<img src=\"%s\"%s />\n", pad, src, attrs))
+			renderImage(b, sheet, node, src, pad, indent)
 			return
 		}
 	case "Text":
@@ -94,22 +140,148 @@ func renderNode(b *strings.Builder, node *core.Node, indent int) {
 		b.WriteString("</button>\n")
 		return
 	case "CameraView":
-		b.WriteString(fmt.Sprintf("%s<div%s>[Camera View]</div>\n", pad, attrs))
+		if ctrl, ok := node.Props["controller"].(string); ok {
+			attrs += fmt.Sprintf(" data-camera=\"%s\"", ctrl)
+		}
+		if facing, ok := node.Props["facing"].(string); ok {
+			attrs += fmt.Sprintf(" data-facing=\"%s\"", facing)
+		}
+		b.WriteString(fmt.Sprintf("%s<div%s>\n", pad, attrs))
+		b.WriteString(fmt.Sprintf("%s  <video autoplay playsinline muted style=\"width:100%%; height:100%%; object-fit:cover\"></video>\n", pad))
+		for _, child := range node.Children {
+			renderNode(b, sheet, child, indent+1)
+		}
+		b.WriteString(fmt.Sprintf("%s</div>\n", pad))
 		return
 	}
 
 	// Default open tag
 	b.WriteString(fmt.Sprintf("%s<%s%s>\n", pad, tag, attrs))
 
+	if node.Type == "Theme" {
+		renderFontFaces(b, node, pad+"  ")
+	}
+
 	// Children
 	for _, child := range node.Children {
-		renderNode(b, child, indent+1)
+		renderNode(b, sheet, child, indent+1)
 	}
 
 	// Close tag
 	b.WriteString(fmt.Sprintf("%s</%s>\n", pad, tag))
 }
 
+func renderImage(b *strings.Builder, sheet *css.Compiler, node *core.Node, src, pad string, indent int) {
+	imgDecls := []string{"width:100%"}
+	if mode := getStr(node.Props["contentMode"]); mode != "" {
+		imgDecls = append(imgDecls, "object-fit:"+mode)
+	}
+	if ratio, ok := node.Props["aspectRatio"].(float64); ok {
+		imgDecls = append(imgDecls, fmt.Sprintf("aspect-ratio:%g", ratio))
+	}
+	onload := ""
+	if ms, ok := node.Props["fadeIn"].(int); ok {
+		imgDecls = append(imgDecls, "opacity:0", fmt.Sprintf("transition:opacity %dms ease", ms))
+		onload = "this.style.opacity=1;"
+	}
+
+	if len(node.Children) == 0 {
+		b.WriteString(fmt.Sprintf("%s<img src=\"%s\" loading=\"lazy\"%s%s%s />\n", pad, src, classAttr(sheet.Class(node.Style)), eventAttr("onload", onload), declsAttr(imgDecls)))
+		return
+	}
+
+	// Placeholder and error slots are siblings of the <img>; the inline
+	// handlers swap them once the image settles.
+	b.WriteString(fmt.Sprintf("%s<div%s style=\"position:relative\">\n", pad, classAttr(sheet.Class(node.Style))))
+	onload += "this.parentNode.querySelectorAll('[data-slot=placeholder]').forEach(function(e){e.remove()});"
+	onerror := "this.style.display='none';this.parentNode.querySelectorAll('[data-slot]').forEach(function(e){e.style.display=e.dataset.slot==='error'?'':'none'});"
+	b.WriteString(fmt.Sprintf("%s  <img src=\"%s\" loading=\"lazy\"%s%s%s />\n", pad, src, eventAttr("onload", onload), eventAttr("onerror", onerror), declsAttr(imgDecls)))
+	for _, child := range node.Children {
+		slot, hidden := "placeholder", ""
+		if child.Type == "ImageError" {
+			slot, hidden = "error", " style=\"display:none\""
+		}
+		b.WriteString(fmt.Sprintf("%s  <div data-slot=\"%s\"%s>\n", pad, slot, hidden))
+		for _, c := range child.Children {
+			renderNode(b, sheet, c, indent+2)
+		}
+		b.WriteString(fmt.Sprintf("%s  </div>\n", pad))
+	}
+	b.WriteString(fmt.Sprintf("%s</div>\n", pad))
+}
+
+func renderFontFaces(b *strings.Builder, node *core.Node, pad string) {
+	fonts, ok := node.Props["fonts"].([]map[string]any)
+	if !ok || len(fonts) == 0 {
+		return
+	}
+	b.WriteString(pad + "<style>\n")
+	for _, f := range fonts {
+		b.WriteString(fmt.Sprintf("%s  @font-face { font-family: '%s'; src: url('%s'); font-weight: %d; font-style: %s; font-display: swap; }\n",
+			pad, f["family"], f["src"], f["weight"], f["style"]))
+	}
+	b.WriteString(pad + "</style>\n")
+}
+
+// inputType picks the <input> type for the keyboard and secure props.
+func inputType(node *core.Node, fallback string) string {
+	if secure, _ := node.Props["secure"].(bool); secure {
+		return "password"
+	}
+	switch getStr(node.Props["keyboard"]) {
+	case "email":
+		return "email"
+	case "phone":
+		return "tel"
+	case "url":
+		return "url"
+	}
+	return fallback
+}
+
+// inputAttrs maps the input props (core/input_props.go) to HTML attributes.
+func inputAttrs(node *core.Node) string {
+	var attrs string
+	switch getStr(node.Props["keyboard"]) {
+	case "number":
+		attrs += ` inputmode="numeric"`
+	case "decimal":
+		attrs += ` inputmode="decimal"`
+	case "phone":
+		attrs += ` inputmode="tel"`
+	}
+	if key := getStr(node.Props["returnKey"]); key != "" {
+		attrs += fmt.Sprintf(" enterkeyhint=\"%s\"", key)
+	}
+	if n, ok := node.Props["maxLength"].(int); ok {
+		attrs += fmt.Sprintf(" maxlength=\"%d\"", n)
+	}
+	if c := getStr(node.Props["autoCapitalize"]); c != "" {
+		attrs += fmt.Sprintf(" autocapitalize=\"%s\"", c)
+	}
+	if on, ok := node.Props["autoCorrect"].(bool); ok {
+		if on {
+			attrs += ` autocorrect="on" spellcheck="true"`
+		} else {
+			attrs += ` autocorrect="off" spellcheck="false"`
+		}
+	}
+	if focus, _ := node.Props["autoFocus"].(bool); focus {
+		attrs += " autofocus"
+	}
+	if id := getStr(node.Props["focusId"]); id != "" {
+		attrs += fmt.Sprintf(" data-focus-id=\"%s\"", id)
+	}
+	return attrs
+}
+
+func eventAttr(name, js string) string {
+	if js == "" {
+		return ""
+	}
+	return fmt.Sprintf(" %s=\"%s\"", name, js)
+}
+
 func getStr(v any) string {
 	if s, ok := v.(string); ok {
 		return s
@@ -130,42 +302,14 @@ func tagForType(t string) string {
 	}
 }
 
-func styleAttr(s *core.Style) string {
-	if s == nil {
+func classAttr(class string) string {
+	if class == "" {
 		return ""
 	}
-	styles := []string{}
-	if s.TextColor != "" {
-		styles = append(styles, fmt.Sprintf("color:%s", s.TextColor))
-	}
-	if s.Background != "" {
-		styles = append(styles, fmt.Sprintf("background:%s", s.Background))
-	}
-	if s.FontSize != 0 {
-		styles = append(styles, fmt.Sprintf("font-size:%dpx", s.FontSize))
-	}
-	if s.Align != "" {
-		switch s.Align {
-		case core.AlignCenter:
-			styles = append(styles, "text-align:center")
-		case core.AlignStart:
-			styles = append(styles, "text-align:left")
-		case core.AlignEnd:
-			styles = append(styles, "text-align:right")
-		}
-	}
-	if s.Display != "" {
-		styles = append(styles, fmt.Sprintf("display:%s", s.Display))
-	}
-	if s.Padding != (core.EdgeInsets{}) {
-		styles = append(styles, fmt.Sprintf("padding:%dpx %dpx %dpx %dpx", s.Padding.Top, s.Padding.Right, s.Padding.Bottom, s.Padding.Left))
-	}
-	if s.Margin != (core.EdgeInsets{}) {
-		styles = append(styles, fmt.Sprintf("margin:%dpx %dpx %dpx %dpx", s.Margin.Top, s.Margin.Right, s.Margin.Bottom, s.Margin.Left))
-	}
-	if s.BorderRadius != 0 {
-		styles = append(styles, fmt.Sprintf("border-radius:%dpx", s.BorderRadius))
-	}
+	return fmt.Sprintf(" class=\"%s\"", class)
+}
+
+func declsAttr(styles []string) string {
 	if len(styles) == 0 {
 		return ""
 	}
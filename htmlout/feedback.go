@@ -0,0 +1,58 @@
+package htmlout
+
+import (
+	"fmt"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// feedbackCSS holds the keyframes the themes' Animation values name and the
+// parts of the feedback components (core/feedback.go) a Style can't express:
+// the Spinner's ring and the Avatar picture filling its circle.
+const feedbackCSS = `@keyframes gv-spin { to { transform: rotate(360deg); } }
+@keyframes gv-pulse { 50% { opacity: 0.4; } }
+@keyframes gv-indeterminate { from { left: -40%; } to { left: 100%; } }
+[data-gv-feedback=Spinner] { display: inline-block; box-sizing: border-box; border: 3px solid transparent; border-top-color: currentColor; border-right-color: currentColor; border-radius: 50%; }
+[data-gv-feedback=Avatar] { box-sizing: border-box; flex-shrink: 0; }
+[data-gv-feedback=Avatar] img { height: 100%; object-fit: cover; border-radius: inherit; }
+[data-gv-feedback=Avatar] [data-slot=error] { position: absolute; inset: 0; display: flex; align-items: center; justify-content: center; }
+[data-gv-feedback=Snackbar] > button { flex-shrink: 0; }
+@media (prefers-reduced-motion: reduce) { [data-gv-feedback], [data-gv-feedback] > * { animation-duration: 3s !important; } }
+`
+
+func usesFeedback(node *core.Node) bool {
+	if node == nil {
+		return false
+	}
+	if feedbackAttrs(node) != "" {
+		return true
+	}
+	for _, child := range node.Children {
+		if usesFeedback(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// feedbackAttrs tags a feedback node for feedbackCSS and gives it its ARIA
+// role.
+func feedbackAttrs(node *core.Node) string {
+	switch node.Type {
+	case "ProgressBar":
+		attrs := " data-gv-feedback=\"ProgressBar\" role=\"progressbar\""
+		if progress, ok := node.Props["progress"].(float64); ok {
+			attrs += fmt.Sprintf(" aria-valuemin=\"0\" aria-valuemax=\"100\" aria-valuenow=\"%g\"", progress*100)
+		}
+		return attrs
+	case "Spinner":
+		return " data-gv-feedback=\"Spinner\" role=\"progressbar\""
+	case "Skeleton":
+		return " data-gv-feedback=\"Skeleton\" aria-hidden=\"true\""
+	case "Snackbar":
+		return " data-gv-feedback=\"Snackbar\" role=\"status\" aria-live=\"polite\""
+	case "Avatar", "Badge":
+		return fmt.Sprintf(" data-gv-feedback=\"%s\"", node.Type)
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package htmlout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// stackCSS puts every child of a Stack in its one grid cell.
+const stackCSS = `[data-gv-layout=Stack] > * { grid-area: 1 / 1; }
+`
+
+func usesStack(node *core.Node) bool {
+	if node == nil {
+		return false
+	}
+	if node.Type == "Stack" {
+		return true
+	}
+	for _, child := range node.Children {
+		if usesStack(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// layoutAttrs renders what a Grid, GridItem or Stack (core/grid.go) keeps
+// in its props as inline CSS grid declarations.
+func layoutAttrs(node *core.Node) string {
+	var decls []string
+	switch node.Type {
+	case "Grid":
+		if tracks, ok := node.Props["columns"].([]map[string]any); ok {
+			decls = append(decls, "grid-template-columns:"+gridTemplate(tracks))
+		}
+	case "GridItem":
+		decls = append(decls,
+			fmt.Sprintf("grid-column:span %v", node.Props["columns"]),
+			fmt.Sprintf("grid-row:span %v", node.Props["rows"]))
+	case "Stack":
+	default:
+		return ""
+	}
+	if v := getStr(node.Props["justifyItems"]); v != "" {
+		decls = append(decls, "justify-items:"+v)
+	}
+	if v := getStr(node.Props["alignItems"]); v != "" {
+		decls = append(decls, "align-items:"+v)
+	}
+	attrs := declsAttr(decls)
+	if node.Type == "Stack" {
+		attrs += " data-gv-layout=\"Stack\""
+	}
+	return attrs
+}
+
+func gridTemplate(tracks []map[string]any) string {
+	parts := make([]string, len(tracks))
+	for i, t := range tracks {
+		switch t["kind"] {
+		case "fixed":
+			parts[i] = fmt.Sprintf("%gpx", t["size"])
+		case "adaptive":
+			max := "1fr"
+			if m, ok := t["max"].(float64); ok {
+				max = fmt.Sprintf("%gpx", m)
+			}
+			parts[i] = fmt.Sprintf("repeat(auto-fill, minmax(%gpx, %s))", t["min"], max)
+		default:
+			parts[i] = fmt.Sprintf("minmax(0, %gfr)", t["size"])
+		}
+	}
+	return strings.Join(parts, " ")
+}
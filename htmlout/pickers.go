@@ -0,0 +1,77 @@
+package htmlout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// Pickers (core/picker.go) export as native controls: a <select> for Select
+// and date/time inputs, which the browser renders in its own locale; "lang"
+// carries the app's.
+
+func renderSelect(b *strings.Builder, node *core.Node, attrs, pad string) {
+	selected := map[int]bool{}
+	if list, ok := node.Props["selected"].([]int); ok {
+		for _, i := range list {
+			selected[i] = true
+		}
+	}
+	if multiple, _ := node.Props["multiple"].(bool); multiple {
+		attrs += " multiple"
+	}
+	b.WriteString(fmt.Sprintf("%s<select%s%s>\n", pad, langAttr(node), attrs))
+	if ph := getStr(node.Props["placeholder"]); ph != "" {
+		empty := ""
+		if len(selected) == 0 {
+			empty = " selected"
+		}
+		b.WriteString(fmt.Sprintf("%s  <option value=\"\" disabled%s>%s</option>\n", pad, empty, ph))
+	}
+	options, _ := node.Props["options"].([]map[string]any)
+	for i, opt := range options {
+		extra := ""
+		if selected[i] {
+			extra += " selected"
+		}
+		if disabled, _ := opt["disabled"].(bool); disabled {
+			extra += " disabled"
+		}
+		b.WriteString(fmt.Sprintf("%s  <option value=\"%d\"%s>%s</option>\n", pad, i, extra, getStr(opt["label"])))
+	}
+	b.WriteString(fmt.Sprintf("%s</select>\n", pad))
+}
+
+func renderDateInput(b *strings.Builder, node *core.Node, kind, value, attrs, pad string) {
+	b.WriteString(fmt.Sprintf("%s<input type=\"%s\" value=\"%s\"%s%s%s />\n", pad, kind, value, boundAttrs(node), langAttr(node), attrs))
+}
+
+func renderDateRange(b *strings.Builder, node *core.Node, attrs, pad string) {
+	inner := boundAttrs(node) + langAttr(node)
+	if disabled, _ := node.Props["disabled"].(bool); disabled {
+		inner += " disabled"
+	}
+	b.WriteString(fmt.Sprintf("%s<div role=\"group\"%s>\n", pad, attrs))
+	b.WriteString(fmt.Sprintf("%s  <input type=\"date\" value=\"%s\" aria-label=\"Start\"%s />\n", pad, getStr(node.Props["start"]), inner))
+	b.WriteString(fmt.Sprintf("%s  <input type=\"date\" value=\"%s\" aria-label=\"End\"%s />\n", pad, getStr(node.Props["end"]), inner))
+	b.WriteString(fmt.Sprintf("%s</div>\n", pad))
+}
+
+func boundAttrs(node *core.Node) string {
+	var attrs string
+	if min := getStr(node.Props["min"]); min != "" {
+		attrs += fmt.Sprintf(" min=\"%s\"", min)
+	}
+	if max := getStr(node.Props["max"]); max != "" {
+		attrs += fmt.Sprintf(" max=\"%s\"", max)
+	}
+	return attrs
+}
+
+func langAttr(node *core.Node) string {
+	if locale := getStr(node.Props["locale"]); locale != "" {
+		return fmt.Sprintf(" lang=\"%s\"", locale)
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package htmlout
+
+import (
+	"strings"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// stateAttrs renders the disabled/selected attributes the generated state
+// rules key off.
+func stateAttrs(node *core.Node) string {
+	var attrs []string
+	if disabled, _ := node.Props["disabled"].(bool); disabled {
+		switch node.Type {
+		case "Button", "Input", "InputPassword", "NumericInput", "TextArea", "Checkbox", "Switch", "Slider",
+			"Select", "DatePicker", "TimePicker":
+			attrs = append(attrs, " disabled")
+		default:
+			attrs = append(attrs, " aria-disabled=\"true\"")
+		}
+	}
+	if selected, _ := node.Props["selected"].(bool); selected {
+		attrs = append(attrs, " aria-selected=\"true\"")
+	}
+	return strings.Join(attrs, "")
+}
@@ -0,0 +1,336 @@
+package imagecache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var ErrUnsupportedSource = errors.New("imagecache: unsupported image source")
+
+type Options struct {
+	// MemoryBytes caps the in-memory tier. Zero means 16 MiB.
+	MemoryBytes int
+	// Dir enables the disk tier when non-empty.
+	Dir string
+	// Fetch allows http(s) sources to be downloaded and processed in Go.
+	Fetch bool
+	// Assets serves asset:// sources, usually the embed.FS given to
+	// assets.Register.
+	Assets fs.FS
+	// Quality is the JPEG quality used for generated thumbnails.
+	Quality int
+	// BaseURL prefixes the thumbnail URLs handed to the renderers. Zero means
+	// "/_govinci/thumbs/", where the Cache is mounted as an http.Handler.
+	BaseURL string
+}
+
+// Cache keeps decoded and resized images in a memory LRU backed by an
+// optional disk directory, so a thumbnail is generated once and reused.
+type Cache struct {
+	opts Options
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	size    int
+
+	inflight map[string]*sync.WaitGroup
+	names    map[string]thumb // thumbnail file name -> what it was made from
+}
+
+type thumb struct {
+	key           string
+	src           string
+	width, height int
+}
+
+type entry struct {
+	key  string
+	data []byte
+	mime string
+}
+
+func New(opts Options) *Cache {
+	if opts.MemoryBytes == 0 {
+		opts.MemoryBytes = 16 << 20
+	}
+	if opts.Quality == 0 {
+		opts.Quality = 85
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = "/_govinci/thumbs/"
+	}
+	return &Cache{
+		opts:     opts,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		inflight: make(map[string]*sync.WaitGroup),
+		names:    make(map[string]thumb),
+	}
+}
+
+// Get looks the key up in memory first, then on disk.
+func (c *Cache) Get(key string) ([]byte, string, bool) {
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		e := el.Value.(*entry)
+		c.mu.Unlock()
+		return e.data, e.mime, true
+	}
+	c.mu.Unlock()
+
+	data, mime, ok := c.readDisk(key)
+	if ok {
+		c.putMemory(key, data, mime)
+	}
+	return data, mime, ok
+}
+
+func (c *Cache) Put(key string, data []byte, mime string) {
+	c.putMemory(key, data, mime)
+	c.writeDisk(key, data, mime)
+}
+
+func (c *Cache) putMemory(key string, data []byte, mime string) {
+	if len(data) > c.opts.MemoryBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= len(el.Value.(*entry).data)
+		c.order.Remove(el)
+	}
+	c.entries[key] = c.order.PushFront(&entry{key: key, data: data, mime: mime})
+	c.size += len(data)
+
+	for c.size > c.opts.MemoryBytes {
+		last := c.order.Back()
+		e := last.Value.(*entry)
+		c.order.Remove(last)
+		delete(c.entries, e.key)
+		c.size -= len(e.data)
+	}
+}
+
+func (c *Cache) diskPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.opts.Dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *Cache) readDisk(key string) ([]byte, string, bool) {
+	if c.opts.Dir == "" {
+		return nil, "", false
+	}
+	raw, err := os.ReadFile(c.diskPath(key))
+	if err != nil {
+		return nil, "", false
+	}
+	// first line holds the mime type
+	mime, data, ok := strings.Cut(string(raw), "\n")
+	if !ok {
+		return nil, "", false
+	}
+	return []byte(data), mime, true
+}
+
+func (c *Cache) writeDisk(key string, data []byte, mime string) {
+	if c.opts.Dir == "" {
+		return
+	}
+	if err := os.MkdirAll(c.opts.Dir, 0o755); err != nil {
+		return
+	}
+	buf := make([]byte, 0, len(mime)+1+len(data))
+	buf = append(buf, mime...)
+	buf = append(buf, '\n')
+	buf = append(buf, data...)
+	tmp := c.diskPath(key) + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, c.diskPath(key))
+}
+
+// Load returns the raw bytes of a data: URL, a bundled asset, a local file or,
+// when Fetch is enabled, a remote URL.
+func (c *Cache) Load(src string) ([]byte, string, error) {
+	switch {
+	case strings.HasPrefix(src, "data:"):
+		return decodeDataURL(src)
+	case strings.HasPrefix(src, "http://"), strings.HasPrefix(src, "https://"):
+		if !c.opts.Fetch {
+			return nil, "", ErrUnsupportedSource
+		}
+		if data, mime, ok := c.Get("src:" + src); ok {
+			return data, mime, nil
+		}
+		resp, err := http.Get(src)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, "", fmt.Errorf("imagecache: %s: %s", src, resp.Status)
+		}
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, "", err
+		}
+		mime := resp.Header.Get("Content-Type")
+		c.writeDisk("src:"+src, data, mime)
+		return data, mime, nil
+	case strings.HasPrefix(src, "asset://"):
+		if c.opts.Assets == nil {
+			return nil, "", ErrUnsupportedSource
+		}
+		data, err := fs.ReadFile(c.opts.Assets, strings.TrimPrefix(src, "asset://"))
+		if err != nil {
+			return nil, "", err
+		}
+		return data, "", nil
+	case strings.Contains(src, "://"):
+		return nil, "", ErrUnsupportedSource
+	default:
+		data, err := os.ReadFile(strings.TrimPrefix(src, "file://"))
+		if err != nil {
+			return nil, "", err
+		}
+		return data, "", nil
+	}
+}
+
+// Thumbnail implements core.ImageProcessor. The result is a short URL under
+// Options.BaseURL naming the cached file; serve it with ServeHTTP, or look
+// it up with Open on hosts that intercept the requests themselves.
+func (c *Cache) Thumbnail(src string, width, height int) (string, error) {
+	key := fmt.Sprintf("thumb:%dx%d:%s", width, height, src)
+
+	for {
+		if _, mime, ok := c.Get(key); ok {
+			return c.opts.BaseURL + c.name(thumb{key, src, width, height}, mime), nil
+		}
+
+		c.mu.Lock()
+		if wg, busy := c.inflight[key]; busy {
+			c.mu.Unlock()
+			wg.Wait()
+			continue
+		}
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		c.inflight[key] = wg
+		c.mu.Unlock()
+
+		data, mime, err := c.generate(src, width, height)
+
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+		wg.Done()
+
+		if err != nil {
+			return "", err
+		}
+		c.Put(key, data, mime)
+		return c.opts.BaseURL + c.name(thumb{key, src, width, height}, mime), nil
+	}
+}
+
+// name is the file name a thumbnail is served under: a hash of its key and
+// the extension of its format.
+func (c *Cache) name(t thumb, mime string) string {
+	sum := sha256.Sum256([]byte(t.key))
+	name := hex.EncodeToString(sum[:8]) + extension(mime)
+
+	c.mu.Lock()
+	c.names[name] = t
+	c.mu.Unlock()
+	return name
+}
+
+// Open returns a thumbnail by the name in its URL. Only thumbnails handed
+// out by this Cache are found; one the memory LRU has dropped, with no disk
+// tier behind it, is generated again, since its URL is still in use.
+func (c *Cache) Open(name string) ([]byte, string, bool) {
+	c.mu.Lock()
+	t, ok := c.names[name]
+	c.mu.Unlock()
+	if !ok {
+		return nil, "", false
+	}
+	if data, mime, ok := c.Get(t.key); ok {
+		return data, mime, true
+	}
+	data, mime, err := c.generate(t.src, t.width, t.height)
+	if err != nil {
+		return nil, "", false
+	}
+	c.Put(t.key, data, mime)
+	return data, mime, true
+}
+
+// ServeHTTP serves thumbnails by their URL. Mount it at Options.BaseURL:
+//
+//	http.Handle("/_govinci/thumbs/", cache)
+func (c *Cache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, mime, ok := c.Open(path.Base(r.URL.Path))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", mime)
+	// names are content-addressed by source and size
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Write(data)
+}
+
+func (c *Cache) generate(src string, width, height int) ([]byte, string, error) {
+	raw, _, err := c.Load(src)
+	if err != nil {
+		return nil, "", err
+	}
+	img, format, err := Decode(raw)
+	if err != nil {
+		return nil, "", err
+	}
+	return Encode(Fit(img, width, height), format, c.opts.Quality)
+}
+
+func decodeDataURL(src string) ([]byte, string, error) {
+	header, body, ok := strings.Cut(strings.TrimPrefix(src, "data:"), ",")
+	if !ok {
+		return nil, "", ErrUnsupportedSource
+	}
+	mime, isBase64 := strings.CutSuffix(header, ";base64")
+	if !isBase64 {
+		return []byte(body), mime, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, mime, nil
+}
+
+func extension(mime string) string {
+	if mime == "image/png" {
+		return ".png"
+	}
+	return ".jpg"
+}
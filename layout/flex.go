@@ -0,0 +1,458 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// container is how a node arranges its children.
+type container struct {
+	row     bool // main axis is horizontal
+	wrap    bool
+	block   bool // no gaps, grow or shrink
+	scroll  bool // content is not limited along the main axis
+	justify core.JustifyContent
+	align   core.AlignItems
+	mainGap float64
+	lineGap float64
+}
+
+func containerOf(n *core.Node, st *core.Style) container {
+	c := container{justify: st.JustifyContent, align: st.AlignItems}
+	switch {
+	case n.Type == "Scroll":
+		c.scroll = true
+		c.row = n.Props["direction"] == "horizontal"
+	case n.Type == "Row", n.Type == "Column", st.Display == core.DisplayFlex:
+		c.row = n.Type != "Column"
+	default:
+		c.block = true
+		c.justify, c.align = "", core.AlignItemsStretch
+		return c
+	}
+	if st.FlexDirection != "" {
+		c.row = st.FlexDirection == core.FlexRow
+	}
+	c.wrap = st.FlexWrap == "wrap"
+
+	rowGap, columnGap := st.Gap, st.Gap
+	if st.RowGap != 0 {
+		rowGap = st.RowGap
+	}
+	if st.ColumnGap != 0 {
+		columnGap = st.ColumnGap
+	}
+	c.mainGap, c.lineGap = rowGap, columnGap
+	if c.row {
+		c.mainGap, c.lineGap = columnGap, rowGap
+	}
+	return c
+}
+
+// axes reads and builds sizes along the main and cross axes of c.
+func (c container) main(s Size) float64 {
+	if c.row {
+		return s.Width
+	}
+	return s.Height
+}
+
+func (c container) cross(s Size) float64 {
+	if c.row {
+		return s.Height
+	}
+	return s.Width
+}
+
+func (c container) size(main, cross float64) Size {
+	if c.row {
+		return Size{main, cross}
+	}
+	return Size{cross, main}
+}
+
+type item struct {
+	index int
+	node  *core.Node
+	path  string
+	style *core.Style
+	align core.AlignItems
+
+	// margins along each axis, and the one before the item
+	marginMain, marginCross float64
+	leadMain, leadCross     float64
+
+	grow, shrink       float64
+	base, hypothetical float64
+	minMain, maxMain   float64
+	minCross, maxCross float64
+	autoCross          bool
+
+	main, cross float64
+	frame       *Frame
+}
+
+type line struct {
+	items []*item
+	space float64 // main size the line is justified in
+	cross float64
+}
+
+// flex lays the in-flow children of f out along c's main axis inside a
+// content box of width x height (either may be auto) at box's offset, and
+// returns the size they take.
+func (e *engine) flex(f *Frame, c container, box edges, width, height float64, avail Size) Size {
+	innerMain, innerCross := c.main(Size{width, height}), c.cross(Size{width, height})
+	limitMain, limitCross := c.main(avail), c.cross(avail)
+	if c.scroll {
+		innerMain, limitMain = auto, math.Inf(1)
+	}
+	if !isAuto(innerMain) {
+		limitMain = innerMain
+	}
+	if !isAuto(innerCross) {
+		limitCross = innerCross
+	}
+
+	var items []*item
+	for i, child := range f.Node.Children {
+		path := childPath(f.Path, i)
+		cs := styleOf(child)
+		if cs.Display == core.DisplayNone {
+			f.Children[i] = hidden(child, path)
+			continue
+		}
+		if cs.Position == core.PositionAbsolute || cs.Position == core.PositionFixed {
+			continue
+		}
+		items = append(items, e.item(c, i, child, path, innerMain, innerCross, limitMain, limitCross))
+	}
+
+	var lines []*line
+	var current *line
+	used := 0.0
+	for _, it := range items {
+		outer := it.hypothetical + it.marginMain
+		if current != nil && c.wrap && used+c.mainGap+outer > limitMain {
+			current = nil
+		}
+		if current == nil {
+			current = &line{}
+			lines = append(lines, current)
+			used = 0
+		} else {
+			used += c.mainGap
+		}
+		used += outer
+		current.items = append(current.items, it)
+	}
+
+	for _, ln := range lines {
+		used := c.mainGap * float64(len(ln.items)-1)
+		for _, it := range ln.items {
+			used += it.hypothetical + it.marginMain
+		}
+		ln.space = innerMain
+		if isAuto(ln.space) {
+			ln.space = math.Min(used, limitMain)
+		}
+		if c.block {
+			for _, it := range ln.items {
+				it.main = it.hypothetical
+			}
+		} else {
+			flexLine(ln.items, ln.space-used)
+		}
+		for _, it := range ln.items {
+			e.lay(c, it, it.cross, limitCross)
+			ln.cross = math.Max(ln.cross, c.cross(frameSize(it.frame))+it.marginCross)
+		}
+	}
+	if len(lines) == 1 && !isAuto(innerCross) {
+		lines[0].cross = innerCross
+	}
+
+	// stretch what sized its cross axis to its content to the line
+	for _, ln := range lines {
+		for _, it := range ln.items {
+			if it.align != core.AlignItemsStretch || !it.autoCross {
+				continue
+			}
+			cross := clamp(ln.cross-it.marginCross, it.minCross, it.maxCross)
+			if cross != c.cross(frameSize(it.frame)) {
+				e.lay(c, it, cross, limitCross)
+			}
+		}
+	}
+
+	var content Size
+	lineStart := 0.0
+	for li, ln := range lines {
+		used := c.mainGap * float64(len(ln.items)-1)
+		for _, it := range ln.items {
+			used += c.main(frameSize(it.frame)) + it.marginMain
+		}
+		start, between := justify(c.justify, ln.space-used, len(ln.items))
+		between += c.mainGap
+
+		pos := start
+		for _, it := range ln.items {
+			size := frameSize(it.frame)
+			room := ln.cross - c.cross(size) - it.marginCross
+			var crossOffset float64
+			switch it.align {
+			case core.AlignItemsCenter:
+				crossOffset = room / 2
+			case core.AlignItemsEnd, "end":
+				crossOffset = room
+			}
+			at := c.size(pos+it.leadMain, lineStart+crossOffset+it.leadCross)
+			it.frame.X, it.frame.Y = box.Left+at.Width, box.Top+at.Height
+			e.offset(it.frame, it.style, Size{width, height})
+			f.Children[it.index] = it.frame
+			pos += c.main(size) + it.marginMain + between
+		}
+
+		content = c.size(math.Max(c.main(content), used), lineStart+ln.cross)
+		lineStart += ln.cross
+		if li < len(lines)-1 {
+			lineStart += c.lineGap
+		}
+	}
+	return content
+}
+
+// item resolves what c needs to know about a child before flexing it: its
+// margins, bounds, alignment and hypothetical main size.
+func (e *engine) item(c container, index int, n *core.Node, path string, innerMain, innerCross, limitMain, limitCross float64) *item {
+	st := styleOf(n)
+	m := insets(st.Margin)
+	it := &item{
+		index:  index,
+		node:   n,
+		path:   path,
+		style:  st,
+		align:  st.AlignSelf,
+		grow:   st.FlexGrow,
+		shrink: st.FlexShrink,
+	}
+	if it.align == "" {
+		it.align = c.align
+	}
+	switch it.align {
+	case "", "normal":
+		it.align = core.AlignItemsStretch
+	case "start":
+		it.align = core.AlignItemsStart
+	}
+	if it.shrink == 0 {
+		// zero is an unset FlexShrink, which CSS takes as 1
+		it.shrink = 1
+	}
+	if c.block {
+		it.grow, it.shrink = 0, 0
+	}
+
+	var mainSize, crossSize string
+	if c.row {
+		it.marginMain, it.marginCross = m.horizontal(), m.vertical()
+		it.leadMain, it.leadCross = m.Left, m.Top
+		mainSize, crossSize = st.Width, st.Height
+		it.minMain, it.maxMain = e.length(st.MinWidth, innerMain), e.length(st.MaxWidth, innerMain)
+		it.minCross, it.maxCross = e.length(st.MinHeight, innerCross), auto
+	} else {
+		it.marginMain, it.marginCross = m.vertical(), m.horizontal()
+		it.leadMain, it.leadCross = m.Top, m.Left
+		mainSize, crossSize = st.Height, st.Width
+		it.minMain, it.maxMain = e.length(st.MinHeight, innerMain), auto
+		it.minCross, it.maxCross = e.length(st.MinWidth, innerCross), e.length(st.MaxWidth, innerCross)
+	}
+
+	it.cross = e.length(crossSize, innerCross)
+	it.autoCross = isAuto(it.cross)
+	if it.autoCross && it.align == core.AlignItemsStretch && !c.wrap && !isAuto(innerCross) {
+		it.cross = innerCross - it.marginCross
+	}
+	it.cross = clamp(it.cross, it.minCross, it.maxCross)
+
+	if n.Type == "Spacer" {
+		it.base = number(n.Props["size"])
+	} else if basis := e.length(st.FlexBasis, innerMain); !isAuto(basis) {
+		it.base = basis
+	} else if size := e.length(mainSize, innerMain); !isAuto(size) {
+		it.base = size
+	} else {
+		avail := c.size(limitMain-it.marginMain, limitCross-it.marginCross)
+		at := c.size(auto, it.cross)
+		it.base = c.main(frameSize(e.layout(n, path, at.Width, at.Height, avail)))
+	}
+	it.hypothetical = clamp(it.base, it.minMain, it.maxMain)
+	return it
+}
+
+// lay lays it out at its resolved main size and the given cross size.
+func (e *engine) lay(c container, it *item, cross, limitCross float64) {
+	at := c.size(it.main, cross)
+	it.frame = e.layout(it.node, it.path, at.Width, at.Height, c.size(it.main, limitCross-it.marginCross))
+}
+
+// flexLine hands free space out to the items of a line in proportion to
+// their FlexGrow, or takes it back in proportion to FlexShrink times their
+// base size. An item its bounds stop is frozen at them and the rest is
+// shared again among the others.
+func flexLine(items []*item, free float64) {
+	for _, it := range items {
+		it.main = it.hypothetical
+	}
+	if free == 0 {
+		return
+	}
+	grow := free > 0
+	factor := func(it *item) float64 {
+		if grow {
+			return it.grow
+		}
+		return it.shrink * it.base
+	}
+	frozen := make([]bool, len(items))
+	for i, it := range items {
+		frozen[i] = factor(it) == 0
+	}
+	for {
+		left, weight := free, 0.0
+		for i, it := range items {
+			if frozen[i] {
+				left -= it.main - it.hypothetical
+			} else {
+				weight += factor(it)
+			}
+		}
+		if weight == 0 {
+			return
+		}
+		if grow && weight < 1 {
+			// grow factors adding up to less than 1 take only that share
+			left *= weight
+		}
+		clamped := false
+		for i, it := range items {
+			if frozen[i] {
+				continue
+			}
+			target := it.hypothetical + left*factor(it)/weight
+			it.main = clamp(target, it.minMain, it.maxMain)
+			if it.main != target {
+				frozen[i] = true
+				clamped = true
+			}
+		}
+		if !clamped {
+			return
+		}
+	}
+}
+
+// justify returns where the first item of a line starts and the extra space
+// between items, for free space left on the line.
+func justify(j core.JustifyContent, free float64, count int) (start, between float64) {
+	if free <= 0 {
+		return 0, 0
+	}
+	switch j {
+	case core.JustifyCenter:
+		return free / 2, 0
+	case core.JustifyEnd, "end":
+		return free, 0
+	case core.JustifyBetween:
+		if count > 1 {
+			return 0, free / float64(count-1)
+		}
+	case core.JustifyAround:
+		return free / float64(count) / 2, free / float64(count)
+	case core.JustifyEvenly:
+		return free / float64(count+1), free / float64(count+1)
+	}
+	return 0, 0
+}
+
+// stack places the in-flow children of f on top of each other inside its
+// content box, aligned by the justifyItems and alignItems props of a Stack
+// (center unless set).
+func (e *engine) stack(f *Frame, box edges, width, height float64, avail Size) Size {
+	horizontal, _ := f.Node.Props["justifyItems"].(string)
+	vertical, _ := f.Node.Props["alignItems"].(string)
+	limit := avail
+	if !isAuto(width) {
+		limit.Width = width
+	}
+	if !isAuto(height) {
+		limit.Height = height
+	}
+
+	var content Size
+	lay := func(i int, child *core.Node, fill Size) *Frame {
+		m := insets(styleOf(child).Margin)
+		w, h := auto, auto
+		if horizontal == string(core.AlignStretch) && !isAuto(fill.Width) {
+			w = fill.Width - m.horizontal()
+		}
+		if vertical == string(core.AlignStretch) && !isAuto(fill.Height) {
+			h = fill.Height - m.vertical()
+		}
+		return e.layout(child, childPath(f.Path, i), w, h, Size{limit.Width - m.horizontal(), limit.Height - m.vertical()})
+	}
+	var children []int
+	for i, child := range f.Node.Children {
+		cs := styleOf(child)
+		if cs.Display == core.DisplayNone {
+			f.Children[i] = hidden(child, childPath(f.Path, i))
+			continue
+		}
+		if cs.Position == core.PositionAbsolute || cs.Position == core.PositionFixed {
+			continue
+		}
+		m := insets(cs.Margin)
+		c := lay(i, child, Size{width, height})
+		content.Width = math.Max(content.Width, c.Width+m.horizontal())
+		content.Height = math.Max(content.Height, c.Height+m.vertical())
+		f.Children[i] = c
+		children = append(children, i)
+	}
+
+	area := Size{width, height}
+	if isAuto(area.Width) {
+		area.Width = content.Width
+	}
+	if isAuto(area.Height) {
+		area.Height = content.Height
+	}
+	place := func(alignment string, room float64) float64 {
+		switch alignment {
+		case string(core.AlignStart), string(core.AlignStretch):
+			return 0
+		case string(core.AlignEnd):
+			return room
+		}
+		return room / 2
+	}
+	for _, i := range children {
+		child := f.Node.Children[i]
+		cs := styleOf(child)
+		m := insets(cs.Margin)
+		c := f.Children[i]
+		if c.Width+m.horizontal() != area.Width && horizontal == string(core.AlignStretch) ||
+			c.Height+m.vertical() != area.Height && vertical == string(core.AlignStretch) {
+			c = lay(i, child, area)
+			f.Children[i] = c
+		}
+		c.X = box.Left + m.Left + place(horizontal, area.Width-c.Width-m.horizontal())
+		c.Y = box.Top + m.Top + place(vertical, area.Height-c.Height-m.vertical())
+		e.offset(c, cs, Size{width, height})
+	}
+	return content
+}
+
+func frameSize(f *Frame) Size {
+	return Size{f.Width, f.Height}
+}
@@ -0,0 +1,330 @@
+// Package layout computes where each node of a rendered tree goes, for
+// renderers that place views themselves and for geometry tests. The built-in
+// renderers do not use it yet: the web lays out with CSS and Android with its
+// own layouts. It implements the part of flexbox the Style fields describe:
+// FlexDirection, JustifyContent, AlignItems and AlignSelf, FlexGrow,
+// FlexShrink and FlexBasis, FlexWrap, Gap, Padding, Margin, BorderWidth,
+// Width, Height and their Min/Max bounds, and absolute, fixed and relative
+// Position with Top, Left, Right and Bottom.
+//
+//	root := app.Render(ctx)
+//	frames := layout.Compute(root, layout.Size{Width: 390, Height: 844}, layout.Monospace)
+//	title := frames.Find("root/0/1") // {X: 16, Y: 12, Width: 358, Height: 19.2}
+//
+// Row and Column lay their children out as flex containers in their
+// direction, as does anything with Display flex (a row unless FlexDirection
+// says otherwise); a Scroll is a Column, or a Row when horizontal, whose
+// content is not limited by its size. A Stack places its children on top of
+// each other. Everything else is a block: children stacked top to bottom at
+// its full width, without gaps, grow or shrink. Grid tracks are left to the
+// renderers; here a Grid is a block.
+//
+// Sizes are border-box: Width and Height include padding and border. Lengths
+// are px ("24px" or "24"), percentages of the parent's content box, or vw
+// and vh of the viewport; anything else ("auto", "fit-content", calc) sizes
+// the node to its content. An absolute child is positioned in its parent's
+// padding box, a fixed one in the viewport.
+package layout
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/GraHms/govinci/core"
+)
+
+type Size struct {
+	Width, Height float64
+}
+
+// Frame is where one node goes: its border box in the coordinates of the
+// viewport, margins outside it. Children mirror Node.Children, hidden ones
+// included as empty frames, so a frame's Path matches the patch target of
+// its node.
+type Frame struct {
+	Node     *core.Node
+	Path     string // "root", "root/0", "root/0/2"...
+	X, Y     float64
+	Width    float64
+	Height   float64
+	Children []*Frame
+}
+
+// Find returns the frame at path, or nil.
+func (f *Frame) Find(path string) *Frame {
+	if f == nil {
+		return nil
+	}
+	if f.Path == path {
+		return f
+	}
+	if !strings.HasPrefix(path, f.Path+"/") {
+		return nil
+	}
+	for _, child := range f.Children {
+		if found := child.Find(path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Compute lays root out in a viewport of the given size and returns its
+// frame. root is as wide as the viewport unless its style sets a Width, and
+// as tall as its content unless it sets a Height. measurer sizes the text of
+// Text and Button nodes; nil means Monospace.
+func Compute(root *core.Node, viewport Size, measurer Measurer) *Frame {
+	if root == nil {
+		return nil
+	}
+	if measurer == nil {
+		measurer = Monospace
+	}
+	e := &engine{measurer: measurer, viewport: viewport, cache: map[cacheKey]*Frame{}}
+
+	st := styleOf(root)
+	margin := insets(st.Margin)
+	avail := Size{viewport.Width - margin.horizontal(), viewport.Height - margin.vertical()}
+	width := e.length(st.Width, viewport.Width)
+	if isAuto(width) {
+		width = avail.Width
+	}
+	f := e.layout(root, "root", width, auto, avail)
+	f.X, f.Y = margin.Left, margin.Top
+	f.place(0, 0)
+	return f
+}
+
+// auto marks a size the node takes from its content.
+var auto = math.NaN()
+
+func isAuto(v float64) bool { return math.IsNaN(v) }
+
+type cacheKey struct {
+	node           *core.Node
+	width, height  float64
+	availW, availH float64
+}
+
+type engine struct {
+	measurer Measurer
+	viewport Size
+	cache    map[cacheKey]*Frame
+}
+
+// layout sizes n and lays its children out. width and height are its border
+// box when the parent has decided them, auto otherwise; avail is the room it
+// may take when sizing itself and the base of its percentages. The frame is
+// at 0,0 and its children relative to it until place.
+//
+// Parents lay a child out more than once (to measure, to flex, to stretch),
+// so results are cached per size. A hit is a copy, which its caller is free
+// to move.
+func (e *engine) layout(n *core.Node, path string, width, height float64, avail Size) *Frame {
+	key := cacheKey{n, keyOf(width), keyOf(height), keyOf(avail.Width), keyOf(avail.Height)}
+	if f, ok := e.cache[key]; ok {
+		c := *f
+		return &c
+	}
+
+	st := styleOf(n)
+	if st.Display == core.DisplayNone {
+		f := hidden(n, path)
+		e.cache[key] = f
+		return f
+	}
+
+	f := &Frame{Node: n, Path: path}
+	box := insets(st.Padding).plus(st.BorderWidth)
+	if isAuto(width) {
+		width = e.length(st.Width, avail.Width)
+	}
+	if isAuto(height) {
+		height = e.length(st.Height, avail.Height)
+	}
+	minW, maxW := e.length(st.MinWidth, avail.Width), e.length(st.MaxWidth, avail.Width)
+	minH := e.length(st.MinHeight, avail.Height)
+	width = clamp(width, minW, maxW)
+	height = clamp(height, minH, auto)
+
+	inner := Size{avail.Width - box.horizontal(), avail.Height - box.vertical()}
+	if !isAuto(maxW) {
+		inner.Width = math.Min(inner.Width, maxW-box.horizontal())
+	}
+	content := e.content(f, st, box, width-box.horizontal(), height-box.vertical(), inner)
+
+	w, h := width, height
+	if isAuto(w) {
+		w = clamp(content.Width+box.horizontal(), minW, maxW)
+	}
+	if isAuto(h) {
+		h = clamp(content.Height+box.vertical(), minH, auto)
+	}
+	if (isAuto(width) && w != content.Width+box.horizontal()) || (isAuto(height) && h != content.Height+box.vertical()) {
+		// a bound changed the size the content asked for; justify and
+		// align against the final box
+		inner := Size{w - box.horizontal(), h - box.vertical()}
+		e.content(f, st, box, inner.Width, inner.Height, inner)
+	}
+	f.Width, f.Height = w, h
+
+	e.absolute(f, st)
+	e.cache[key] = f
+	return f
+}
+
+// content lays out the text or the children of f inside its content box
+// and returns the size they take.
+func (e *engine) content(f *Frame, st *core.Style, box edges, width, height float64, avail Size) Size {
+	n := f.Node
+	f.Children = make([]*Frame, len(n.Children))
+	if text, ok := textOf(n); ok {
+		limit := width
+		if isAuto(limit) {
+			limit = avail.Width
+		}
+		return e.measurer.MeasureText(text, st, math.Max(limit, 0))
+	}
+	if n.Type == "Stack" {
+		return e.stack(f, box, width, height, avail)
+	}
+	return e.flex(f, containerOf(n, st), box, width, height, avail)
+}
+
+// absolute lays out the absolute and fixed children of f once its size is
+// known. Left and Right (Top and Bottom) both set stretch a child between
+// them; with neither it stays at the start of the content box.
+func (e *engine) absolute(f *Frame, st *core.Style) {
+	border := st.BorderWidth
+	for i, child := range f.Node.Children {
+		cs := styleOf(child)
+		if cs.Display == core.DisplayNone || (cs.Position != core.PositionAbsolute && cs.Position != core.PositionFixed) {
+			continue
+		}
+		origin := Size{border, border}
+		block := Size{f.Width - 2*border, f.Height - 2*border}
+		start := insets(st.Padding)
+		if cs.Position == core.PositionFixed {
+			origin, block, start = Size{}, e.viewport, edges{}
+		}
+
+		m := insets(cs.Margin)
+		left, right := e.length(cs.Left, block.Width), e.length(cs.Right, block.Width)
+		top, bottom := e.length(cs.Top, block.Height), e.length(cs.Bottom, block.Height)
+		width, height := e.length(cs.Width, block.Width), e.length(cs.Height, block.Height)
+		if isAuto(width) && !isAuto(left) && !isAuto(right) {
+			width = block.Width - left - right - m.horizontal()
+		}
+		if isAuto(height) && !isAuto(top) && !isAuto(bottom) {
+			height = block.Height - top - bottom - m.vertical()
+		}
+		c := e.layout(child, childPath(f.Path, i), width, height,
+			Size{block.Width - m.horizontal(), block.Height - m.vertical()})
+
+		switch {
+		case !isAuto(left):
+			c.X = left + m.Left
+		case !isAuto(right):
+			c.X = block.Width - right - m.Right - c.Width
+		default:
+			c.X = start.Left + m.Left
+		}
+		switch {
+		case !isAuto(top):
+			c.Y = top + m.Top
+		case !isAuto(bottom):
+			c.Y = block.Height - bottom - m.Bottom - c.Height
+		default:
+			c.Y = start.Top + m.Top
+		}
+		c.X += origin.Width
+		c.Y += origin.Height
+		f.Children[i] = c
+	}
+}
+
+// place turns positions relative to the parent into viewport coordinates.
+// Fixed frames are in the viewport already.
+func (f *Frame) place(x, y float64) {
+	if f.Node == nil || f.Node.Style == nil || f.Node.Style.Position != core.PositionFixed {
+		f.X += x
+		f.Y += y
+	}
+	for _, child := range f.Children {
+		if child != nil {
+			child.place(f.X, f.Y)
+		}
+	}
+}
+
+// offset moves a relatively positioned frame by its Left/Top, or back by
+// its Right/Bottom, without moving its siblings.
+func (e *engine) offset(f *Frame, st *core.Style, parent Size) {
+	if st.Position != core.PositionRelative {
+		return
+	}
+	if left := e.length(st.Left, parent.Width); !isAuto(left) {
+		f.X += left
+	} else if right := e.length(st.Right, parent.Width); !isAuto(right) {
+		f.X -= right
+	}
+	if top := e.length(st.Top, parent.Height); !isAuto(top) {
+		f.Y += top
+	} else if bottom := e.length(st.Bottom, parent.Height); !isAuto(bottom) {
+		f.Y -= bottom
+	}
+}
+
+func hidden(n *core.Node, path string) *Frame {
+	if n == nil {
+		return &Frame{Path: path}
+	}
+	f := &Frame{Node: n, Path: path, Children: make([]*Frame, len(n.Children))}
+	for i, child := range n.Children {
+		f.Children[i] = hidden(child, childPath(path, i))
+	}
+	return f
+}
+
+func childPath(path string, i int) string {
+	return fmt.Sprintf("%s/%d", path, i)
+}
+
+var (
+	noStyle core.Style
+	// a nil child takes no room, like a hidden one, and gets an empty frame
+	nilStyle = core.Style{Display: core.DisplayNone}
+)
+
+func styleOf(n *core.Node) *core.Style {
+	if n == nil {
+		return &nilStyle
+	}
+	if n.Style == nil {
+		return &noStyle
+	}
+	return n.Style
+}
+
+// textOf is the text a leaf node shows: a Text's content, a Button's label.
+func textOf(n *core.Node) (string, bool) {
+	switch n.Type {
+	case "Text":
+		s, _ := n.Props["content"].(string)
+		return s, true
+	case "Button":
+		s, _ := n.Props["label"].(string)
+		return s, true
+	}
+	return "", false
+}
+
+// keyOf makes auto, which is NaN and never equal to itself, usable in a
+// cache key.
+func keyOf(v float64) float64 {
+	if isAuto(v) {
+		return -1
+	}
+	return v
+}
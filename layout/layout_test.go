@@ -0,0 +1,239 @@
+package layout
+
+import (
+	"math"
+	"testing"
+
+	"github.com/GraHms/govinci/core"
+)
+
+type rect struct{ X, Y, W, H float64 }
+
+func node(typ string, st core.Style, children ...*core.Node) *core.Node {
+	return &core.Node{Type: typ, Style: &st, Children: children}
+}
+
+func box(width, height string) *core.Node {
+	return node("Box", core.Style{Width: width, Height: height})
+}
+
+func text(content string, st core.Style) *core.Node {
+	return &core.Node{Type: "Text", Props: map[string]any{"content": content}, Style: &st}
+}
+
+func TestCompute(t *testing.T) {
+	viewport := Size{Width: 400, Height: 800}
+
+	tests := []struct {
+		name string
+		root *core.Node
+		want map[string]rect
+	}{
+		{
+			name: "grow shares free space by factor",
+			root: node("Row", core.Style{Width: "400"},
+				box("100", "10"),
+				node("Box", core.Style{Height: "10", FlexGrow: 1}),
+				node("Box", core.Style{Height: "10", FlexGrow: 2}),
+			),
+			want: map[string]rect{
+				"root":   {0, 0, 400, 10},
+				"root/0": {0, 0, 100, 10},
+				"root/1": {100, 0, 100, 10},
+				"root/2": {200, 0, 200, 10},
+			},
+		},
+		{
+			name: "shrink weighs factor by base size",
+			root: node("Row", core.Style{Width: "300"},
+				box("200", "10"),
+				node("Box", core.Style{Width: "200", Height: "10", FlexShrink: 3}),
+			),
+			want: map[string]rect{
+				"root/0": {0, 0, 175, 10},
+				"root/1": {175, 0, 125, 10},
+			},
+		},
+		{
+			name: "gap between items, children stretched across",
+			root: node("Column", core.Style{Gap: 8},
+				box("", "20"),
+				box("", "30"),
+			),
+			want: map[string]rect{
+				"root":   {0, 0, 400, 58},
+				"root/0": {0, 0, 400, 20},
+				"root/1": {0, 28, 400, 30},
+			},
+		},
+		{
+			name: "wrap starts a line after the gap",
+			root: node("Row", core.Style{Width: "100", FlexWrap: "wrap", Gap: 10},
+				box("40", "10"),
+				box("40", "10"),
+				box("40", "10"),
+			),
+			want: map[string]rect{
+				"root":   {0, 0, 100, 30},
+				"root/0": {0, 0, 40, 10},
+				"root/1": {50, 0, 40, 10},
+				"root/2": {0, 20, 40, 10},
+			},
+		},
+		{
+			name: "justify center",
+			root: node("Row", core.Style{Width: "300", JustifyContent: core.JustifyCenter},
+				box("50", "10"),
+				box("50", "10"),
+			),
+			want: map[string]rect{
+				"root/0": {100, 0, 50, 10},
+				"root/1": {150, 0, 50, 10},
+			},
+		},
+		{
+			name: "justify space-between",
+			root: node("Row", core.Style{Width: "300", JustifyContent: core.JustifyBetween},
+				box("50", "10"),
+				box("50", "10"),
+				box("50", "10"),
+			),
+			want: map[string]rect{
+				"root/0": {0, 0, 50, 10},
+				"root/1": {125, 0, 50, 10},
+				"root/2": {250, 0, 50, 10},
+			},
+		},
+		{
+			name: "justify end",
+			root: node("Row", core.Style{Width: "300", JustifyContent: core.JustifyEnd},
+				box("50", "10"),
+			),
+			want: map[string]rect{
+				"root/0": {250, 0, 50, 10},
+			},
+		},
+		{
+			name: "align items, align self and stretch",
+			root: node("Row", core.Style{Width: "300", Height: "100", AlignItems: core.AlignItemsCenter},
+				box("50", "20"),
+				node("Box", core.Style{Width: "50", Height: "20", AlignSelf: core.AlignItemsEnd}),
+				node("Box", core.Style{Width: "50", AlignSelf: core.AlignItemsStretch}),
+			),
+			want: map[string]rect{
+				"root/0": {0, 40, 50, 20},
+				"root/1": {50, 80, 50, 20},
+				"root/2": {100, 0, 50, 100},
+			},
+		},
+		{
+			name: "max freezes a growing item, min raises its base",
+			root: node("Row", core.Style{Width: "300"},
+				node("Box", core.Style{Height: "10", FlexGrow: 1, MaxWidth: "100"}),
+				node("Box", core.Style{Height: "10", FlexGrow: 1}),
+				node("Box", core.Style{Width: "50", Height: "10", MinWidth: "80"}),
+			),
+			want: map[string]rect{
+				"root/0": {0, 0, 100, 10},
+				"root/1": {100, 0, 120, 10},
+				"root/2": {220, 0, 80, 10},
+			},
+		},
+		{
+			name: "min height and max width on the root",
+			root: node("Column", core.Style{MinHeight: "50", MaxWidth: "200"},
+				box("", "10"),
+			),
+			want: map[string]rect{
+				"root":   {0, 0, 200, 50},
+				"root/0": {0, 0, 200, 10},
+			},
+		},
+		{
+			name: "absolute children in the padding box",
+			root: node("Box", core.Style{Width: "200", Height: "100", Padding: core.EdgeInsets{Horizontal: 10, Vertical: 10}},
+				node("Box", core.Style{Position: core.PositionAbsolute, Right: "10", Bottom: "5", Width: "20", Height: "20"}),
+				node("Box", core.Style{Position: core.PositionAbsolute, Left: "0", Right: "0", Top: "0", Height: "10"}),
+				node("Box", core.Style{Position: core.PositionAbsolute, Width: "10", Height: "10"}),
+			),
+			want: map[string]rect{
+				"root/0": {170, 75, 20, 20},
+				"root/1": {0, 0, 200, 10},
+				"root/2": {10, 10, 10, 10},
+			},
+		},
+		{
+			name: "relative offset leaves siblings in place",
+			root: node("Column", core.Style{},
+				box("", "20"),
+				node("Box", core.Style{Height: "20", Position: core.PositionRelative, Top: "5", Left: "7"}),
+				box("", "20"),
+			),
+			want: map[string]rect{
+				"root":   {0, 0, 400, 60},
+				"root/1": {7, 25, 400, 20},
+				"root/2": {0, 40, 400, 20},
+			},
+		},
+		{
+			name: "padding, border and margin add up down the tree",
+			root: node("Column", core.Style{Padding: core.EdgeInsets{Horizontal: 10, Vertical: 10}, BorderWidth: 2},
+				node("Row", core.Style{Padding: core.EdgeInsets{Horizontal: 5, Vertical: 5}, Margin: core.EdgeInsets{Left: 3}},
+					box("20", "20"),
+				),
+			),
+			want: map[string]rect{
+				"root":     {0, 0, 400, 54},
+				"root/0":   {15, 12, 373, 30},
+				"root/0/0": {20, 17, 20, 20},
+			},
+		},
+		{
+			name: "nil children get empty frames",
+			root: node("Row", core.Style{},
+				nil,
+				box("20", "10"),
+				node("Stack", core.Style{}, nil, box("5", "5")),
+			),
+			want: map[string]rect{
+				"root/0":   {0, 0, 0, 0},
+				"root/1":   {0, 0, 20, 10},
+				"root/2/0": {20, 0, 0, 0},
+				"root/2/1": {20, 2.5, 5, 5},
+			},
+		},
+		{
+			name: "text sized by the measurer",
+			root: node("Row", core.Style{},
+				text("hello", core.Style{FontSize: 10}),
+			),
+			want: map[string]rect{
+				"root":   {0, 0, 400, 12},
+				"root/0": {0, 0, 30, 12},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := Compute(tt.root, viewport, Monospace)
+			for path, want := range tt.want {
+				f := frames.Find(path)
+				if f == nil {
+					t.Errorf("%s: no frame", path)
+					continue
+				}
+				got := rect{f.X, f.Y, f.Width, f.Height}
+				if !near(got, want) {
+					t.Errorf("%s = %+v, want %+v", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+func near(a, b rect) bool {
+	const eps = 1e-6
+	return math.Abs(a.X-b.X) < eps && math.Abs(a.Y-b.Y) < eps &&
+		math.Abs(a.W-b.W) < eps && math.Abs(a.H-b.H) < eps
+}
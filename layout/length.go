@@ -0,0 +1,99 @@
+package layout
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// length resolves a Style length against base, the size percentages are
+// of. It returns auto for an empty, unknown or unresolvable value, such as
+// a percentage of a size that depends on the content.
+func (e *engine) length(value string, base float64) float64 {
+	value = strings.TrimSpace(value)
+	unit := func(suffix string) (float64, bool) {
+		if !strings.HasSuffix(value, suffix) {
+			return 0, false
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, suffix)), 64)
+		return v, err == nil
+	}
+	if v, ok := unit("px"); ok {
+		return v
+	}
+	if v, ok := unit("%"); ok {
+		if isAuto(base) || math.IsInf(base, 0) {
+			return auto
+		}
+		return base * v / 100
+	}
+	if v, ok := unit("vw"); ok {
+		return e.viewport.Width * v / 100
+	}
+	if v, ok := unit("vh"); ok {
+		return e.viewport.Height * v / 100
+	}
+	if v, err := strconv.ParseFloat(value, 64); err == nil {
+		return v
+	}
+	return auto
+}
+
+// clamp bounds v by min and max, either of which may be auto; min wins over
+// max, as in CSS. An auto v stays auto and sizes never go below zero.
+func clamp(v, min, max float64) float64 {
+	if isAuto(v) {
+		return v
+	}
+	if !isAuto(max) && v > max {
+		v = max
+	}
+	if !isAuto(min) && v < min {
+		v = min
+	}
+	return math.Max(v, 0)
+}
+
+type edges struct {
+	Top, Right, Bottom, Left float64
+}
+
+// insets resolves Horizontal/Vertical as fallbacks for unset sides, like
+// the css package.
+func insets(e core.EdgeInsets) edges {
+	side := func(v, fallback int) float64 {
+		if v == 0 {
+			return float64(fallback)
+		}
+		return float64(v)
+	}
+	return edges{
+		Top:    side(e.Top, e.Vertical),
+		Right:  side(e.Right, e.Horizontal),
+		Bottom: side(e.Bottom, e.Vertical),
+		Left:   side(e.Left, e.Horizontal),
+	}
+}
+
+func (e edges) plus(px float64) edges {
+	return edges{e.Top + px, e.Right + px, e.Bottom + px, e.Left + px}
+}
+
+func (e edges) horizontal() float64 { return e.Left + e.Right }
+func (e edges) vertical() float64   { return e.Top + e.Bottom }
+
+func number(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	}
+	return 0
+}
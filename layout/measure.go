@@ -0,0 +1,73 @@
+package layout
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// Measurer sizes text for the layout. Hosts wrap their text engine in one
+// (Paint on Android, canvas measureText on the web) so frames match what
+// they draw.
+type Measurer interface {
+	// MeasureText returns the size of text set in style, wrapped to lines of
+	// at most maxWidth; maxWidth is +Inf when nothing limits the width.
+	MeasureText(text string, style *core.Style, maxWidth float64) Size
+}
+
+type MeasurerFunc func(text string, style *core.Style, maxWidth float64) Size
+
+func (f MeasurerFunc) MeasureText(text string, style *core.Style, maxWidth float64) Size {
+	return f(text, style, maxWidth)
+}
+
+// Monospace measures every character as 0.6em wide and every line as
+// LineHeight, or 1.2em, tall, breaking lines between words unless
+// WhiteSpace is "nowrap". FontSize defaults to 16. It needs no fonts, so
+// tests that assert on geometry get the same numbers everywhere.
+var Monospace Measurer = MeasurerFunc(monospace)
+
+func monospace(text string, style *core.Style, maxWidth float64) Size {
+	if text == "" {
+		return Size{}
+	}
+	fontSize, lineHeight, wrap := 16.0, 0.0, true
+	if style != nil {
+		if style.FontSize > 0 {
+			fontSize = style.FontSize
+		}
+		lineHeight = float64(style.LineHeight)
+		wrap = style.WhiteSpace != "nowrap"
+	}
+	if lineHeight == 0 {
+		lineHeight = fontSize * 1.2
+	}
+	advance := fontSize * 0.6
+
+	columns := math.MaxInt
+	if wrap && !math.IsInf(maxWidth, 1) {
+		columns = max(1, int(maxWidth/advance))
+	}
+	widest, lines := 0, 0
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines++
+		n := 0
+		for _, word := range strings.Fields(paragraph) {
+			w := utf8.RuneCountInString(word)
+			switch {
+			case n == 0:
+				n = w
+			case n+1+w <= columns:
+				n += 1 + w
+			default:
+				widest = max(widest, n)
+				lines++
+				n = w
+			}
+		}
+		widest = max(widest, n)
+	}
+	return Size{Width: float64(widest) * advance, Height: float64(lines) * lineHeight}
+}
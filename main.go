@@ -3,20 +3,27 @@
 package main
 
 import (
+	"encoding/json"
+
+	"github.com/GraHms/govinci/assets"
 	"github.com/GraHms/govinci/core"
 	"github.com/GraHms/govinci/render"
 	"myapp/app"
 )
 
-var manager *render.Manager
+var (
+	ctx     *core.Context
+	manager *render.Manager
+)
 
 // Exported to native (called once)
 func InitApp() {
-	ctx := core.NewContext().With(
+	ctx = core.NewContext().With(
 		core.WithThemeOpt(app.AppTheme),
 		core.WithConfigOpt(app.Config),
 	)
 	manager = render.New(ctx, app.App)
+	assets.Watch(assets.TargetAndroid)
 }
 
 // Exported to native (to get first render)
@@ -34,3 +41,48 @@ func TriggerTextCallback(id, val string) string {
 	core.TriggerTextCallback(id, val)
 	return manager.RenderAndGetPatches()
 }
+
+// Exported to native (structured payloads: camera captures, scans...)
+func TriggerEvent(id, payload string) string {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return "[]"
+	}
+	core.ReceiveEventPayload(map[string]any{
+		"callback": id,
+		"value":    data["value"],
+	})
+	return manager.RenderAndGetPatches()
+}
+
+// Exported to native (drains queued system events such as camera commands)
+func PollSystemEvents() string {
+	data, err := json.Marshal(core.DrainSystemEvents())
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// Exported to native (appearance, window size... reported by the platform)
+func ReceiveSystemEvent(name, payload string) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return
+	}
+	core.ReceiveSystemEvent(name, data)
+	ctx.MarkDirty()
+}
+
+// Exported to native (polled every frame; timers, thumbnails and system
+// events mark the root context dirty)
+func IsDirty() bool {
+	return ctx.IsDirty()
+}
+
+// Exported to native (re-render after a system event or when dirty)
+func RenderAgain() string {
+	out := manager.RenderAndGetPatches()
+	ctx.ClearDirty()
+	return out
+}
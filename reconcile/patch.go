@@ -3,6 +3,7 @@ package reconcile
 import (
 	"fmt"
 	"github.com/GraHms/govinci/core"
+	"reflect"
 )
 
 // Patch represents a minimal change set between two Node trees
@@ -44,6 +45,13 @@ func Diff(old, new *core.Node, path string) []Patch {
 			Changes:  new.Props,
 		})
 	}
+	if old.Class != new.Class {
+		patches = append(patches, Patch{
+			Type:     "update-class",
+			TargetID: path,
+			Changes:  new.Class,
+		})
+	}
 	if styleChanged(old.Style, new.Style) {
 		patches = append(patches, Patch{
 			Type:     "update-style",
@@ -83,12 +91,14 @@ func Diff(old, new *core.Node, path string) []Patch {
 	return patches
 }
 
+// propsChanged compares values deeply: props such as "fonts" and "animate"
+// hold slices, which == would panic on.
 func propsChanged(a, b map[string]any) bool {
 	if len(a) != len(b) {
 		return true
 	}
 	for k, v := range a {
-		if b[k] != v {
+		if !reflect.DeepEqual(b[k], v) {
 			return true
 		}
 	}
@@ -102,7 +112,7 @@ func styleChanged(a, b *core.Style) bool {
 	if a == nil || b == nil {
 		return true
 	}
-	return a != b
+	return !reflect.DeepEqual(*a, *b)
 }
 
 func min(a, b int) int {
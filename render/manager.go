@@ -3,6 +3,7 @@ package render
 import (
 	"encoding/json"
 	"github.com/GraHms/govinci/core"
+	"github.com/GraHms/govinci/css"
 	"github.com/GraHms/govinci/reconcile"
 )
 
@@ -10,28 +11,45 @@ type Manager struct {
 	currentTree *core.Node
 	context     *core.Context
 	renderFunc  func(*core.Context) core.View
+	styles      *css.Compiler
 }
 
 func New(ctx *core.Context, rootView func(*core.Context) core.View) *Manager {
 	if ctx.Theme() == nil {
 		ctx = ctx.WithTheme(core.DefaultTheme)
 	}
+	core.SetRootContext(ctx)
 	return &Manager{
 		context:    ctx,
 		renderFunc: rootView,
 	}
 }
 
+// UseStyleCompiler makes the manager emit class names instead of style
+// objects, in the initial tree and in patches ("update-class"). The host is
+// responsible for injecting the compiler's rules (see css.Compiler.Drain).
+func (r *Manager) UseStyleCompiler(c *css.Compiler) {
+	r.styles = c
+}
+
+func (r *Manager) renderTree() *core.Node {
+	tree := r.renderFunc(r.context).Render(r.context)
+	if r.styles != nil {
+		r.styles.Apply(tree)
+	}
+	return tree
+}
+
 func (r *Manager) RenderInitial() string {
 	r.context.Reset()
-	r.currentTree = r.renderFunc(r.context).Render(r.context)
+	r.currentTree = r.renderTree()
 	return renderJSON(r.currentTree)
 }
 
 // RenderAgain ReRender Used after an event (input/click/state change) to get diff
 func (r *Manager) RenderAgain() string {
 	r.context.Reset()
-	newTree := r.renderFunc(r.context).Render(r.context)
+	newTree := r.renderTree()
 	patches := reconcile.Diff(r.currentTree, newTree, "root")
 	r.currentTree = newTree
 	r.context.ClearDirty()
@@ -50,7 +68,7 @@ func renderJSON[T any](v T) string {
 
 func (r *Manager) RenderAndGetPatches() string {
 	r.context.Cursor = 0
-	newTree := r.renderFunc(r.context).Render(r.context)
+	newTree := r.renderTree()
 
 	if r.currentTree == nil {
 		r.currentTree = newTree
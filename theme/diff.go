@@ -0,0 +1,80 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/GraHms/govinci/core"
+)
+
+// Change is one property that differs between two themes, addressed by a
+// dotted path such as "components.button.background".
+type Change struct {
+	Path string
+	From any
+	To   any
+}
+
+func (c Change) String() string {
+	switch {
+	case c.From == nil:
+		return fmt.Sprintf("+ %s: %v", c.Path, c.To)
+	case c.To == nil:
+		return fmt.Sprintf("- %s: %v", c.Path, c.From)
+	}
+	return fmt.Sprintf("~ %s: %v -> %v", c.Path, c.From, c.To)
+}
+
+// Diff lists the properties that changed from a to b, sorted by path.
+func Diff(a, b *core.Theme) []Change {
+	left, right := flatten(a), flatten(b)
+
+	var changes []Change
+	for path, from := range left {
+		to, ok := right[path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Path: path, From: from})
+		case fmt.Sprint(from) != fmt.Sprint(to):
+			changes = append(changes, Change{Path: path, From: from, To: to})
+		}
+	}
+	for path, to := range right {
+		if _, ok := left[path]; !ok {
+			changes = append(changes, Change{Path: path, To: to})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func flatten(t *core.Theme) map[string]any {
+	data, _ := json.Marshal(toDocument(t))
+	var raw map[string]any
+	_ = json.Unmarshal(data, &raw)
+
+	out := map[string]any{}
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		switch val := v.(type) {
+		case map[string]any:
+			for k, child := range val {
+				if prefix == "" {
+					walk(k, child)
+				} else {
+					walk(prefix+"."+k, child)
+				}
+			}
+		case []any:
+			for i, child := range val {
+				walk(fmt.Sprintf("%s[%d]", prefix, i), child)
+			}
+		default:
+			out[prefix] = val
+		}
+	}
+	walk("", raw)
+	return out
+}
@@ -0,0 +1,486 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/GraHms/govinci/core"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// document is the on-disk shape of a theme (see docs/ui-architecture.md 8.6).
+// Style maps use lowerCamel Style field names, e.g. {"fontSize": 24}.
+type document struct {
+	Extends    string                    `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Colors     map[string]string         `json:"colors,omitempty" yaml:"colors,omitempty"`
+	Typography map[string]map[string]any `json:"typography,omitempty" yaml:"typography,omitempty"`
+	Spacing    map[string]int            `json:"spacing,omitempty" yaml:"spacing,omitempty"`
+	Components map[string]map[string]any `json:"components,omitempty" yaml:"components,omitempty"`
+	// Variants is keyed by component and then variant name, e.g.
+	// variants.button.outline.borderWidth.
+	Variants map[string]map[string]map[string]any `json:"variants,omitempty" yaml:"variants,omitempty"`
+	Fonts    []fontDoc                            `json:"fonts,omitempty" yaml:"fonts,omitempty"`
+}
+
+type fontDoc struct {
+	Family string `json:"family" yaml:"family"`
+	Source string `json:"src" yaml:"src"`
+	Weight int    `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Style  string `json:"style,omitempty" yaml:"style,omitempty"`
+}
+
+var (
+	builtinsMu sync.RWMutex
+	builtins   = map[string]*core.Theme{
+		"none":          {},
+		"default":       core.DefaultTheme,
+		"default-dark":  core.DefaultDarkTheme,
+		"material":      core.MaterialTheme,
+		"material-dark": core.MaterialDarkTheme,
+	}
+)
+
+// Register makes a theme available to `extends`. It is safe to call while
+// other goroutines load themes.
+func Register(name string, t *core.Theme) {
+	builtinsMu.Lock()
+	builtins[name] = t
+	builtinsMu.Unlock()
+}
+
+// Load reads a JSON or YAML theme. Values not present in the file come from
+// the theme named by `extends` (DefaultTheme when omitted).
+func Load(r io.Reader) (*core.Theme, error) {
+	return load(r, nil)
+}
+
+// LoadFile is like Load but also accepts `extends` pointing to another theme
+// file, relative to the current one.
+func LoadFile(path string) (*core.Theme, error) {
+	return loadFile(path, map[string]bool{})
+}
+
+func loadFile(path string, seen map[string]bool) (*core.Theme, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if seen[abs] {
+		return nil, fmt.Errorf("theme: extends cycle at %s", path)
+	}
+	seen[abs] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return load(f, func(name string) (*core.Theme, error) {
+		switch filepath.Ext(name) {
+		case ".json", ".yaml", ".yml":
+			return loadFile(filepath.Join(filepath.Dir(path), name), seen)
+		}
+		return nil, nil
+	})
+}
+
+func load(r io.Reader, resolve func(string) (*core.Theme, error)) (*core.Theme, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := baseTheme(doc.Extends, resolve)
+	if err != nil {
+		return nil, err
+	}
+	t := clone(base)
+	if err := apply(t, doc); err != nil {
+		return nil, err
+	}
+	if err := Validate(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func decode(data []byte) (*document, error) {
+	var doc document
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("theme: %w", err)
+		}
+		return &doc, nil
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("theme: %w", err)
+	}
+	return &doc, nil
+}
+
+func baseTheme(name string, resolve func(string) (*core.Theme, error)) (*core.Theme, error) {
+	if name == "" {
+		return core.DefaultTheme, nil
+	}
+	if resolve != nil {
+		t, err := resolve(name)
+		if err != nil || t != nil {
+			return t, err
+		}
+	}
+	builtinsMu.RLock()
+	t, ok := builtins[name]
+	builtinsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("theme: unknown base theme %q", name)
+	}
+	return t, nil
+}
+
+func apply(t *core.Theme, doc *document) error {
+	for key, value := range doc.Colors {
+		field, ok := fieldByKey(reflect.ValueOf(&t.Colors).Elem(), key)
+		if !ok {
+			return fmt.Errorf("theme: unknown color %q", key)
+		}
+		field.SetString(value)
+	}
+
+	for key, value := range doc.Spacing {
+		field, ok := fieldByKey(reflect.ValueOf(&t.Spacing).Elem(), key)
+		if !ok {
+			return fmt.Errorf("theme: unknown spacing %q", key)
+		}
+		field.SetInt(int64(value))
+	}
+
+	for role, props := range doc.Typography {
+		field, ok := fieldByKey(reflect.ValueOf(&t.Typography).Elem(), role)
+		if !ok {
+			return fmt.Errorf("theme: unknown typography role %q", role)
+		}
+		if err := overlayStyle(field.Addr().Interface().(*core.Style), props); err != nil {
+			return fmt.Errorf("theme: typography.%s: %w", role, err)
+		}
+	}
+
+	for name, props := range doc.Components {
+		field, ok := fieldByKey(reflect.ValueOf(&t.Components).Elem(), name)
+		if !ok || field.Type() != styleType {
+			return fmt.Errorf("theme: unknown component %q", name)
+		}
+		if err := overlayStyle(field.Addr().Interface().(*core.Style), props); err != nil {
+			return fmt.Errorf("theme: components.%s: %w", name, err)
+		}
+	}
+
+	for component, variants := range doc.Variants {
+		if t.Components.Variants == nil {
+			t.Components.Variants = map[string]map[string]core.Style{}
+		}
+		component = strings.ToLower(component)
+		if t.Components.Variants[component] == nil {
+			t.Components.Variants[component] = map[string]core.Style{}
+		}
+		for name, props := range variants {
+			s := t.Components.Variants[component][name]
+			if err := overlayStyle(&s, props); err != nil {
+				return fmt.Errorf("theme: variants.%s.%s: %w", component, name, err)
+			}
+			t.Components.Variants[component][name] = s
+		}
+	}
+
+	if doc.Fonts != nil {
+		t.Fonts = nil
+		for _, f := range doc.Fonts {
+			t.Fonts = append(t.Fonts, core.FontFace{
+				Family: f.Family,
+				Source: f.Source,
+				Weight: core.Weight(f.Weight),
+				Style:  core.FontStyle(f.Style),
+			})
+		}
+	}
+	return nil
+}
+
+// overlayStyle sets only the properties present in props. encoding/json
+// matches field names case-insensitively, so lowerCamel keys map straight onto
+// Style fields.
+//
+// The "unset" key lists properties to clear, e.g. `unset: [shadow]`, which
+// variants use to drop something their component default declares.
+func overlayStyle(s *core.Style, props map[string]any) error {
+	var mask core.StyleField
+	values := map[string]any{}
+	for key, v := range props {
+		if key == "unset" {
+			m, err := parseUnset(v)
+			if err != nil {
+				return err
+			}
+			mask |= m
+			continue
+		}
+		if !styleKeys[strings.ToLower(key)] {
+			return fmt.Errorf("unknown style property %q", key)
+		}
+		values[key] = v
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return err
+	}
+	if mask != 0 {
+		*s = s.Merge(core.Style{Unset: mask})
+	}
+	return nil
+}
+
+func parseUnset(v any) (core.StyleField, error) {
+	list, ok := v.([]any)
+	if !ok {
+		return 0, fmt.Errorf("unset must be a list of property names")
+	}
+	var mask core.StyleField
+	for _, item := range list {
+		name, _ := item.(string)
+		f, ok := core.ParseStyleField(name)
+		if !ok {
+			return 0, fmt.Errorf("unset: unknown style property %q", item)
+		}
+		mask |= f
+	}
+	return mask, nil
+}
+
+var styleType = reflect.TypeOf(core.Style{})
+
+// styleKeys holds the lowercased names encoding/json reads into a Style.
+// Fields tagged `json:"-"` (Variant, Disabled, Selected, Unset) are left
+// out, so a theme setting them fails instead of being silently dropped.
+var styleKeys = func() map[string]bool {
+	keys := map[string]bool{}
+	for i := 0; i < styleType.NumField(); i++ {
+		f := styleType.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		keys[strings.ToLower(name)] = true
+	}
+	return keys
+}()
+
+func fieldByKey(v reflect.Value, key string) (reflect.Value, bool) {
+	f := v.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, key) })
+	return f, f.IsValid()
+}
+
+// clone copies a theme deeply enough that loading never mutates the base.
+func clone(t *core.Theme) *core.Theme {
+	out := *t
+	out.Fonts = append([]core.FontFace(nil), t.Fonts...)
+	for _, group := range []reflect.Value{
+		reflect.ValueOf(&out.Typography).Elem(),
+		reflect.ValueOf(&out.Components).Elem(),
+	} {
+		for i := 0; i < group.NumField(); i++ {
+			if group.Field(i).Type() != styleType {
+				continue
+			}
+			cloneStyle(group.Field(i).Addr().Interface().(*core.Style))
+		}
+	}
+	if t.Components.Variants != nil {
+		out.Components.Variants = make(map[string]map[string]core.Style, len(t.Components.Variants))
+		for component, variants := range t.Components.Variants {
+			copied := make(map[string]core.Style, len(variants))
+			for name, s := range variants {
+				cloneStyle(&s)
+				copied[name] = s
+			}
+			out.Components.Variants[component] = copied
+		}
+	}
+	return &out
+}
+
+func cloneStyle(s *core.Style) {
+	for _, nested := range []**core.Style{&s.HoverStyle, &s.FocusStyle, &s.PressedStyle, &s.DisabledStyle, &s.SelectedStyle} {
+		if *nested != nil {
+			copied := **nested
+			*nested = &copied
+		}
+	}
+	if s.PseudoStates != nil {
+		states := make(map[string]core.Style, len(s.PseudoStates))
+		for k, v := range s.PseudoStates {
+			states[k] = v
+		}
+		s.PseudoStates = states
+	}
+	if s.Responsive != nil {
+		responsive := make(core.ResponsiveStyle, len(s.Responsive))
+		for k, v := range s.Responsive {
+			responsive[k] = v
+		}
+		s.Responsive = responsive
+	}
+}
+
+// Save writes the full theme with `extends: none`, so the file does not depend
+// on any base.
+func Save(w io.Writer, t *core.Theme, format Format) error {
+	doc := toDocument(t)
+	doc.Extends = "none"
+	switch format {
+	case YAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	}
+}
+
+func toDocument(t *core.Theme) *document {
+	doc := &document{
+		Colors:     map[string]string{},
+		Typography: map[string]map[string]any{},
+		Spacing:    map[string]int{},
+		Components: map[string]map[string]any{},
+		Variants:   map[string]map[string]map[string]any{},
+	}
+
+	colors := reflect.ValueOf(t.Colors)
+	for i := 0; i < colors.NumField(); i++ {
+		if v := colors.Field(i).String(); v != "" {
+			doc.Colors[lowerCamel(colors.Type().Field(i).Name)] = v
+		}
+	}
+	spacing := reflect.ValueOf(t.Spacing)
+	for i := 0; i < spacing.NumField(); i++ {
+		doc.Spacing[strings.ToLower(spacing.Type().Field(i).Name)] = int(spacing.Field(i).Int())
+	}
+	styleGroup(reflect.ValueOf(t.Typography), doc.Typography)
+	styleGroup(reflect.ValueOf(t.Components), doc.Components)
+	for component, variants := range t.Components.Variants {
+		doc.Variants[component] = map[string]map[string]any{}
+		for name, s := range variants {
+			doc.Variants[component][name] = styleMap(s)
+		}
+	}
+
+	for _, f := range t.Fonts {
+		doc.Fonts = append(doc.Fonts, fontDoc{
+			Family: f.Family,
+			Source: f.Source,
+			Weight: int(f.Weight),
+			Style:  string(f.Style),
+		})
+	}
+	return doc
+}
+
+func styleGroup(group reflect.Value, out map[string]map[string]any) {
+	for i := 0; i < group.NumField(); i++ {
+		if group.Field(i).Type() != styleType {
+			continue
+		}
+		props := styleMap(group.Field(i).Interface().(core.Style))
+		if len(props) > 0 {
+			out[lowerCamel(group.Type().Field(i).Name)] = props
+		}
+	}
+}
+
+// styleMap turns a Style into its non-zero properties with lowerCamel keys.
+func styleMap(s core.Style) map[string]any {
+	data, _ := json.Marshal(s)
+	var raw map[string]any
+	_ = json.Unmarshal(data, &raw)
+	out := compact(raw)
+	if s.Unset != 0 {
+		var names []any
+		for _, n := range s.Unset.Names() {
+			names = append(names, lowerCamel(n))
+		}
+		out["unset"] = names
+	}
+	return out
+}
+
+func compact(m map[string]any) map[string]any {
+	out := map[string]any{}
+	for k, v := range m {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case string:
+			if val == "" {
+				continue
+			}
+		case float64:
+			if val == 0 {
+				continue
+			}
+		case map[string]any:
+			nested := compact(val)
+			if len(nested) == 0 {
+				continue
+			}
+			v = nested
+		}
+		out[lowerCamel(k)] = v
+	}
+	return out
+}
+
+func lowerCamel(s string) string {
+	if s == "" {
+		return s
+	}
+	// keep acronyms such as XS/MD readable: "XS" -> "xs"
+	if strings.ToUpper(s) == s {
+		return strings.ToLower(s)
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,109 @@
+package theme
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/GraHms/govinci/core"
+)
+
+var (
+	hexColor  = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	funcColor = regexp.MustCompile(`^(rgb|rgba|hsl|hsla)\([^()]*\)$`)
+)
+
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "theme: invalid theme:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Validate checks every color in the palette and in the typography and
+// component styles. Colors may be hex (#RGB, #RGBA, #RRGGBB, #RRGGBBAA),
+// rgb()/rgba()/hsl()/hsla(), "transparent" or a color token.
+func Validate(t *core.Theme) error {
+	var problems []string
+
+	colors := reflect.ValueOf(t.Colors)
+	for i := 0; i < colors.NumField(); i++ {
+		v := colors.Field(i).String()
+		if v != "" && !validColor(t, v, false) {
+			problems = append(problems, fmt.Sprintf("colors.%s: invalid color %q", lowerCamel(colors.Type().Field(i).Name), v))
+		}
+	}
+
+	for prefix, group := range map[string]reflect.Value{
+		"typography": reflect.ValueOf(t.Typography),
+		"components": reflect.ValueOf(t.Components),
+	} {
+		for i := 0; i < group.NumField(); i++ {
+			if group.Field(i).Type() != styleType {
+				continue
+			}
+			s := group.Field(i).Interface().(core.Style)
+			path := prefix + "." + lowerCamel(group.Type().Field(i).Name)
+			problems = append(problems, validateStyle(t, path, &s)...)
+		}
+	}
+
+	for component, variants := range t.Components.Variants {
+		for name, s := range variants {
+			problems = append(problems, validateStyle(t, "variants."+component+"."+name, &s)...)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func validateStyle(t *core.Theme, path string, s *core.Style) []string {
+	var problems []string
+	check := func(name, v string, background bool) {
+		if v != "" && !validColor(t, v, background) {
+			problems = append(problems, fmt.Sprintf("%s.%s: invalid color %q", path, name, v))
+		}
+	}
+	check("textColor", s.TextColor, false)
+	check("background", s.Background, true)
+	check("borderColor", s.BorderColor, false)
+
+	for name, nested := range map[string]*core.Style{
+		"hoverStyle":    s.HoverStyle,
+		"focusStyle":    s.FocusStyle,
+		"pressedStyle":  s.PressedStyle,
+		"disabledStyle": s.DisabledStyle,
+		"selectedStyle": s.SelectedStyle,
+	} {
+		if nested != nil {
+			problems = append(problems, validateStyle(t, path+"."+name, nested)...)
+		}
+	}
+	for state, ps := range s.PseudoStates {
+		problems = append(problems, validateStyle(t, path+".pseudoStates."+state, &ps)...)
+	}
+	for bp, rs := range s.Responsive {
+		problems = append(problems, validateStyle(t, path+".responsive."+bp, &rs)...)
+	}
+	return problems
+}
+
+func validColor(t *core.Theme, v string, background bool) bool {
+	switch {
+	case core.IsToken(v):
+		_, ok := t.Token(v)
+		return ok
+	case v == "transparent", hexColor.MatchString(v), funcColor.MatchString(v):
+		return true
+	case background:
+		return strings.HasPrefix(v, "linear-gradient(") ||
+			strings.HasPrefix(v, "radial-gradient(") ||
+			strings.HasPrefix(v, "url(")
+	}
+	return false
+}
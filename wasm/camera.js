@@ -5,10 +5,17 @@ window.GovinciCameraView = class {
         this.props = props;
         this.videoElement = document.createElement("video");
         this.videoElement.autoplay = true;
+        this.videoElement.muted = true;
+        this.videoElement.playsInline = true;
         this.videoElement.style.width = "100%";
         this.videoElement.style.height = "100%";
         this.videoElement.style.objectFit = "cover";
         this.stream = null;
+        this.recorder = null;
+        this.chunks = [];
+        this.recordStart = 0;
+        this.scanTimer = null;
+        this.lastScan = null;
 
         this.init();
     }
@@ -19,19 +26,60 @@ window.GovinciCameraView = class {
             const constraints = {
                 video: {
                     facingMode: { ideal: facingMode },
-                }
+                },
+                audio: !!this.props.onRecord,
             };
 
             this.stream = await navigator.mediaDevices.getUserMedia(constraints);
             this.videoElement.srcObject = this.stream;
+            this.applyZoom(this.props.zoom);
+            this.applyTorch(this.props.flash);
+            if (this.props.onCodeScanned) {
+                this.startScanning();
+            }
         } catch (err) {
             console.error("Camera access error:", err);
             if (this.props.onError) {
-                window.GoInvokeCallback(this.props.onError, err.message);
+                window.GoInvokeCallback(this.props.onError, { value: err.message });
             }
         }
     }
 
+    videoTrack() {
+        if (!this.stream) return null;
+        return this.stream.getVideoTracks()[0] || null;
+    }
+
+    applyZoom(level) {
+        const track = this.videoTrack();
+        if (!track || !level || !track.getCapabilities) return;
+        const caps = track.getCapabilities();
+        if (!caps.zoom) return;
+        const zoom = Math.min(Math.max(level, caps.zoom.min), caps.zoom.max);
+        track.applyConstraints({ advanced: [{ zoom }] }).catch(() => {});
+    }
+
+    applyTorch(enabled) {
+        const track = this.videoTrack();
+        if (!track || !track.getCapabilities || !track.getCapabilities().torch) return;
+        track.applyConstraints({ advanced: [{ torch: !!enabled }] }).catch(() => {});
+    }
+
+    // Commands sent from Go through CameraController.
+    command(data) {
+        switch (data.action) {
+            case "capture": this.capture(); break;
+            case "facing":
+                this.props.facing = data.facing;
+                this.stop();
+                this.init();
+                break;
+            case "zoom": this.applyZoom(data.zoom); break;
+            case "record-start": this.startRecording(); break;
+            case "record-stop": this.stopRecording(); break;
+        }
+    }
+
     capture() {
         if (!this.videoElement || !this.stream) return;
 
@@ -40,16 +88,89 @@ window.GovinciCameraView = class {
         canvas.height = this.videoElement.videoHeight;
         const ctx = canvas.getContext("2d");
         ctx.drawImage(this.videoElement, 0, 0);
-        const dataURL = canvas.toDataURL("image/png");
+        const mime = "image/jpeg";
+        const dataURL = canvas.toDataURL(mime, 0.92);
 
         if (this.props.onCapture) {
-            window.GoInvokeCallback(this.props.onCapture, dataURL);
+            window.GoInvokeCallback(this.props.onCapture, {
+                value: {
+                    data: dataURL.substring(dataURL.indexOf(",") + 1),
+                    mime,
+                    width: canvas.width,
+                    height: canvas.height,
+                },
+            });
         }
     }
 
+    startRecording() {
+        if (!this.stream || this.recorder || typeof MediaRecorder === "undefined") return;
+        this.chunks = [];
+        this.recorder = new MediaRecorder(this.stream);
+        this.recorder.ondataavailable = (e) => {
+            if (e.data && e.data.size > 0) this.chunks.push(e.data);
+        };
+        this.recorder.onstop = () => {
+            const mime = this.recorder.mimeType || "video/webm";
+            const duration = (performance.now() - this.recordStart) / 1000;
+            const blob = new Blob(this.chunks, { type: mime });
+            this.recorder = null;
+            if (!this.props.onRecord) return;
+            const reader = new FileReader();
+            reader.onloadend = () => {
+                const url = reader.result;
+                window.GoInvokeCallback(this.props.onRecord, {
+                    value: { data: url.substring(url.indexOf(",") + 1), mime, duration },
+                });
+            };
+            reader.readAsDataURL(blob);
+        };
+        this.recordStart = performance.now();
+        this.recorder.start();
+    }
+
+    stopRecording() {
+        if (this.recorder && this.recorder.state !== "inactive") {
+            this.recorder.stop();
+        }
+    }
+
+    startScanning() {
+        if (!("BarcodeDetector" in window)) {
+            if (this.props.onError) {
+                window.GoInvokeCallback(this.props.onError, { value: "barcode scanning not supported" });
+            }
+            return;
+        }
+        const detector = new BarcodeDetector({ formats: (this.props.scanFormats || "qr_code").split(",") });
+        const scan = async () => {
+            if (!this.stream) return;
+            try {
+                const codes = await detector.detect(this.videoElement);
+                if (codes.length > 0) {
+                    const code = codes[0];
+                    const key = `${code.format}:${code.rawValue}`;
+                    if (key !== this.lastScan) {
+                        this.lastScan = key;
+                        window.GoInvokeCallback(this.props.onCodeScanned, {
+                            value: { format: code.format, value: code.rawValue },
+                        });
+                    }
+                }
+            } catch (_) {
+                // frame not ready yet
+            }
+            this.scanTimer = setTimeout(scan, 250);
+        };
+        scan();
+    }
+
     stop() {
+        clearTimeout(this.scanTimer);
+        this.stopRecording();
         if (this.stream) {
             this.stream.getTracks().forEach(track => track.stop());
+            this.stream = null;
         }
     }
 
@@ -57,3 +178,12 @@ window.GovinciCameraView = class {
         return this.videoElement;
     }
 };
+
+window.GovinciCameras = {};
+
+Govinci.onSystemEvent("camera", (data) => {
+    const camera = window.GovinciCameras[data.controller];
+    if (camera) {
+        camera.command(data);
+    }
+});
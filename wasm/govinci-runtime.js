@@ -3,9 +3,14 @@
 const Govinci = (() => {
     let rootElement = null;
     const callbackMap = {};
+    const systemEventHandlers = {};
     const DEBUG = true;
 
     function renderNode(node, path = "") {
+        if (node.Type === "Image") {
+            return renderImage(node, path);
+        }
+
         const el = createElement(node);
         el.setAttribute("data-node-path", path);
 
@@ -27,33 +32,57 @@ const Govinci = (() => {
     function createElement(node) {
         const el = document.createElement(tagForType(node.Type));
 
-        if (node.Style) {
+        if (node.Class) {
+            el.className = node.Class;
+        } else if (node.Style) {
             Object.assign(el.style, styleFromGovinci(node.Style));
+            applyStateStyles(el, node.Style);
+        }
+        applyStateProps(el, node.Props || {});
+        applyMotionProps(el, node.Props || {}, true);
+        applyGestures(el, node.Props || {});
+        if (TEXT_INPUTS.includes(node.Type)) applyInputProps(el, node.Type, node.Props || {});
+        if (CONTROLS.includes(node.Type)) applyControlProps(el, node.Type, node.Props || {});
+        if (FEEDBACK.includes(node.Type)) applyFeedbackProps(el, node.Type, node.Props || {});
+        if (LAYOUTS.includes(node.Type)) applyLayoutProps(el, node.Type, node.Props || {});
+        if (node.Type === "LazyList" || node.Type === "LazyItem" || node.Type === "Scroll") {
+            el.dataset.gvType = node.Type;
+            applyTypeProps(el, node.Type, node.Props || {});
+        }
+
+        if (node.Type === "Theme" && node.Props && node.Props.fonts) {
+            loadFonts(node.Props.fonts);
+        }
+
+        if (node.Type === "CameraView") {
+            mountCamera(el, node.Props || {});
+            return el;
         }
 
         if (node.Props) {
             for (const [key, value] of Object.entries(node.Props)) {
-                if (key.startsWith("on")) {
+                if (key.startsWith("on") && !ownsEvent(el, key)) {
                     const event = mapEventName(key);
                     const existing = el.dataset[`listener_${key}`];
                     if (existing && callbackMap[existing]) {
                         el.removeEventListener(event, callbackMap[existing]);
                     }
                     const handler = (e) => {
-                        const payload = extractEventPayload(e, node.Type);
+                        if (isDisabled(el)) return;
+                        const payload = extractEventPayload(e, node.Type.toLowerCase());
                         window.GoInvokeCallback(value, payload);
                     };
                     el.addEventListener(event, handler);
                     el.dataset[`listener_${key}`] = value;
                     callbackMap[value] = handler;
                 } else if (key === "value") {
-                    el.value = value;
+                    if (!el.dataset.gvControl) el.value = value;
                 } else if (key === "placeholder") {
                     el.placeholder = value;
                 } else if (key === "content") {
                     el.textContent = value;
                 }
-                else if (key === "label") {
+                else if (key === "label" && !el.dataset.gvControl) {
                     el.textContent = value;
                 }
                 else if (key === "src" && node.Type === "Image") {
@@ -66,17 +95,1381 @@ const Govinci = (() => {
         return el;
     }
 
+    function renderImage(node, path) {
+        const props = node.Props || {};
+        const img = document.createElement("img");
+        applyImageProps(img, props);
+
+        if (!node.Children || node.Children.length === 0) {
+            if (node.Class) img.className = node.Class;
+            else if (node.Style) Object.assign(img.style, styleFromGovinci(node.Style));
+            img.setAttribute("data-node-path", path);
+            applyGestures(img, props);
+            return img;
+        }
+
+        // Placeholder / error slots live next to the <img> inside a wrapper.
+        const wrapper = document.createElement("div");
+        wrapper.setAttribute("data-node-path", path);
+        if (node.Class) wrapper.className = node.Class;
+        else if (node.Style) Object.assign(wrapper.style, styleFromGovinci(node.Style));
+        wrapper.style.position = "relative";
+        applyGestures(wrapper, props);
+        img.style.width = "100%";
+        wrapper.appendChild(img);
+
+        node.Children.forEach((child, i) => {
+            const slot = document.createElement("div");
+            slot.setAttribute("data-node-path", `${path}/${i}`);
+            slot.dataset.slot = child.Type === "ImageError" ? "error" : "placeholder";
+            if (slot.dataset.slot === "error") slot.style.display = "none";
+            (child.Children || []).forEach((c, j) => {
+                slot.appendChild(renderNode(c, `${path}/${i}/${j}`));
+            });
+            wrapper.appendChild(slot);
+        });
+
+        img.addEventListener("load", () => {
+            wrapper.querySelectorAll('[data-slot="placeholder"]').forEach(e => e.style.display = "none");
+        });
+        img.addEventListener("error", () => {
+            img.style.display = "none";
+            wrapper.querySelectorAll("[data-slot]").forEach(e => {
+                e.style.display = e.dataset.slot === "error" ? "" : "none";
+            });
+        });
+        return wrapper;
+    }
+
+    function applyImageProps(img, props) {
+        img.loading = "lazy";
+        if (props.contentMode) img.style.objectFit = props.contentMode;
+        if (props.aspectRatio) img.style.aspectRatio = String(props.aspectRatio);
+        if (props.fadeIn) {
+            img.style.opacity = "0";
+            img.style.transition = `opacity ${props.fadeIn}ms ease`;
+            img.addEventListener("load", () => { img.style.opacity = "1"; }, { once: true });
+        }
+        if (props.src) img.src = props.src;
+    }
+
+    const loadedFonts = new Set();
+
+    function loadFonts(fonts) {
+        if (!window.FontFace || !document.fonts) return;
+        fonts.forEach(f => {
+            const key = `${f.family}|${f.weight}|${f.style}|${f.src}`;
+            if (loadedFonts.has(key)) return;
+            loadedFonts.add(key);
+            const face = new FontFace(f.family, `url(${f.src})`, {
+                weight: String(f.weight),
+                style: f.style,
+                display: "swap",
+            });
+            face.load()
+                .then(loaded => document.fonts.add(loaded))
+                .catch(err => console.warn("Font load failed:", f.family, err));
+        });
+    }
+
+    function mountCamera(el, props) {
+        if (!window.GovinciCameraView || props.active === false) return;
+        el.style.position = "relative";
+        const camera = new window.GovinciCameraView({ ...props });
+        el.appendChild(camera.getElement());
+        if (props.controller) {
+            const previous = window.GovinciCameras[props.controller];
+            if (previous) previous.stop();
+            window.GovinciCameras[props.controller] = camera;
+        }
+    }
+
+    function onSystemEvent(name, handler) {
+        systemEventHandlers[name] = handler;
+    }
+
+    function dispatchSystemEvent(name, data) {
+        const handler = systemEventHandlers[name];
+        if (handler) {
+            handler(typeof data === "string" ? JSON.parse(data) : data);
+        } else if (DEBUG) {
+            console.log("Unhandled system event:", name, data);
+        }
+    }
+
     function styleFromGovinci(style) {
         const out = {};
         if (style.FontSize) out.fontSize = `${style.FontSize}px`;
+        if (style.FontWeight) out.fontWeight = String(style.FontWeight);
+        if (style.FontFamily) out.fontFamily = `'${style.FontFamily}'`;
+        if (style.FontStyle) out.fontStyle = style.FontStyle;
+        if (style.LetterSpacing) out.letterSpacing = `${style.LetterSpacing}px`;
+        if (style.TextAlign) out.textAlign = style.TextAlign;
+        if (style.TextDecoration) out.textDecoration = style.TextDecoration;
+        if (style.TextTransform) out.textTransform = style.TextTransform;
         if (style.TextColor) out.color = style.TextColor;
         if (style.Background) out.background = style.Background;
         if (style.Padding) out.padding = edgeToCSS(style.Padding);
         if (style.Margin) out.margin = edgeToCSS(style.Margin);
         if (style.BorderRadius) out.borderRadius = `${style.BorderRadius}px`;
+        if (style.BorderWidth) out.border = `${style.BorderWidth}px solid ${style.BorderColor || "currentColor"}`;
+        if (style.Shadow) out.boxShadow = `0 ${style.Shadow}px ${style.Shadow * 2}px rgba(0,0,0,0.2)`;
+        if (style.Overflow) out.overflow = style.Overflow;
+        if (style.MaxWidth) out.maxWidth = style.MaxWidth;
+        if (style.FlexGrow) out.flexGrow = String(style.FlexGrow);
+        if (style.Display) out.display = style.Display;
+        if (style.FlexDirection) out.flexDirection = style.FlexDirection;
+        if (style.FlexWrap) out.flexWrap = style.FlexWrap;
+        if (style.FlexBasis) out.flexBasis = style.FlexBasis;
+        if (style.FlexShrink) out.flexShrink = String(style.FlexShrink);
+        if (style.JustifyContent) out.justifyContent = style.JustifyContent;
+        if (style.AlignItems) out.alignItems = style.AlignItems;
+        if (style.AlignSelf) out.alignSelf = style.AlignSelf;
+        if (style.Width) out.width = style.Width;
+        if (style.Height) out.height = style.Height;
+        if (style.MinWidth) out.minWidth = style.MinWidth;
+        if (style.MinHeight) out.minHeight = style.MinHeight;
+        if (style.Gap) out.gap = `${style.Gap}px`;
+        if (style.RowGap) out.rowGap = `${style.RowGap}px`;
+        if (style.ColumnGap) out.columnGap = `${style.ColumnGap}px`;
         return out;
     }
 
+    // State styles become generated classes; "&" stands for the class name.
+    // Inline styles would otherwise win, hence !important.
+    const stateSelectors = [
+        ["HoverStyle", "&:hover:not(:disabled):not([aria-disabled=true])"],
+        ["FocusStyle", "&:focus"],
+        ["PressedStyle", "&:active:not(:disabled):not([aria-disabled=true])"],
+        ["SelectedStyle", "&[aria-selected=true]"],
+        ["DisabledStyle", "&:disabled, &[aria-disabled=true]"],
+    ];
+    const stateClasses = {};
+    let stateSheet = null;
+
+    function applyStateStyles(el, style) {
+        [...el.classList].filter(c => c.startsWith("gv-state-")).forEach(c => el.classList.remove(c));
+        const cls = stateClass(style);
+        if (cls) el.classList.add(cls);
+    }
+
+    function stateClass(style) {
+        const rules = [];
+        for (const [field, selector] of stateSelectors) {
+            if (!style[field]) continue;
+            const body = cssDeclarations(styleFromGovinci(style[field]));
+            if (body) rules.push([selector, body]);
+        }
+        if (rules.length === 0) return "";
+
+        const key = JSON.stringify(rules);
+        if (stateClasses[key]) return stateClasses[key];
+        const cls = `gv-state-${Object.keys(stateClasses).length}`;
+        stateClasses[key] = cls;
+
+        if (!stateSheet) {
+            const tag = document.createElement("style");
+            tag.id = "govinci-states";
+            document.head.appendChild(tag);
+            stateSheet = tag.sheet;
+        }
+        rules.forEach(([selector, body]) => {
+            stateSheet.insertRule(`${selector.replaceAll("&", "." + cls)} { ${body} }`, stateSheet.cssRules.length);
+        });
+        return cls;
+    }
+
+    function cssDeclarations(decls) {
+        return Object.entries(decls)
+            .map(([k, v]) => `${k.replace(/[A-Z]/g, m => "-" + m.toLowerCase())}: ${v} !important`)
+            .join("; ");
+    }
+
+    function applyStateProps(el, props) {
+        if ("disabled" in el) {
+            el.disabled = !!props.disabled;
+        } else if (props.disabled) {
+            el.setAttribute("aria-disabled", "true");
+        } else {
+            el.removeAttribute("aria-disabled");
+        }
+        if (props.selected) {
+            el.setAttribute("aria-selected", "true");
+        } else {
+            el.removeAttribute("aria-selected");
+        }
+    }
+
+    // Animations arrive as resolved frames plus timing (core/animation.go) and
+    // play through the Web Animations API. A single frame animates from
+    // wherever the element currently is.
+    function applyMotionProps(el, props, mounting) {
+        el._gvExit = props.exit || null;
+        const key = JSON.stringify(props.animate || null);
+        if (key === el._gvAnimateKey) return;
+        el._gvAnimateKey = key;
+
+        const animate = props.animate;
+        if (mounting && props.enter) {
+            playMotion(el, props.enter)
+                .then(() => { if (animate && animate.frames.length > 1) playMotion(el, animate); })
+                .catch(() => {});
+            return;
+        }
+        if (!animate) return;
+        if (mounting && animate.frames.length === 1) {
+            Object.assign(el.style, frameStyle(animate.frames[0]));
+            return;
+        }
+        playMotion(el, animate).catch(() => {});
+    }
+
+    function playMotion(el, motion) {
+        const easing = motion.curve ? `linear(${motion.curve.join(", ")})` : (motion.easing || "ease");
+        const keyframes = motion.frames.map(f => ({ ...frameStyle(f), offset: f.offset, easing }));
+        const previous = el._gvAnimation;
+        if (previous) {
+            // keep the in-flight values as the new starting point
+            try { previous.commitStyles(); } catch (e) { /* not rendered */ }
+            previous.cancel();
+        }
+        const animation = el.animate(keyframes, {
+            duration: motion.duration,
+            delay: motion.delay || 0,
+            iterations: motion.iterations < 0 ? Infinity : (motion.iterations || 1),
+            fill: "forwards",
+        });
+        el._gvAnimation = animation;
+        return animation.finished;
+    }
+
+    function frameStyle(f) {
+        return {
+            opacity: String(f.opacity),
+            transform: `translate(${f.x}px, ${f.y}px) scale(${f.scale}) rotate(${f.rotate}deg)`,
+        };
+    }
+
+    // Removes el, first playing its exit animation if it has one. The element
+    // stops being addressable right away so later patches reach its
+    // replacement, not the leaving copy.
+    function removeElement(el) {
+        const exit = el._gvExit;
+        if (!exit) {
+            el.remove();
+            return;
+        }
+        el.removeAttribute("data-node-path");
+        el.querySelectorAll("[data-node-path]").forEach(c => c.removeAttribute("data-node-path"));
+        el.style.pointerEvents = "none";
+        playMotion(el, exit).catch(() => {}).finally(() => el.remove());
+    }
+
+    // Gesture arena. On pointerdown every element with recognizers between the
+    // target and the root joins; once the pointer moves past the slop, the
+    // innermost element whose recognizers match the movement wins and the rest
+    // are dropped. Native scrolling takes part through touch-action: an
+    // element that only swipes horizontally keeps pan-y, so a vertical drag
+    // finds no winner and the browser scrolls as usual.
+    const SLOP = 10;            // px before a press becomes a drag
+    const LONG_PRESS = 500;     // ms
+    const DOUBLE_TAP = 300;     // ms between the two taps
+    const SWIPE_VELOCITY = 300; // px/s along the swipe axis
+    let arena = null;
+    let lastTap = null;
+
+    function applyGestures(el, props) {
+        const gestures = props.gestures || null;
+        el._gvGestures = gestures;
+        if (!gestures) {
+            if (el._gvTouchAction) el.style.touchAction = el._gvTouchAction = "";
+            return;
+        }
+        const horizontal = gestures.swipeleft || gestures.swiperight;
+        const vertical = gestures.swipeup || gestures.swipedown;
+        let action = "manipulation";
+        if (gestures.pan || gestures.pinch || (horizontal && vertical)) action = "none";
+        else if (horizontal) action = "pan-y";
+        else if (vertical) action = "pan-x";
+        el.style.touchAction = el._gvTouchAction = action;
+    }
+
+    function pointerPosition(e) {
+        return { x: e.clientX, y: e.clientY, t: e.timeStamp };
+    }
+
+    function gestureStart(e) {
+        if (arena) {
+            arena.pointers.set(e.pointerId, pointerPosition(e));
+            if (arena.pointers.size === 2) startPinch();
+            return;
+        }
+        const members = [];
+        for (let el = e.target; el && el !== document; el = el.parentElement) {
+            if (el._gvGestures && !isDisabled(el)) members.push(el);
+        }
+        if (members.length === 0) return;
+        const start = pointerPosition(e);
+        arena = {
+            members,
+            pointers: new Map([[e.pointerId, start]]),
+            start,
+            last: start,
+            velocity: { x: 0, y: 0 },
+            scale: 1,
+            winner: null,
+            kind: null,
+        };
+        arena.timer = setTimeout(() => {
+            if (arena && !arena.winner && claim(g => g.longPress && "longPress")) {
+                emitGesture("longPress", "ended");
+            }
+        }, LONG_PRESS);
+    }
+
+    // claim hands the arena to the innermost member for which match returns a
+    // recognizer kind. Without a winner the arena is abandoned.
+    function claim(match) {
+        clearTimeout(arena.timer);
+        for (const el of arena.members) {
+            const kind = match(el._gvGestures);
+            if (kind) {
+                arena.winner = el;
+                arena.kind = kind;
+                return true;
+            }
+        }
+        arena = null;
+        return false;
+    }
+
+    function startPinch() {
+        const [a, b] = [...arena.pointers.values()];
+        arena.pinchDistance = Math.hypot(a.x - b.x, a.y - b.y) || 1;
+        if (arena.kind === "pan") emitGesture("pan", "cancelled");
+        if (claim(g => g.pinch && "pinch")) emitGesture("pinch", "began");
+    }
+
+    function gestureMove(e) {
+        if (!arena || !arena.pointers.has(e.pointerId)) return;
+        const p = pointerPosition(e);
+        arena.pointers.set(e.pointerId, p);
+
+        if (arena.kind === "pinch") {
+            const [a, b] = [...arena.pointers.values()];
+            arena.scale = Math.hypot(a.x - b.x, a.y - b.y) / arena.pinchDistance;
+            arena.last = { x: (a.x + b.x) / 2, y: (a.y + b.y) / 2, t: p.t };
+            emitThrottled("pinch");
+            return;
+        }
+
+        const dt = p.t - arena.last.t;
+        if (dt > 0) {
+            arena.velocity = {
+                x: 0.8 * (p.x - arena.last.x) / dt * 1000 + 0.2 * arena.velocity.x,
+                y: 0.8 * (p.y - arena.last.y) / dt * 1000 + 0.2 * arena.velocity.y,
+            };
+        }
+        arena.last = p;
+
+        if (!arena.winner) {
+            const dx = p.x - arena.start.x, dy = p.y - arena.start.y;
+            if (Math.hypot(dx, dy) < SLOP) return;
+            const horizontal = Math.abs(dx) > Math.abs(dy);
+            const matched = claim(g => {
+                if (g.pan) return "pan";
+                if (horizontal && (g.swipeleft || g.swiperight)) return "swipe";
+                if (!horizontal && (g.swipeup || g.swipedown)) return "swipe";
+                return null;
+            });
+            if (matched && arena.kind === "pan") emitGesture("pan", "began");
+            return;
+        }
+        if (arena.kind === "pan") emitThrottled("pan");
+    }
+
+    function gestureEnd(e) {
+        if (!arena || !arena.pointers.has(e.pointerId)) return;
+        clearTimeout(arena.timer);
+        const cancelled = e.type === "pointercancel";
+
+        if (!arena.winner) {
+            if (!cancelled) tap();
+        } else if (arena.kind === "pan" || arena.kind === "pinch") {
+            emitGesture(arena.kind, cancelled ? "cancelled" : "ended");
+        } else if (arena.kind === "swipe" && !cancelled) {
+            const { x, y } = arena.velocity;
+            const horizontal = Math.abs(x) > Math.abs(y);
+            const speed = horizontal ? Math.abs(x) : Math.abs(y);
+            const direction = horizontal ? (x < 0 ? "left" : "right") : (y < 0 ? "up" : "down");
+            if (speed >= SWIPE_VELOCITY && arena.winner._gvGestures["swipe" + direction]) {
+                emitGesture("swipe" + direction, "ended", { direction });
+            }
+        }
+        arena = null;
+    }
+
+    // A tap goes to the innermost member with tap or doubleTap. When it has
+    // both, the single tap waits to see whether a second one follows.
+    function tap() {
+        const el = arena.members.find(m => m._gvGestures.tap || m._gvGestures.doubleTap);
+        if (!el) return;
+        const gestures = el._gvGestures;
+        arena.winner = el;
+        const now = arena.last.t;
+        if (gestures.doubleTap && lastTap && lastTap.el === el && now - lastTap.t < DOUBLE_TAP) {
+            clearTimeout(lastTap.timer);
+            lastTap = null;
+            emitGesture("doubleTap", "ended");
+            return;
+        }
+        if (!gestures.doubleTap) {
+            emitGesture("tap", "ended");
+            return;
+        }
+        const pending = gesturePayload("ended");
+        lastTap = { el, t: now, timer: null };
+        if (gestures.tap) {
+            lastTap.timer = setTimeout(() => {
+                lastTap = null;
+                if (el._gvGestures && el._gvGestures.tap) window.GoInvokeCallback(el._gvGestures.tap, { value: pending });
+            }, DOUBLE_TAP);
+        }
+    }
+
+    let pendingFrame = null;
+    function emitThrottled(kind) {
+        if (pendingFrame) return;
+        pendingFrame = requestAnimationFrame(() => {
+            pendingFrame = null;
+            if (arena && arena.kind === kind) emitGesture(kind, "changed");
+        });
+    }
+
+    function emitGesture(name, state, extra = {}) {
+        const id = arena.winner._gvGestures[name];
+        if (!id) return;
+        window.GoInvokeCallback(id, { value: { ...gesturePayload(state), ...extra } });
+    }
+
+    function gesturePayload(state) {
+        const rect = arena.winner.getBoundingClientRect();
+        return {
+            state,
+            x: arena.last.x - rect.left,
+            y: arena.last.y - rect.top,
+            translationX: arena.last.x - arena.start.x,
+            translationY: arena.last.y - arena.start.y,
+            velocityX: arena.velocity.x,
+            velocityY: arena.velocity.y,
+            scale: arena.scale,
+        };
+    }
+
+    document.addEventListener("pointerdown", gestureStart);
+    document.addEventListener("pointermove", gestureMove);
+    document.addEventListener("pointerup", gestureEnd);
+    document.addEventListener("pointercancel", gestureEnd);
+
+    // LazyList: items are absolutely positioned slots inside a scrolling
+    // container, which gets its scroll range from an ::after block stretched
+    // to the total height. Scroll position and measured item heights go back
+    // to Go, which answers with the new window.
+    let lazySheet = null;
+    const itemObserver = typeof ResizeObserver === "undefined" ? null : new ResizeObserver(measureItems);
+
+    function applyLazyProps(el, type, props) {
+        el._gvProps = props;
+        if (type === "LazyList") {
+            if (!lazySheet) {
+                lazySheet = document.createElement("style");
+                lazySheet.textContent = '[data-gv-type="LazyList"]::after { content: ""; position: absolute; top: 0; left: 0; width: 1px; height: var(--gv-lazy-height, 0); pointer-events: none; }';
+                document.head.appendChild(lazySheet);
+            }
+            el.style.position = "relative";
+            el.style.overflowY = "auto";
+            el.style.setProperty("--gv-lazy-height", `${props.totalHeight || 0}px`);
+            if (props.controller) el.dataset.scrollTarget = props.controller;
+            if (!el._gvScrollBound) {
+                el._gvScrollBound = true;
+                el.addEventListener("scroll", () => lazyScrolled(el));
+                requestAnimationFrame(() => reportScroll(el));
+            }
+            positionSticky(el);
+            return;
+        }
+        el.style.position = "absolute";
+        el.style.left = "0";
+        el.style.right = "0";
+        el.style.display = props.index < 0 ? "none" : "";
+        el.style.top = `${props.top || 0}px`;
+        el.style.zIndex = props.sticky ? "1" : "";
+        el._gvHeight = props.height || 0;
+        if (itemObserver && !el._gvObserved) {
+            el._gvObserved = true;
+            itemObserver.observe(el);
+        }
+        if (props.sticky && el.parentElement) positionSticky(el.parentElement);
+    }
+
+    function lazyScrolled(list) {
+        positionSticky(list);
+        if (list._gvScrollPending) return;
+        list._gvScrollPending = true;
+        requestAnimationFrame(() => {
+            list._gvScrollPending = false;
+            reportScroll(list);
+        });
+    }
+
+    function reportScroll(list) {
+        if (!list.isConnected || !list._gvProps.onScroll) return;
+        window.GoInvokeCallback(list._gvProps.onScroll, {
+            value: { offset: list.scrollTop, viewport: list.clientHeight },
+        });
+    }
+
+    function positionSticky(list) {
+        const sticky = [...list.children].find(c => c._gvProps && c._gvProps.sticky);
+        if (!sticky) return;
+        const p = sticky._gvProps;
+        const limit = p.stickyLimit ?? Infinity;
+        sticky.style.top = `${Math.max(p.top, Math.min(list.scrollTop, limit))}px`;
+    }
+
+    // Reports items whose rendered height differs from the one Go laid them
+    // out with, one batch per list.
+    function measureItems(entries) {
+        const batches = new Map();
+        for (const entry of entries) {
+            const item = entry.target;
+            const p = item._gvProps;
+            const list = item.parentElement;
+            if (!p || p.index < 0 || !list || !list._gvProps) continue;
+            const height = item.offsetHeight;
+            if (Math.abs(height - item._gvHeight) < 0.5) continue;
+            item._gvHeight = height;
+            if (!batches.has(list)) batches.set(list, {});
+            batches.get(list)[p.index] = height;
+        }
+        batches.forEach((heights, list) => {
+            window.GoInvokeCallback(list._gvProps.onMeasure, { value: { heights } });
+        });
+    }
+
+    function applyTypeProps(el, type, props) {
+        if (type === "Scroll") applyScrollProps(el, props);
+        else applyLazyProps(el, type, props);
+    }
+
+    // Scroll follows "direction" for its overflow. The position goes to Go at
+    // most once per scrollThrottle ms; onEndReached fires once per content
+    // size when the end comes within endThreshold; with onRefresh a downward
+    // pull at the top shows the ::before indicator and refreshes on release.
+    let scrollSheet = null;
+    const PULL_TRIGGER = 64; // px the indicator has to reach to refresh
+
+    function applyScrollProps(el, props) {
+        el._gvProps = props;
+        const direction = props.direction || "vertical";
+        el.style.overflowX = direction === "vertical" ? "hidden" : "auto";
+        el.style.overflowY = direction === "horizontal" ? "hidden" : "auto";
+        setFlag(el, "scrollTarget", props.controller);
+        setFlag(el, "keyboardAware", props.keyboardAware && "");
+        if (props.onRefresh && !scrollSheet) {
+            scrollSheet = document.createElement("style");
+            scrollSheet.textContent = [
+                '[data-refreshable]::before { content: ""; display: block; height: var(--gv-pull, 0px); opacity: 0.5; background: center / 12px 12px no-repeat radial-gradient(circle, currentColor 60%, transparent 65%); }',
+                `[data-refreshing]::before { height: ${PULL_TRIGGER}px; animation: gv-refresh 0.8s ease-in-out infinite alternate; }`,
+                "@keyframes gv-refresh { from { opacity: 0.2; } to { opacity: 0.8; } }",
+            ].join("\n");
+            document.head.appendChild(scrollSheet);
+        }
+        setFlag(el, "refreshable", props.onRefresh && "");
+        setFlag(el, "refreshing", props.onRefresh && props.refreshing && "");
+        if (!el._gvScrollBound) {
+            el._gvScrollBound = true;
+            el.addEventListener("scroll", () => scrolled(el));
+            el.addEventListener("touchstart", pullStart, { passive: true });
+            el.addEventListener("touchmove", pullMove, { passive: false });
+            el.addEventListener("touchend", pullEnd);
+            el.addEventListener("touchcancel", pullEnd);
+        }
+        // content may already be shorter than the threshold
+        requestAnimationFrame(() => checkEndReached(el));
+    }
+
+    function setFlag(el, key, value) {
+        if (value === undefined || value === null || value === false) delete el.dataset[key];
+        else el.dataset[key] = value;
+    }
+
+    function scrolled(el) {
+        const p = el._gvProps;
+        if (p.onScroll && !el._gvScrollTimer) {
+            const wait = (p.scrollThrottle || 0) - (performance.now() - (el._gvLastScroll || 0));
+            if (wait <= 0) {
+                reportScrollMetrics(el);
+            } else {
+                el._gvScrollTimer = setTimeout(() => {
+                    el._gvScrollTimer = null;
+                    reportScrollMetrics(el);
+                }, wait);
+            }
+        }
+        checkEndReached(el);
+    }
+
+    function reportScrollMetrics(el) {
+        el._gvLastScroll = performance.now();
+        if (!el.isConnected || !el._gvProps.onScroll) return;
+        window.GoInvokeCallback(el._gvProps.onScroll, {
+            value: {
+                x: el.scrollLeft,
+                y: el.scrollTop,
+                contentWidth: el.scrollWidth,
+                contentHeight: el.scrollHeight,
+                viewportWidth: el.clientWidth,
+                viewportHeight: el.clientHeight,
+            },
+        });
+    }
+
+    function checkEndReached(el) {
+        const p = el._gvProps;
+        if (!p.onEndReached || !el.isConnected) return;
+        const horizontal = p.direction === "horizontal";
+        const size = horizontal ? el.scrollWidth : el.scrollHeight;
+        const remaining = horizontal
+            ? size - el.scrollLeft - el.clientWidth
+            : size - el.scrollTop - el.clientHeight;
+        if (remaining > (p.endThreshold ?? 200) || el._gvEndReachedAt === size) return;
+        el._gvEndReachedAt = size;
+        window.GoInvokeCallback(p.onEndReached, {});
+    }
+
+    function pullStart(e) {
+        const el = e.currentTarget;
+        const armed = el._gvProps.onRefresh && el.scrollTop <= 0 && !("refreshing" in el.dataset);
+        el._gvPullStart = armed ? e.touches[0].clientY : null;
+        el._gvPulled = 0;
+    }
+
+    function pullMove(e) {
+        const el = e.currentTarget;
+        if (el._gvPullStart == null) return;
+        const dy = e.touches[0].clientY - el._gvPullStart;
+        if (dy <= 0 || el.scrollTop > 0) {
+            el._gvPulled = 0;
+        } else {
+            e.preventDefault();
+            el._gvPulled = Math.min(dy / 2, PULL_TRIGGER * 1.5);
+        }
+        el.style.setProperty("--gv-pull", `${el._gvPulled}px`);
+    }
+
+    function pullEnd(e) {
+        const el = e.currentTarget;
+        if (el._gvPullStart == null) return;
+        el._gvPullStart = null;
+        el.style.setProperty("--gv-pull", "0px");
+        if (e.type === "touchend" && el._gvPulled >= PULL_TRIGGER && el._gvProps.onRefresh) {
+            // shown until Go renders refreshing=false
+            el.dataset.refreshing = "";
+            window.GoInvokeCallback(el._gvProps.onRefresh, {});
+        }
+    }
+
+    // Imperative scrolling (ScrollRef, ListController); the target is the
+    // controller ID the Scroll or LazyList exposes.
+    systemEventHandlers.scroll = (data) => {
+        const el = document.querySelector(`[data-scroll-target="${data.target}"]`);
+        if (!el) return;
+        const behavior = data.animated ? "smooth" : "auto";
+        if (data.action === "scrollTo") {
+            el.scrollTo({ left: data.x, top: data.y, behavior });
+        } else if (data.action === "scrollToEnd") {
+            const direction = (el._gvProps && el._gvProps.direction) || "vertical";
+            el.scrollTo({
+                left: direction === "vertical" ? el.scrollLeft : el.scrollWidth,
+                top: direction === "horizontal" ? el.scrollTop : el.scrollHeight,
+                behavior,
+            });
+        }
+    };
+
+    // Text inputs (core/input_props.go) wire their own events instead of
+    // plain listeners: edits follow the sequence protocol in
+    // core/input_sync.go, focus and blur carry no value, and Enter has to be
+    // told apart from typing. While an IME composes, values coming back from
+    // Go are not written into the field, which would cancel the composition;
+    // the composed text goes out again when it ends.
+    const TEXT_INPUTS = ["Input", "InputPassword", "NumericInput", "TextArea"];
+    const INPUT_EVENTS = ["onChange", "onSubmit", "onFocus", "onBlur"];
+    let inputSeq = 0;
+    const INPUT_MODES = { number: "numeric", decimal: "decimal", phone: "tel", email: "email", url: "url" };
+
+    function applyInputProps(el, type, props) {
+        el._gvInput = props;
+        el.dataset.gvInput = type;
+        if (el.tagName === "INPUT") el.type = inputType(props);
+        setAttr(el, "inputmode", INPUT_MODES[props.keyboard]);
+        setAttr(el, "enterkeyhint", props.returnKey);
+        setAttr(el, "maxlength", props.maxLength);
+        setAttr(el, "autocapitalize", props.autoCapitalize);
+        setAttr(el, "autocorrect", props.autoCorrect === undefined ? undefined : (props.autoCorrect ? "on" : "off"));
+        setAttr(el, "spellcheck", props.autoCorrect);
+        setAttr(el, "data-focus-id", props.focusId);
+        writeInputValue(el, props);
+        if (el._gvInputBound) return;
+        el._gvInputBound = true;
+        el.addEventListener("input", () => {
+            const p = el._gvInput;
+            if (isDisabled(el) || !p.onChange) return;
+            el._gvSeq = ++inputSeq;
+            const value = {
+                text: el.value,
+                seq: el._gvSeq,
+                selectionStart: el.selectionStart ?? el.value.length,
+                selectionEnd: el.selectionEnd ?? el.value.length,
+            };
+            if (el._gvComposing) {
+                value.composingStart = el._gvComposeStart;
+                value.composingEnd = value.selectionEnd;
+            }
+            window.GoInvokeCallback(p.onChange, { value });
+        });
+        el.addEventListener("focus", () => {
+            if (el._gvInput.onFocus) window.GoInvokeCallback(el._gvInput.onFocus, {});
+        });
+        el.addEventListener("blur", () => {
+            if (el._gvInput.onBlur) window.GoInvokeCallback(el._gvInput.onBlur, {});
+        });
+        el.addEventListener("keydown", (e) => {
+            const p = el._gvInput;
+            if (e.key !== "Enter" || e.isComposing || !p.onSubmit) return;
+            // a TextArea keeps Enter for new lines; Ctrl/Cmd+Enter submits
+            if (el.tagName === "TEXTAREA" && !e.ctrlKey && !e.metaKey) return;
+            e.preventDefault();
+            window.GoInvokeCallback(p.onSubmit, { value: el.value });
+        });
+        el.addEventListener("compositionstart", () => {
+            el._gvComposing = true;
+            el._gvComposeStart = el.selectionStart ?? el.value.length;
+        });
+        el.addEventListener("compositionend", () => {
+            el._gvComposing = false;
+            el.dispatchEvent(new Event("input"));
+        });
+        if (props.autoFocus) requestAnimationFrame(() => el.isConnected && el.focus());
+    }
+
+    // Writes Go's value unless it is a stale echo (rendered before Go saw the
+    // latest edit) or a composition is open. The caret keeps its distance
+    // from the end, so a mask inserting separators doesn't push it back,
+    // unless Go sends a selection.
+    function writeInputValue(el, props) {
+        if ((props.seq ?? 0) < (el._gvSeq || 0) || el._gvComposing) return;
+        const focused = document.activeElement === el;
+        if (props.value !== undefined && el.value !== props.value) {
+            const fromEnd = el.value.length - (el.selectionEnd ?? el.value.length);
+            el.value = props.value;
+            if (focused && !props.selection) {
+                const caret = Math.max(0, el.value.length - fromEnd);
+                setSelection(el, caret, caret);
+            }
+            el._gvSelection = null;
+        }
+        const selection = JSON.stringify(props.selection || null);
+        if (props.selection && selection !== el._gvSelection) {
+            setSelection(el, props.selection[0], props.selection[1]);
+        }
+        el._gvSelection = selection;
+    }
+
+    // email and number inputs have no selection API and throw.
+    function setSelection(el, start, end) {
+        try {
+            el.setSelectionRange(start, end);
+        } catch (_) {}
+    }
+
+    function ownsEvent(el, key) {
+        return (!!el.dataset.gvInput && INPUT_EVENTS.includes(key)) ||
+            (!!el.dataset.gvControl && CONTROL_EVENTS.includes(key)) ||
+            (!!el.dataset.gvFeedback && FEEDBACK_EVENTS.includes(key));
+    }
+
+    function inputType(props) {
+        if (props.secure) return "password";
+        switch (props.keyboard) {
+            case "email": return "email";
+            case "phone": return "tel";
+            case "url": return "url";
+        }
+        return "text";
+    }
+
+    function setAttr(el, name, value) {
+        if (value === undefined || value === null) el.removeAttribute(name);
+        else el.setAttribute(name, String(value));
+    }
+
+    // Programmatic focus (FocusManager); the target is the input's focusId.
+    systemEventHandlers.focus = (data) => {
+        const el = document.querySelector(`[data-focus-id="${data.target}"]`);
+        if (!el) return;
+        if (data.action === "focus") el.focus();
+        else if (data.action === "blur") el.blur();
+    };
+
+    // Selection controls (core/selection.go) are native inputs driven by
+    // their props; like text inputs they wire their own events so each one
+    // sends the typed value its Go callback expects. A slider being dragged
+    // ignores values from Go until it is released, so a late render doesn't
+    // pull the thumb back.
+    const CONTROLS = ["Checkbox", "Switch", "Radio", "Slider", "RangeSlider", "Select", "DatePicker", "TimePicker", "DateRangePicker"];
+    const CONTROL_EVENTS = ["onToggle", "onSelect", "onChange"];
+    const CONTROL_CSS = [
+        "input[type=checkbox], input[type=radio], input[type=range] { accent-color: currentColor; }",
+        "input[role=switch] { appearance: none; -webkit-appearance: none; position: relative; margin: 0; cursor: pointer; transition: background 0.2s; }",
+        "input[role=switch]::before { content: ''; position: absolute; top: 2px; bottom: 2px; left: 2px; aspect-ratio: 1; border-radius: 50%; background: currentColor; box-shadow: 0 1px 3px rgba(0,0,0,0.3); transition: left 0.2s, transform 0.2s; }",
+        "input[role=switch]:checked::before { left: calc(100% - 2px); transform: translateX(-100%); }",
+        "label > input[type=radio] { margin: 0 8px 0 0; }",
+        "[data-gv-range] { position: relative; min-height: 24px; }",
+        "[data-gv-range] > input { position: absolute; left: 0; width: 100%; margin: 0; pointer-events: none; background: none; }",
+        "[data-gv-range] > input::-webkit-slider-thumb { pointer-events: auto; }",
+        "[data-gv-range] > input::-moz-range-thumb { pointer-events: auto; }",
+        "[data-gv-control=Select] { position: relative; }",
+        "[data-gv-control=Select] > select, [data-gv-control=Select] > input, [data-gv-control=DateRangePicker] > input { font: inherit; color: inherit; background: transparent; border: none; outline: none; width: 100%; padding: 0; }",
+        "[data-gv-control=DateRangePicker] { display: flex; gap: 8px; }",
+        "[data-gv-listbox] { position: absolute; left: 0; right: 0; top: 100%; z-index: 1000; margin: 4px 0 0; padding: 4px 0; list-style: none; max-height: 240px; overflow-y: auto; background: Canvas; color: CanvasText; border-radius: 8px; box-shadow: 0 4px 16px rgba(0,0,0,0.2); }",
+        "[data-gv-listbox] > li { padding: 8px 12px; cursor: pointer; }",
+        "[data-gv-listbox] > li[aria-selected=true] { font-weight: 600; }",
+        "[data-gv-listbox] > li.gv-active { background: rgba(127,127,127,0.15); }",
+        "[data-gv-listbox] > li[aria-disabled=true] { opacity: 0.4; cursor: default; }",
+    ];
+    let controlSheet = null;
+
+    function applyControlProps(el, type, props) {
+        if (!controlSheet) {
+            const tag = document.createElement("style");
+            tag.id = "govinci-controls";
+            document.head.appendChild(tag);
+            controlSheet = tag.sheet;
+            CONTROL_CSS.forEach(rule => {
+                try {
+                    controlSheet.insertRule(rule, controlSheet.cssRules.length);
+                } catch (_) {} // the other engine's thumb selector
+            });
+        }
+        el._gvControl = props;
+        el.dataset.gvControl = type;
+        switch (type) {
+            case "Checkbox":
+            case "Switch":
+                el.type = "checkbox";
+                if (type === "Switch") el.setAttribute("role", "switch");
+                el.checked = !!props.checked;
+                bindControl(el, "change", () => sendControl(el, "onToggle", el.checked));
+                break;
+            case "Radio": {
+                let input = el.querySelector("input");
+                if (!input) {
+                    input = document.createElement("input");
+                    input.type = "radio";
+                    el.append(input, document.createElement("span"));
+                }
+                input.name = props.name || "";
+                input.checked = !!props.checked;
+                input.disabled = !!props.disabled;
+                el.lastChild.textContent = props.label ?? "";
+                bindControl(input, "change", () => input.checked && sendControl(el, "onSelect"));
+                break;
+            }
+            case "Slider":
+                el.type = "range";
+                setRange(el, props, props.value);
+                bindControl(el, "input", () => sendControl(el, "onChange", Number(el.value)));
+                break;
+            case "RangeSlider": {
+                el.dataset.gvRange = "";
+                while (el.children.length < 2) {
+                    const thumb = document.createElement("input");
+                    thumb.type = "range";
+                    el.appendChild(thumb);
+                }
+                const [low, high] = el.children;
+                setRange(low, props, props.low);
+                setRange(high, props, props.high);
+                low.disabled = high.disabled = !!props.disabled;
+                const send = (moved) => {
+                    // the moved thumb stops at the other one
+                    if (Number(low.value) > Number(high.value)) moved.value = moved === low ? high.value : low.value;
+                    sendControl(el, "onChange", { low: Number(low.value), high: Number(high.value) });
+                };
+                bindControl(low, "input", () => send(low));
+                bindControl(high, "input", () => send(high));
+                break;
+            }
+            case "Select":
+                if (props.searchable) applyCombobox(el, props);
+                else applyNativeSelect(el, props);
+                break;
+            case "DatePicker":
+            case "TimePicker":
+                el.type = type === "DatePicker" ? "date" : "time";
+                setPicked(el, props, props.value);
+                bindControl(el, "change", () => sendControl(el, "onChange", parsePicked(el)));
+                break;
+            case "DateRangePicker": {
+                while (el.children.length < 2) {
+                    const day = document.createElement("input");
+                    day.type = "date";
+                    day.setAttribute("aria-label", el.children.length ? "End" : "Start");
+                    el.appendChild(day);
+                }
+                const [start, end] = el.children;
+                setPicked(start, props, props.start);
+                setPicked(end, props, props.end);
+                start.disabled = end.disabled = !!props.disabled;
+                const send = () => sendControl(el, "onChange", { start: parsePicked(start), end: parsePicked(end) });
+                bindControl(start, "change", send);
+                bindControl(end, "change", send);
+                break;
+            }
+        }
+    }
+
+    // Pickers (core/picker.go). Dates and times are native inputs, which the
+    // browser shows in its own locale; "lang" carries the app's. Values go
+    // back as numbers ({year, month, day} or {hour, minute}), and an emptied
+    // input as {cleared: true}: a null value would not reach the callback.
+    function setPicked(input, props, value) {
+        setAttr(input, "min", props.min);
+        setAttr(input, "max", props.max);
+        setAttr(input, "lang", props.locale);
+        if (input.value !== (value || "")) input.value = value || "";
+    }
+
+    function parsePicked(input) {
+        if (!input.value) return { cleared: true };
+        const parts = input.value.split(/[-:]/).map(Number);
+        return input.type === "time"
+            ? { hour: parts[0], minute: parts[1] }
+            : { year: parts[0], month: parts[1], day: parts[2] };
+    }
+
+    // A Select without search is a native <select> inside the field; with
+    // search it is a combobox: a text field that filters a listbox popover,
+    // driven by the arrow keys, Enter and Escape. Either way the field keeps
+    // the theme's look and the inner element inherits it.
+    function applyNativeSelect(el, props) {
+        let select = el.firstElementChild;
+        if (!select || select.tagName !== "SELECT") {
+            el.replaceChildren();
+            el._gvControlBound = false;
+            select = document.createElement("select");
+            el.appendChild(select);
+        }
+        select.multiple = !!props.multiple;
+        select.disabled = !!props.disabled;
+        setAttr(select, "lang", props.locale);
+        const selected = props.selected || [];
+        const options = (props.options || []).map((opt, i) => {
+            const option = document.createElement("option");
+            option.value = String(i);
+            option.textContent = opt.label;
+            option.disabled = !!opt.disabled;
+            option.selected = selected.includes(i);
+            return option;
+        });
+        if (props.placeholder && !props.multiple) {
+            const empty = document.createElement("option");
+            empty.value = "";
+            empty.textContent = props.placeholder;
+            empty.disabled = true;
+            empty.selected = selected.length === 0;
+            options.unshift(empty);
+        }
+        select.replaceChildren(...options);
+        bindControl(el, "change", () => {
+            const select = el.querySelector("select");
+            if (!select) return;
+            const picked = [...select.selectedOptions].map(o => Number(o.value));
+            if (el._gvControl.multiple) sendControl(el, "onChange", { indices: picked });
+            else if (picked.length) sendControl(el, "onChange", picked[0]);
+        });
+    }
+
+    let listboxCounter = 0;
+
+    function applyCombobox(el, props) {
+        let input = el.firstElementChild;
+        if (!input || input.tagName !== "INPUT") {
+            el.replaceChildren();
+            el._gvControlBound = false;
+            input = document.createElement("input");
+            const list = document.createElement("ul");
+            list.id = `gv-listbox-${listboxCounter++}`;
+            list.dataset.gvListbox = "";
+            list.setAttribute("role", "listbox");
+            list.hidden = true;
+            input.setAttribute("role", "combobox");
+            input.setAttribute("aria-autocomplete", "list");
+            input.setAttribute("aria-expanded", "false");
+            input.setAttribute("aria-controls", list.id);
+            el.append(input, list);
+            bindCombobox(el, input, list);
+        }
+        const list = el.lastElementChild;
+        input.disabled = !!props.disabled;
+        if (props.multiple) list.setAttribute("aria-multiselectable", "true");
+        else list.removeAttribute("aria-multiselectable");
+        const labels = (props.selected || []).map(i => props.options[i]?.label).filter(Boolean).join(", ");
+        if (list.hidden) {
+            input.value = labels;
+            input.placeholder = props.placeholder || "";
+        } else {
+            input.placeholder = labels || props.placeholder || "";
+            fillListbox(el, input, list);
+        }
+    }
+
+    function bindCombobox(el, input, list) {
+        const open = () => {
+            if (!list.hidden || isDisabled(input)) return;
+            list.hidden = false;
+            input.setAttribute("aria-expanded", "true");
+            input.placeholder = input.value || input.placeholder;
+            input.value = "";
+            el._gvActive = 0;
+            fillListbox(el, input, list);
+        };
+        const close = () => {
+            if (list.hidden) return;
+            list.hidden = true;
+            input.setAttribute("aria-expanded", "false");
+            input.removeAttribute("aria-activedescendant");
+            applyCombobox(el, el._gvControl);
+        };
+        input.addEventListener("focus", open);
+        input.addEventListener("click", open);
+        input.addEventListener("blur", close);
+        input.addEventListener("input", () => {
+            el._gvActive = 0;
+            fillListbox(el, input, list);
+        });
+        input.addEventListener("keydown", (e) => {
+            const items = [...list.children];
+            if (e.key === "ArrowDown" || e.key === "ArrowUp") {
+                e.preventDefault();
+                open();
+                const step = e.key === "ArrowDown" ? 1 : -1;
+                el._gvActive = Math.max(0, Math.min(items.length - 1, (el._gvActive || 0) + step));
+                markActive(el, input, list);
+            } else if (e.key === "Enter" && !list.hidden && items[el._gvActive]) {
+                e.preventDefault();
+                choose(el, Number(items[el._gvActive].dataset.index), close);
+            } else if (e.key === "Escape") {
+                close();
+            }
+        });
+        // mousedown would blur the input and close the list before the click
+        list.addEventListener("mousedown", (e) => e.preventDefault());
+        list.addEventListener("click", (e) => {
+            const item = e.target.closest("li");
+            if (item) choose(el, Number(item.dataset.index), close);
+        });
+    }
+
+    function fillListbox(el, input, list) {
+        const props = el._gvControl;
+        const query = input.value.trim().toLocaleLowerCase(props.locale);
+        const selected = props.selected || [];
+        const items = [];
+        (props.options || []).forEach((opt, i) => {
+            if (query && !opt.label.toLocaleLowerCase(props.locale).includes(query)) return;
+            const item = document.createElement("li");
+            item.id = `${list.id}-${i}`;
+            item.dataset.index = String(i);
+            item.textContent = opt.label;
+            item.setAttribute("role", "option");
+            item.setAttribute("aria-selected", String(selected.includes(i)));
+            if (opt.disabled) item.setAttribute("aria-disabled", "true");
+            items.push(item);
+        });
+        list.replaceChildren(...items);
+        markActive(el, input, list);
+    }
+
+    function markActive(el, input, list) {
+        [...list.children].forEach((item, i) => {
+            const active = i === el._gvActive;
+            item.classList.toggle("gv-active", active);
+            if (active) {
+                input.setAttribute("aria-activedescendant", item.id);
+                item.scrollIntoView({ block: "nearest" });
+            }
+        });
+    }
+
+    function choose(el, index, close) {
+        const props = el._gvControl;
+        if (props.options[index]?.disabled) return;
+        if (!props.multiple) {
+            sendControl(el, "onChange", index);
+            close();
+            return;
+        }
+        const selected = new Set(props.selected || []);
+        if (selected.has(index)) selected.delete(index);
+        else selected.add(index);
+        sendControl(el, "onChange", { indices: [...selected].sort((a, b) => a - b) });
+    }
+
+    function setRange(input, props, value) {
+        input.min = props.min ?? 0;
+        input.max = props.max ?? 1;
+        input.step = props.step ?? "any";
+        if (!input._gvDragging && value !== undefined) input.value = value;
+        if (input._gvDragBound) return;
+        input._gvDragBound = true;
+        input.addEventListener("pointerdown", () => { input._gvDragging = true; });
+        input.addEventListener("change", () => { input._gvDragging = false; });
+    }
+
+    function bindControl(el, event, handler) {
+        if (el._gvControlBound) return;
+        el._gvControlBound = true;
+        el.addEventListener(event, handler);
+    }
+
+    function sendControl(el, key, value) {
+        const callback = el._gvControl[key];
+        if (!callback || isDisabled(el)) return;
+        window.GoInvokeCallback(callback, value === undefined ? {} : { value });
+    }
+
+    function isDisabled(el) {
+        return el.disabled || el.getAttribute("aria-disabled") === "true";
+    }
+
+    // Grid, its Span items and Stack (core/grid.go) are CSS grids: the
+    // tracks, spans and item alignment travel in props and become inline
+    // declarations; a Stack's children share its one cell.
+    const LAYOUTS = ["Grid", "GridItem", "Stack"];
+    let stackSheet = null;
+
+    function applyLayoutProps(el, type, props) {
+        el.dataset.gvLayout = type;
+        if (type === "GridItem") {
+            el.style.gridColumn = `span ${props.columns || 1}`;
+            el.style.gridRow = `span ${props.rows || 1}`;
+            return;
+        }
+        if (type === "Grid") el.style.gridTemplateColumns = gridTemplate(props.columns || []);
+        if (type === "Stack" && !stackSheet) {
+            stackSheet = document.createElement("style");
+            stackSheet.id = "govinci-stack";
+            stackSheet.textContent = "[data-gv-layout=Stack] > * { grid-area: 1 / 1; }";
+            document.head.appendChild(stackSheet);
+        }
+        el.style.justifyItems = props.justifyItems || "";
+        el.style.alignItems = props.alignItems || "";
+    }
+
+    function gridTemplate(tracks) {
+        return tracks.map(t => {
+            switch (t.kind) {
+                case "fixed": return `${t.size}px`;
+                case "adaptive": return `repeat(auto-fill, minmax(${t.min}px, ${t.max ? `${t.max}px` : "1fr"}))`;
+                default: return `minmax(0, ${t.size}fr)`;
+            }
+        }).join(" ");
+    }
+
+    // Feedback components (core/feedback.go) render as plain elements; the
+    // runtime ships the keyframes their theme Animation values name, the
+    // Spinner's ring and ARIA roles, and dismisses a Snackbar once its
+    // duration is up. The timer restarts only when the key changes, i.e.
+    // when the next snackbar in the queue shows.
+    const FEEDBACK = ["ProgressBar", "Spinner", "Skeleton", "Snackbar", "Avatar", "Badge"];
+    const FEEDBACK_EVENTS = ["onDismiss"];
+    const FEEDBACK_CSS = [
+        "@keyframes gv-spin { to { transform: rotate(360deg); } }",
+        "@keyframes gv-pulse { 50% { opacity: 0.4; } }",
+        "@keyframes gv-indeterminate { from { left: -40%; } to { left: 100%; } }",
+        "[data-gv-feedback=Spinner] { display: inline-block; box-sizing: border-box; border: 3px solid transparent; border-top-color: currentColor; border-right-color: currentColor; border-radius: 50%; }",
+        "[data-gv-feedback=Avatar] { box-sizing: border-box; flex-shrink: 0; }",
+        "[data-gv-feedback=Avatar] img { height: 100%; object-fit: cover; border-radius: inherit; }",
+        "[data-gv-feedback=Avatar] [data-slot=error] { position: absolute; inset: 0; display: flex; align-items: center; justify-content: center; }",
+        "[data-gv-feedback=Snackbar] > button { flex-shrink: 0; }",
+        "@media (prefers-reduced-motion: reduce) { [data-gv-feedback], [data-gv-feedback] > * { animation-duration: 3s !important; } }",
+    ];
+    let feedbackSheet = null;
+
+    function applyFeedbackProps(el, type, props) {
+        if (!feedbackSheet) {
+            const tag = document.createElement("style");
+            tag.id = "govinci-feedback";
+            tag.textContent = FEEDBACK_CSS.join("\n");
+            document.head.appendChild(tag);
+            feedbackSheet = tag;
+        }
+        el._gvFeedback = props;
+        el.dataset.gvFeedback = type;
+        switch (type) {
+            case "ProgressBar":
+                el.setAttribute("role", "progressbar");
+                setAttr(el, "aria-valuemin", props.indeterminate ? null : 0);
+                setAttr(el, "aria-valuemax", props.indeterminate ? null : 100);
+                setAttr(el, "aria-valuenow", props.indeterminate ? null : Math.round(props.progress * 100));
+                break;
+            case "Spinner":
+                el.setAttribute("role", "progressbar");
+                break;
+            case "Skeleton":
+                el.setAttribute("aria-hidden", "true");
+                break;
+            case "Snackbar":
+                el.setAttribute("role", "status");
+                el.setAttribute("aria-live", "polite");
+                if (el._gvSnack === props.key) break;
+                el._gvSnack = props.key;
+                clearTimeout(el._gvSnackTimer);
+                if (props.duration > 0) {
+                    el._gvSnackTimer = setTimeout(() => {
+                        if (el.isConnected && el._gvFeedback.onDismiss) window.GoInvokeCallback(el._gvFeedback.onDismiss, {});
+                    }, props.duration);
+                }
+                break;
+        }
+    }
+
+    // Dialogs (dialog package) are drawn over the page in the system colors
+    // and answered with the "dialog" system event: {id, confirmed, value,
+    // index}. Escape and a click on the backdrop cancel; Enter confirms a
+    // prompt. Focus goes back where it was once the dialog closes.
+    const DIALOG_CSS = [
+        "[data-gv-dialog] { position: fixed; inset: 0; z-index: 1002; display: flex; align-items: center; justify-content: center; background: rgba(0,0,0,0.4); font: 15px system-ui, sans-serif; }",
+        "[data-gv-dialog=actionSheet] { align-items: flex-end; }",
+        "[data-gv-dialog] > div { box-sizing: border-box; width: min(320px, 90%); padding: 20px; border-radius: 14px; background: Canvas; color: CanvasText; box-shadow: 0 8px 32px rgba(0,0,0,0.3); }",
+        "[data-gv-dialog=actionSheet] > div { width: min(480px, 100%); margin-bottom: 8px; padding: 8px; }",
+        "[data-gv-dialog] h2 { margin: 0 0 8px; font-size: 17px; }",
+        "[data-gv-dialog] p { margin: 0 0 16px; opacity: 0.8; }",
+        "[data-gv-dialog] input { box-sizing: border-box; width: 100%; margin: 0 0 16px; padding: 8px; font: inherit; }",
+        "[data-gv-dialog] nav { display: flex; justify-content: flex-end; gap: 8px; }",
+        "[data-gv-dialog=actionSheet] nav { flex-direction: column; }",
+        "[data-gv-dialog] button { padding: 8px 14px; border: none; border-radius: 8px; font: inherit; cursor: pointer; background: rgba(127,127,127,0.15); color: inherit; }",
+        "[data-gv-dialog] button[data-confirm] { background: #007AFF; color: #FFFFFF; }",
+        "[data-gv-dialog] button[data-destructive] { color: #FF3B30; }",
+        "[data-gv-dialog] button[data-confirm][data-destructive] { background: #FF3B30; color: #FFFFFF; }",
+    ];
+    let dialogSheet = null;
+
+    systemEventHandlers.dialog = (data) => {
+        if (!dialogSheet) {
+            dialogSheet = document.createElement("style");
+            dialogSheet.id = "govinci-dialogs";
+            dialogSheet.textContent = DIALOG_CSS.join("\n");
+            document.head.appendChild(dialogSheet);
+        }
+        const previous = document.activeElement;
+        const backdrop = document.createElement("div");
+        backdrop.dataset.gvDialog = data.kind;
+        const box = document.createElement("div");
+        box.setAttribute("role", data.kind === "alert" || data.kind === "confirm" ? "alertdialog" : "dialog");
+        box.setAttribute("aria-modal", "true");
+        backdrop.appendChild(box);
+
+        if (data.title) {
+            const title = document.createElement("h2");
+            title.id = `${data.id}-title`;
+            title.textContent = data.title;
+            box.setAttribute("aria-labelledby", title.id);
+            box.appendChild(title);
+        }
+        if (data.message) {
+            const message = document.createElement("p");
+            message.textContent = data.message;
+            box.appendChild(message);
+        }
+        let input = null;
+        if (data.kind === "prompt") {
+            input = document.createElement("input");
+            input.type = inputType(data);
+            setAttr(input, "inputmode", INPUT_MODES[data.keyboard]);
+            input.value = data.value || "";
+            input.placeholder = data.placeholder || "";
+            box.appendChild(input);
+        }
+
+        let answered = false;
+        const answer = (result) => {
+            if (answered) return;
+            answered = true;
+            backdrop.remove();
+            if (previous && previous.focus) previous.focus();
+            window.GovinciWASM.ReceiveSystemEvent("dialog", JSON.stringify({ id: data.id, confirmed: false, value: "", index: -1, ...result }));
+        };
+        const button = (label, onClick) => {
+            const b = document.createElement("button");
+            b.type = "button";
+            b.textContent = label;
+            b.addEventListener("click", onClick);
+            return b;
+        };
+
+        const nav = document.createElement("nav");
+        if (data.kind === "actionSheet") {
+            (data.actions || []).forEach((action, index) => {
+                const b = button(action.label, () => answer({ confirmed: true, index }));
+                if (action.destructive) b.dataset.destructive = "";
+                nav.appendChild(b);
+            });
+        }
+        if (data.cancel) nav.appendChild(button(data.cancel, () => answer({})));
+        if (data.kind !== "actionSheet") {
+            const confirm = button(data.confirm, () => answer({ confirmed: true, value: input ? input.value : "" }));
+            confirm.dataset.confirm = "";
+            if (data.destructive) confirm.dataset.destructive = "";
+            nav.appendChild(confirm);
+        }
+        box.appendChild(nav);
+
+        backdrop.addEventListener("click", (e) => {
+            if (e.target === backdrop) answer({});
+        });
+        backdrop.addEventListener("keydown", (e) => {
+            if (e.key === "Escape") answer({});
+            else if (e.key === "Enter" && input && e.target === input) answer({ confirmed: true, value: input.value });
+        });
+        document.body.appendChild(backdrop);
+        // Focusing blurs the input that opened the dialog, whose onBlur calls
+        // into Go; do it once Go has returned from sending the event.
+        queueMicrotask(() => (input || nav.lastChild || box).focus());
+    };
+
+    // ShowToast (core/toast.go): a message over the page for its duration,
+    // in the given style or a dark pill.
+    systemEventHandlers.toast = (data) => {
+        const toast = document.createElement("div");
+        toast.setAttribute("role", "status");
+        toast.textContent = data.message;
+        Object.assign(toast.style, {
+            position: "fixed", left: "50%", bottom: "32px", transform: "translateX(-50%)", zIndex: "1001",
+            maxWidth: "90%", padding: "10px 16px", borderRadius: "20px",
+            background: "rgba(50,50,50,0.92)", color: "#FFFFFF", font: "14px system-ui, sans-serif",
+            transition: "opacity 0.2s", pointerEvents: "none",
+        }, data.style ? styleFromGovinci(data.style) : {});
+        document.body.appendChild(toast);
+        setTimeout(() => {
+            toast.style.opacity = "0";
+            setTimeout(() => toast.remove(), 200);
+        }, data.duration || 2000);
+    };
+
     function edgeToCSS(edge) {
         return `${edge.Top}px ${edge.Right}px ${edge.Bottom}px ${edge.Left}px`;
     }
@@ -87,7 +1480,12 @@ const Govinci = (() => {
             case "Input":
             case "InputPassword":
             case "NumericInput":
-            case "Checkbox": return "input";
+            case "Checkbox":
+            case "Switch":
+            case "Slider":
+            case "DatePicker":
+            case "TimePicker": return "input";
+            case "Radio": return "label";
             case "TextArea": return "textarea";
             case "Button": return "button";
             case "Image": return "img";
@@ -133,16 +1531,26 @@ const Govinci = (() => {
         const patches = typeof patchList === "string" ? JSON.parse(patchList) : patchList;
 
         patches.forEach(p => {
-            const el = document.querySelector(`[data-node-path="${p.TargetID}"]`);
+            // add-child targets the new child's path; it goes into its parent
+            const path = p.Type === "add-child" ? p.TargetID.slice(0, p.TargetID.lastIndexOf("/")) : p.TargetID;
+            const el = document.querySelector(`[data-node-path="${path}"]`);
             if (!el) {
                 return;
             }
 
             switch (p.Type) {
                 case "update-props":
+                    applyStateProps(el, p.Changes);
+                    applyMotionProps(el, p.Changes, false);
+                    applyGestures(el, p.Changes);
+                    if (el.dataset.gvType) applyTypeProps(el, el.dataset.gvType, p.Changes);
+                    if (el.dataset.gvInput) applyInputProps(el, el.dataset.gvInput, p.Changes);
+                    if (el.dataset.gvControl) applyControlProps(el, el.dataset.gvControl, p.Changes);
+                    if (el.dataset.gvFeedback) applyFeedbackProps(el, el.dataset.gvFeedback, p.Changes);
+                    if (el.dataset.gvLayout) applyLayoutProps(el, el.dataset.gvLayout, p.Changes);
                     for (const [k, v] of Object.entries(p.Changes)) {
                         if (k === "value") {
-                            if (el.value === v) continue;
+                            if (el.dataset.gvInput || el.dataset.gvControl || el.value === v) continue;
                             el.value = v;
                         } else if (k === "content") {
                             if (el.textContent === v) continue;
@@ -150,7 +1558,11 @@ const Govinci = (() => {
                         } else if (k === "placeholder") {
                             if (el.placeholder === v) continue;
                             el.placeholder = v;
-                        } else if (k.startsWith("on")) {
+                        } else if (k === "src") {
+                            const img = el.tagName === "IMG" ? el : el.querySelector("img");
+                            if (!img || img.getAttribute("src") === v) continue;
+                            img.src = v;
+                        } else if (k.startsWith("on") && !ownsEvent(el, k)) {
                             const event = mapEventName(k);
                             const oldListenerId = el.dataset[`listener_${k}`];
 
@@ -161,6 +1573,7 @@ const Govinci = (() => {
                             }
 
                             const handler = (e) => {
+                                if (isDisabled(el)) return;
                                 const payload = extractEventPayload(e, el.tagName.toLowerCase());
                                 window.GoInvokeCallback(v, payload);
                             };
@@ -174,31 +1587,144 @@ const Govinci = (() => {
                     break;
 
 
+                case "update-class":
+                    el.className = p.Changes;
+                    break;
+
                 case "update-style":
                     Object.assign(el.style, styleFromGovinci(p.Changes));
+                    applyStateStyles(el, p.Changes);
+                    // the style's overflow would undo the scroll direction
+                    if (el.dataset.gvType === "Scroll") applyScrollProps(el, el._gvProps);
                     break;
 
                 case "replace":
                     const newEl = renderNode(p.Changes, p.TargetID);
-                    el.replaceWith(newEl);
+                    el.before(newEl);
+                    removeElement(el);
                     break;
 
                 case "remove":
-                    el.remove();
+                case "remove-child":
+                    removeElement(el);
                     break;
 
                 case "add-child":
-                    const index = el.children.length;
-                    const newChild = renderNode(p.Changes, `${p.TargetID}/${index}`);
-                    el.appendChild(newChild);
+                    // the diff only adds children after the existing ones
+                    el.appendChild(renderNode(p.Changes, p.TargetID));
                     break;
             }
         });
     }
 
+    // Reports the bundled asset manifest (written by `govinci assets`) and
+    // the device pixel ratio, so asset:// URLs resolve to the hashed @2x/@3x
+    // files. Resolves once Go has them; render after it.
+    function loadAssets() {
+        const report = manifest => {
+            const data = { density: window.devicePixelRatio || 1 };
+            if (manifest) data.manifest = manifest;
+            window.GovinciWASM.ReceiveSystemEvent("assets", JSON.stringify(data));
+        };
+        return fetch("assets/govinci-assets.json")
+            .then(response => (response.ok ? response.text() : ""))
+            .catch(() => "")
+            .then(report);
+    }
+
+    // Reports prefers-color-scheme to Go now and whenever it changes.
+    function watchAppearance() {
+        if (!window.matchMedia) return;
+        const query = window.matchMedia("(prefers-color-scheme: dark)");
+        const report = () => {
+            const colorScheme = query.matches ? "dark" : "light";
+            window.GovinciWASM.ReceiveSystemEvent("appearance", JSON.stringify({ colorScheme }));
+        };
+        report();
+        query.addEventListener("change", report);
+    }
+
+    // Reports the viewport size now and after every resize, at most once per
+    // frame; Go re-renders with the new breakpoint.
+    function watchWindowSize() {
+        let pending = false;
+        const report = () => {
+            pending = false;
+            const size = { width: window.innerWidth, height: window.innerHeight };
+            window.GovinciWASM.ReceiveSystemEvent("resize", JSON.stringify(size));
+        };
+        report();
+        window.addEventListener("resize", () => {
+            if (pending) return;
+            pending = true;
+            requestAnimationFrame(report);
+        });
+    }
+
+    // Reports the height the on-screen keyboard covers (the layout viewport
+    // below the visual one) and keeps the focused input of a keyboard-aware
+    // Scroll in view.
+    function watchKeyboard() {
+        const viewport = window.visualViewport;
+        if (!viewport) return;
+        let last = -1;
+        const report = () => {
+            if (viewport.scale > 1) return; // pinch zoom, not a keyboard
+            const height = Math.max(0, Math.round(window.innerHeight - viewport.height - viewport.offsetTop));
+            if (height === last) return;
+            last = height;
+            window.GovinciWASM.ReceiveSystemEvent("keyboard", JSON.stringify({ height }));
+            if (height > 0) requestAnimationFrame(revealFocused);
+        };
+        report();
+        viewport.addEventListener("resize", report);
+        document.addEventListener("focusin", () => requestAnimationFrame(revealFocused));
+    }
+
+    function revealFocused() {
+        const el = document.activeElement;
+        if (el && el.closest && el.closest("[data-keyboard-aware]")) {
+            el.scrollIntoView({ block: "nearest" });
+        }
+    }
+
+    // Rules compiled in Go (css.Compiler) arrive before the tree or patches
+    // that use their classes.
+    let compiledSheet = null;
+    function insertStyles(rules) {
+        if (!compiledSheet) {
+            const tag = document.createElement("style");
+            tag.id = "govinci-styles";
+            document.head.appendChild(tag);
+            compiledSheet = tag.sheet;
+        }
+        rules.forEach(rule => compiledSheet.insertRule(rule, compiledSheet.cssRules.length));
+    }
+
+    // Drops the rules of classes Go has evicted; media rules are matched by
+    // the selector of the rule inside them.
+    function removeStyles(classes) {
+        if (!compiledSheet) return;
+        const names = new Set(classes);
+        for (let i = compiledSheet.cssRules.length - 1; i >= 0; i--) {
+            const rule = compiledSheet.cssRules[i];
+            const selector = rule.selectorText || (rule.cssRules && rule.cssRules[0] && rule.cssRules[0].selectorText) || "";
+            const match = selector.match(/\.(gv-[\w-]+)/);
+            if (match && names.has(match[1])) compiledSheet.deleteRule(i);
+        }
+    }
+
     return {
         mount,
+        loadAssets,
+        insertStyles,
+        removeStyles,
+        watchAppearance,
+        watchWindowSize,
+        watchKeyboard,
         patch,
+        onSystemEvent,
+        dispatchSystemEvent,
     };
 })();
 
@@ -220,6 +1746,21 @@ function waitForWasm() {
 }
 waitForWasm();
 
+// Go sends system events in the middle of its own calls (an onClick running
+// CameraController.Capture, an onSubmit moving focus). Handlers run once Go
+// has returned, so a capture or a blur they trigger can call back into it.
+window.GovinciSystemEvent = function (name, data) {
+    queueMicrotask(() => Govinci.dispatchSystemEvent(name, data));
+};
+
+window.GovinciInsertStyles = function (rules) {
+    Govinci.insertStyles(rules);
+};
+
+window.GovinciRemoveStyles = function (classes) {
+    Govinci.removeStyles(classes);
+};
+
 
 window.GovinciRequestPermission = function (permission, callback) {
     if (permission === "camera") {
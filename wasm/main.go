@@ -4,7 +4,9 @@ package main
 
 import (
 	"encoding/json"
+	"github.com/GraHms/govinci/assets"
 	"github.com/GraHms/govinci/core"
+	"github.com/GraHms/govinci/css"
 	. "github.com/GraHms/govinci/examples/social"
 	"github.com/GraHms/govinci/hooks"
 	"github.com/GraHms/govinci/render"
@@ -17,10 +19,14 @@ var (
 
 var manager *render.Manager
 
+var styles = css.New()
+
 func renderInitial(this js.Value, args []js.Value) any {
 	manager = render.New(ctx, App) // `App` é tua função de root view
+	manager.UseStyleCompiler(styles)
 	hooks.ClearIntervals()
 	out := manager.RenderInitial()
+	flushStyles()
 	return js.ValueOf(out)
 }
 func RequestPermission(p Permission, onResult func(granted bool)) {
@@ -31,12 +37,15 @@ func RequestPermission(p Permission, onResult func(granted bool)) {
 	}))
 }
 
+// isDirty stays false until RenderInitial: the page reports the assets
+// asynchronously, and checkLoop may poll before the first render.
 func isDirty(this js.Value, args []js.Value) any {
-	return js.ValueOf(ctx.IsDirty())
+	return js.ValueOf(manager != nil && ctx.IsDirty())
 }
 
 func renderAgain(this js.Value, args []js.Value) any {
 	out := manager.RenderAgain()
+	flushStyles()
 	return js.ValueOf(out)
 }
 
@@ -66,24 +75,71 @@ func receiveEvent(this js.Value, args []js.Value) any {
 	return nil
 }
 
+func receiveSystemEvent(this js.Value, args []js.Value) any {
+	name := args[0].String()
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(args[1].String()), &data); err != nil {
+		println("Erro ao fazer parse do evento de sistema:", err.Error())
+		return nil
+	}
+
+	core.ReceiveSystemEvent(name, data)
+	ctx.MarkDirty()
+	return nil
+}
+
 func registerCallbacks() {
 	js.Global().Set("GovinciWASM", map[string]any{
 		"RenderInitial": js.FuncOf(renderInitial),
 		"RenderAgain":   js.FuncOf(renderAgain),
 		"ReceiveEvent":  js.FuncOf(receiveEvent),
 		"IsDirty":       js.FuncOf(isDirty),
+
+		"ReceiveSystemEvent": js.FuncOf(receiveSystemEvent),
 	})
 }
 
+// flushStyles injects the CSS rules created by the last render before the
+// tree or patches that reference them are applied, and removes the ones no
+// longer on screen so the sheet does not grow with every distinct style.
+func flushStyles() {
+	if rules := styles.Drain(); len(rules) > 0 {
+		js.Global().Call("GovinciInsertStyles", js.ValueOf(toList(rules)))
+	}
+	if classes := styles.Evict(); len(classes) > 0 {
+		js.Global().Call("GovinciRemoveStyles", js.ValueOf(toList(classes)))
+	}
+}
+
+func toList(items []string) []any {
+	list := make([]any, len(items))
+	for i, item := range items {
+		list[i] = item
+	}
+	return list
+}
+
+func forwardSystemEvent(name string, data map[string]any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		println("Erro ao serializar evento de sistema:", err.Error())
+		return
+	}
+	js.Global().Call("GovinciSystemEvent", name, string(payload))
+}
+
 func main() {
 	c := make(chan struct{}, 0)
 	registerCallbacks()
+	assets.Watch(assets.TargetWasm)
+	core.SetSystemEventHandler(forwardSystemEvent)
 	println("Govinci WASM ready.")
 	<-c
 }
 
 func App(ctx *core.Context) core.View {
-	return core.Navigator(func(ctx *core.Context) core.View {
+	return core.WithThemePair(core.DefaultThemes, core.Navigator(func(ctx *core.Context) core.View {
 		currentTab := core.NewState(ctx, "home")
 
 		return core.Column(
@@ -98,7 +154,7 @@ func App(ctx *core.Context) core.View {
 				TabButton("👤", "profile", currentTab),
 			),
 		)
-	})
+	}))
 }
 
 func TabsComponent(ctx *core.Context, activeTab core.State[string]) core.View {